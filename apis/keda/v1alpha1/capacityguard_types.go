@@ -0,0 +1,35 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// CapacityGuard temporarily caps the replica count of a ScaledObject, or the count of Jobs created
+// by a ScaledJob, to the pods the cluster nodes can schedule, based on node allocatable and the
+// requests of the pods already running on the nodes. The nodes and the pods of all the namespaces are read
+// from the API server, so the operator needs to list them cluster wide, also when WATCH_NAMESPACE is set.
+type CapacityGuard struct {
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Headroom is the count of pods allowed on top of the schedulable ones,
+	// so the cluster autoscaler still sees Pending pods and adds nodes
+	// +optional
+	Headroom int32 `json:"headroom,omitempty"`
+}
+
+// IsCapacityGuardEnabled returns whether the capacity guard is enabled
+func (g *CapacityGuard) IsCapacityGuardEnabled() bool {
+	return g != nil && g.Enabled
+}
@@ -34,6 +34,8 @@ const (
 	ConditionFallback ConditionType = "Fallback"
 	// ConditionPaused specifies that the resource is paused.
 	ConditionPaused ConditionType = "Paused"
+	// ConditionCapacityLimited specifies that the scaling of the resource is capped by the cluster capacity.
+	ConditionCapacityLimited ConditionType = "CapacityLimited"
 )
 
 const (
@@ -88,6 +90,7 @@ func (c *Conditions) AreInitialized() bool {
 	foundActive := false
 	foundFallback := false
 	foundPaused := false
+	foundCapacityLimited := false
 	if *c != nil {
 		for _, condition := range *c {
 			if condition.Type == ConditionReady {
@@ -113,14 +116,20 @@ func (c *Conditions) AreInitialized() bool {
 				break
 			}
 		}
+		for _, condition := range *c {
+			if condition.Type == ConditionCapacityLimited {
+				foundCapacityLimited = true
+				break
+			}
+		}
 	}
 
-	return foundReady && foundActive && foundFallback && foundPaused
+	return foundReady && foundActive && foundFallback && foundPaused && foundCapacityLimited
 }
 
 // GetInitializedConditions returns Conditions initialized to the default -> Status: Unknown
 func GetInitializedConditions() *Conditions {
-	return &Conditions{{Type: ConditionReady, Status: metav1.ConditionUnknown}, {Type: ConditionActive, Status: metav1.ConditionUnknown}, {Type: ConditionFallback, Status: metav1.ConditionUnknown}, {Type: ConditionPaused, Status: metav1.ConditionUnknown}}
+	return &Conditions{{Type: ConditionReady, Status: metav1.ConditionUnknown}, {Type: ConditionActive, Status: metav1.ConditionUnknown}, {Type: ConditionFallback, Status: metav1.ConditionUnknown}, {Type: ConditionPaused, Status: metav1.ConditionUnknown}, {Type: ConditionCapacityLimited, Status: metav1.ConditionUnknown}}
 }
 
 // IsTrue is true if the condition is True
@@ -179,6 +188,14 @@ func (c *Conditions) SetPausedCondition(status metav1.ConditionStatus, reason st
 	c.setCondition(ConditionPaused, status, reason, message)
 }
 
+// SetCapacityLimitedCondition modifies CapacityLimited Condition according to input parameters
+func (c *Conditions) SetCapacityLimitedCondition(status metav1.ConditionStatus, reason string, message string) {
+	if *c == nil {
+		c = GetInitializedConditions()
+	}
+	c.setCondition(ConditionCapacityLimited, status, reason, message)
+}
+
 // GetActiveCondition returns Condition of type Active
 func (c *Conditions) GetActiveCondition() Condition {
 	if *c == nil {
@@ -211,6 +228,14 @@ func (c *Conditions) GetPausedCondition() Condition {
 	return c.getCondition(ConditionPaused)
 }
 
+// GetCapacityLimitedCondition returns Condition of type CapacityLimited
+func (c *Conditions) GetCapacityLimitedCondition() Condition {
+	if *c == nil {
+		c = GetInitializedConditions()
+	}
+	return c.getCondition(ConditionCapacityLimited)
+}
+
 func (c Conditions) getCondition(conditionType ConditionType) Condition {
 	for i := range c {
 		if c[i].Type == conditionType {
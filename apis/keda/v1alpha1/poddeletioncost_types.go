@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PodDeletionCostAnnotation is the annotation the ReplicaSet controller reads to pick the pods removed
+// first when the replicas are lowered, the pods with the lowest cost go first
+const PodDeletionCostAnnotation = "controller.kubernetes.io/pod-deletion-cost"
+
+// PodDeletionCost writes the pod deletion cost of the pods of the scale target on every polling interval from a
+// per-pod work signal, so the idle pods are removed first when the HPA or KEDA lowers the replicas.
+// The signal is either reported by a pod-metrics trigger of the ScaledObject or scraped with the metadata of a
+// pod-metrics trigger the ScaledObject doesn't scale on.
+type PodDeletionCost struct {
+	// TriggerName is the name of the pod-metrics trigger reporting the signal
+	// +optional
+	TriggerName string `json:"triggerName,omitempty"`
+	// Metadata is the metadata of a pod-metrics trigger scraping the signal, without the targetValue
+	// +optional
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// AuthenticationRef is the TriggerAuthentication of the pods scraped with the metadata
+	// +optional
+	AuthenticationRef *AuthenticationRef `json:"authenticationRef,omitempty"`
+}
+
+// GetPodDeletionCost returns the pod deletion cost of the ScaledObject or nil if it isn't defined
+func (so *ScaledObject) GetPodDeletionCost() *PodDeletionCost {
+	if so.Spec.Advanced == nil {
+		return nil
+	}
+	return so.Spec.Advanced.PodDeletionCost
+}
+
+// CheckPodDeletionCostValid checks that the scale target is a Deployment or a ReplicaSet, whose ReplicaSet controller
+// reads the pod deletion cost, and that the signal is either a pod-metrics trigger of the ScaledObject or the metadata
+// of a pod-metrics trigger
+func CheckPodDeletionCostValid(scaledObject *ScaledObject) error {
+	cost := scaledObject.GetPodDeletionCost()
+	if cost == nil {
+		return nil
+	}
+	if !isReplicaSetScaleTarget(scaledObject.Spec.ScaleTargetRef) {
+		return fmt.Errorf("podDeletionCost requires a Deployment or a ReplicaSet scale target, the pod deletion cost isn't read by the controller of %s", scaledObject.Spec.ScaleTargetRef.Kind)
+	}
+	if (cost.TriggerName == "") == (len(cost.Metadata) == 0) {
+		return fmt.Errorf("podDeletionCost requires either a triggerName or metadata")
+	}
+	if cost.TriggerName == "" {
+		return nil
+	}
+	if cost.AuthenticationRef != nil {
+		return fmt.Errorf("podDeletionCost authenticationRef is only used with metadata, the trigger %s has its own", cost.TriggerName)
+	}
+	for _, trigger := range scaledObject.Spec.Triggers {
+		if trigger.Name != cost.TriggerName {
+			continue
+		}
+		if trigger.Type != "pod-metrics" {
+			return fmt.Errorf("podDeletionCost trigger %s is of type %s, only pod-metrics triggers report per-pod values", cost.TriggerName, trigger.Type)
+		}
+		return nil
+	}
+	return fmt.Errorf("podDeletionCost trigger %s isn't a trigger of the ScaledObject", cost.TriggerName)
+}
+
+// isReplicaSetScaleTarget returns whether the scale target is a Deployment, the default, or a ReplicaSet of the apps group
+func isReplicaSetScaleTarget(scaleTarget *ScaleTarget) bool {
+	if scaleTarget == nil {
+		return false
+	}
+	if scaleTarget.APIVersion != "" && !strings.HasPrefix(scaleTarget.APIVersion, "apps/") {
+		return false
+	}
+	return scaleTarget.Kind == "" || scaleTarget.Kind == "Deployment" || scaleTarget.Kind == "ReplicaSet"
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=scaledgroups,scope=Namespaced,shortName=sg
+// +kubebuilder:printcolumn:name="Members",type="integer",JSONPath=".status.memberCount"
+// +kubebuilder:printcolumn:name="Triggers",type="string",JSONPath=".spec.triggers[*].type"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ScaledGroup selects a set of workloads by label and puts all of them to sleep
+// and wakes them up together, based on a shared set of triggers
+type ScaledGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ScaledGroupSpec `json:"spec"`
+	// +optional
+	Status ScaledGroupStatus `json:"status,omitempty"`
+}
+
+const (
+	// ScaledGroupNameLabel is set on every ScaledObject generated for a ScaledGroup member
+	ScaledGroupNameLabel = "scaledgroup.keda.sh/name"
+	// ScaledGroupWakeAfterAnnotation lists the ScaledObjects whose scale targets need to be ready
+	// before the scale target of the annotated ScaledObject is woken up
+	ScaledGroupWakeAfterAnnotation = "scaledgroup.keda.sh/wake-after"
+	// ScaledGroupOriginalReplicasAnnotation records on the generated ScaledObject the replica count
+	// the member is woken up to, so it isn't lost together with the ScaledGroup status
+	ScaledGroupOriginalReplicasAnnotation = "scaledgroup.keda.sh/original-replicas"
+)
+
+// ScaledGroupSpec is the spec for a ScaledGroup resource
+type ScaledGroupSpec struct {
+	// Selector selects the Deployments and StatefulSets in the ScaledGroup namespace
+	// which are members of the group
+	Selector metav1.LabelSelector `json:"selector"`
+	// +optional
+	PollingInterval *int32 `json:"pollingInterval,omitempty"`
+	// +optional
+	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
+	// +optional
+	InitialCooldownPeriod int32 `json:"initialCooldownPeriod,omitempty"`
+	// WakeOrder splits the members into stages, every stage is woken up only once
+	// the members of the previous stage are ready. Members not matching any stage
+	// are woken up last
+	// +optional
+	WakeOrder []WakeStage `json:"wakeOrder,omitempty"`
+
+	Triggers []ScaleTriggers `json:"triggers"`
+}
+
+// WakeStage selects the ScaledGroup members which are woken up together
+type WakeStage struct {
+	Selector metav1.LabelSelector `json:"selector"`
+}
+
+// ScaledGroupStatus is the status for a ScaledGroup resource
+// +optional
+type ScaledGroupStatus struct {
+	// +optional
+	Members []ScaledGroupMember `json:"members,omitempty"`
+	// +optional
+	MemberCount int32 `json:"memberCount,omitempty"`
+	// +optional
+	Conditions Conditions `json:"conditions,omitempty"`
+}
+
+// ScaledGroupMember records a workload managed by the ScaledGroup
+type ScaledGroupMember struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// ScaledObjectName is the name of the ScaledObject generated for the member
+	ScaledObjectName string `json:"scaledObjectName"`
+	// OriginalReplicaCount is the replica count of the member when it joined the group,
+	// the member is woken up to this replica count
+	OriginalReplicaCount int32 `json:"originalReplicaCount"`
+	// +optional
+	WakeStage int32 `json:"wakeStage,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ScaledGroupList is a list of ScaledGroup resources
+type ScaledGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ScaledGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ScaledGroup{}, &ScaledGroupList{})
+}
+
+// GenerateIdentifier returns identifier for the object in for "kind.namespace.name"
+func (sg *ScaledGroup) GenerateIdentifier() string {
+	return GenerateIdentifier("ScaledGroup", sg.Namespace, sg.Name)
+}
+
+// GetMember returns the member recorded in the status for the given workload or nil if it isn't found
+func (sg *ScaledGroup) GetMember(kind, name string) *ScaledGroupMember {
+	for i := range sg.Status.Members {
+		if sg.Status.Members[i].Kind == kind && sg.Status.Members[i].Name == name {
+			return &sg.Status.Members[i]
+		}
+	}
+	return nil
+}
+
+// ScaledObjectNameForMember returns the name of the ScaledObject generated for the given workload
+func (sg *ScaledGroup) ScaledObjectNameForMember(kind, name string) string {
+	return fmt.Sprintf("%s-%s-%s", sg.Name, strings.ToLower(kind), name)
+}
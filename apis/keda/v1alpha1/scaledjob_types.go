@@ -17,6 +17,10 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"encoding/json"
+	"hash/fnv"
+	"strconv"
+
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -26,9 +30,17 @@ const (
 	defaultScaledJobMinReplicaCount = 0
 )
 
+const (
+	// ScaledJobNameLabel labels the Jobs, and their pods, with the name of the ScaledJob they were created for
+	ScaledJobNameLabel = "scaledjob.keda.sh/name"
+	// ScaledJobVersionLabel labels the Jobs with the version of the ScaledJob they were created from
+	ScaledJobVersionLabel = "scaledjob.keda.sh/version"
+)
+
 // +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:resource:path=scaledjobs,scope=Namespaced,shortName=sj
 // +kubebuilder:printcolumn:name="Min",type="integer",JSONPath=".spec.minReplicaCount"
 // +kubebuilder:printcolumn:name="Max",type="integer",JSONPath=".spec.maxReplicaCount"
@@ -69,7 +81,9 @@ type ScaledJobSpec struct {
 	MaxReplicaCount *int32 `json:"maxReplicaCount,omitempty"`
 	// +optional
 	ScalingStrategy ScalingStrategy `json:"scalingStrategy,omitempty"`
-	Triggers        []ScaleTriggers `json:"triggers"`
+	// +optional
+	CapacityGuard *CapacityGuard  `json:"capacityGuard,omitempty"`
+	Triggers      []ScaleTriggers `json:"triggers"`
 }
 
 // ScaledJobStatus defines the observed state of ScaledJob
@@ -81,6 +95,25 @@ type ScaledJobStatus struct {
 	Conditions Conditions `json:"conditions,omitempty"`
 	// +optional
 	Paused string `json:"Paused,omitempty"`
+	// +optional
+	Rollout *ScaledJobRolloutStatus `json:"rollout,omitempty"`
+}
+
+// ScaledJobRolloutStatus tracks the current version of the ScaledJob and the Jobs of every version still around
+type ScaledJobRolloutStatus struct {
+	CurrentVersion string `json:"currentVersion"`
+	// +optional
+	RolloutTime *metav1.Time `json:"rolloutTime,omitempty"`
+	// +optional
+	Versions []ScaledJobVersionStatus `json:"versions,omitempty"`
+}
+
+// ScaledJobVersionStatus counts the Jobs created from a version of the ScaledJob
+type ScaledJobVersionStatus struct {
+	Version   string `json:"version"`
+	Running   int32  `json:"running"`
+	Succeeded int32  `json:"succeeded"`
+	Failed    int32  `json:"failed"`
 }
 
 // ScaledJobList contains a list of ScaledJob
@@ -104,6 +137,10 @@ type ScalingStrategy struct {
 	PendingPodConditions []string `json:"pendingPodConditions,omitempty"`
 	// +optional
 	MultipleScalersCalculation string `json:"multipleScalersCalculation,omitempty"`
+	// ExcludePreviousVersions leaves the Jobs of the previous versions of the ScaledJob
+	// out of the running and pending Job counts
+	// +optional
+	ExcludePreviousVersions bool `json:"excludePreviousVersions,omitempty"`
 }
 
 // Rollout defines the strategy for job rollouts
@@ -113,6 +150,21 @@ type Rollout struct {
 	Strategy string `json:"strategy,omitempty"`
 	// +optional
 	PropagationPolicy string `json:"propagationPolicy,omitempty"`
+	// +optional
+	StopPreviousVersion *StopPreviousVersion `json:"stopPreviousVersion,omitempty"`
+}
+
+// StopPreviousVersion defines when the Jobs of the previous versions still running are stopped with the
+// gradual strategy, they are stopped as soon as one of the conditions is met
+// +optional
+type StopPreviousVersion struct {
+	// GracePeriod after the rollout of the current version
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+	// SucceededJobs of the current version
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SucceededJobs *int32 `json:"succeededJobs,omitempty"`
 }
 
 func init() {
@@ -146,3 +198,30 @@ func (s ScaledJob) MinReplicaCount() int64 {
 func (s *ScaledJob) GenerateIdentifier() string {
 	return GenerateIdentifier("ScaledJob", s.Namespace, s.Name)
 }
+
+// GetRolloutStrategy returns the strategy for job rollouts, the deprecated RolloutStrategy takes precedence
+func (s *ScaledJob) GetRolloutStrategy() string {
+	if len(s.Spec.RolloutStrategy) > 0 {
+		return s.Spec.RolloutStrategy
+	}
+	return s.Spec.Rollout.Strategy
+}
+
+// Version returns the hash of the job template, identifying the Jobs created from this version of the ScaledJob
+func (s *ScaledJob) Version() string {
+	if s.Spec.JobTargetRef == nil {
+		return ""
+	}
+	jobSpec := s.Spec.JobTargetRef.DeepCopy()
+	// the scale executor sets these on the template of the ScaledJob it creates the Jobs from
+	jobSpec.Template.GenerateName = ""
+	delete(jobSpec.Template.Labels, ScaledJobNameLabel)
+	if len(jobSpec.Template.Labels) == 0 {
+		jobSpec.Template.Labels = nil
+	}
+
+	data, _ := json.Marshal(jobSpec)
+	hasher := fnv.New32a()
+	_, _ = hasher.Write(data)
+	return strconv.FormatUint(uint64(hasher.Sum32()), 16)
+}
@@ -18,6 +18,9 @@ package v1alpha1
 
 import (
 	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestScaledJob(t *testing.T) {
@@ -93,6 +96,34 @@ func TestScaledJob(t *testing.T) {
 	}
 }
 
+func TestScaledJobVersion(t *testing.T) {
+	scaledJob := &ScaledJob{
+		Spec: ScaledJobSpec{
+			JobTargetRef: &batchv1.JobSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "worker", Image: "worker:1"}}},
+				},
+			},
+		},
+	}
+	version := scaledJob.Version()
+	if version == "" {
+		t.Fatal("Version() is empty")
+	}
+
+	// the fields set by the scale executor on the template don't change the version
+	scaledJob.Spec.JobTargetRef.Template.GenerateName = "test-"
+	scaledJob.Spec.JobTargetRef.Template.Labels = map[string]string{ScaledJobNameLabel: "test"}
+	if scaledJob.Version() != version {
+		t.Errorf("Version()=%s after the template was labelled, expected %s", scaledJob.Version(), version)
+	}
+
+	scaledJob.Spec.JobTargetRef.Template.Spec.Containers[0].Image = "worker:2"
+	if scaledJob.Version() == version {
+		t.Errorf("Version()=%s after the template changed, expected a new version", version)
+	}
+}
+
 func int32Ptr(i int32) *int32 {
 	return &i
 }
@@ -31,6 +31,7 @@ import (
 var scaledjoblog = logf.Log.WithName("scaledjob-validation-webhook")
 
 func (s *ScaledJob) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	kc = mgr.GetClient()
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(s).
 		Complete()
@@ -44,7 +45,7 @@ var _ webhook.Validator = &ScaledJob{}
 func (s *ScaledJob) ValidateCreate() (admission.Warnings, error) {
 	val, _ := json.MarshalIndent(s, "", "  ")
 	scaledjoblog.Info(fmt.Sprintf("validating scaledjob creation for %s", string(val)))
-	return nil, verifyTriggers(s, "create", false)
+	return nil, verifyScaledJob(s, "create")
 }
 
 func (s *ScaledJob) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
@@ -56,13 +57,20 @@ func (s *ScaledJob) ValidateUpdate(old runtime.Object) (admission.Warnings, erro
 		scaledjoblog.V(1).Info("finalizer removal, skipping validation")
 		return nil, nil
 	}
-	return nil, verifyTriggers(s, "update", false)
+	return nil, verifyScaledJob(s, "update")
 }
 
 func (s *ScaledJob) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
 
+func verifyScaledJob(s *ScaledJob, action string) error {
+	if err := verifyTriggers(s, action, false); err != nil {
+		return err
+	}
+	return verifyAuthenticationRefs(s, action, false)
+}
+
 func isScaledJobRemovingFinalizer(om metav1.ObjectMeta, oldOm metav1.ObjectMeta, spec ScaledJobSpec, oldSpec ScaledJobSpec) bool {
 	taSpec, _ := json.MarshalIndent(spec, "", "  ")
 	oldTaSpec, _ := json.MarshalIndent(oldSpec, "", "  ")
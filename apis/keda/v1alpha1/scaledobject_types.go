@@ -18,16 +18,19 @@ package v1alpha1
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:resource:path=scaledobjects,scope=Namespaced,shortName=so
 // +kubebuilder:printcolumn:name="ScaleTargetKind",type="string",JSONPath=".status.scaleTargetKind"
 // +kubebuilder:printcolumn:name="ScaleTargetName",type="string",JSONPath=".spec.scaleTargetRef.name"
@@ -103,6 +106,24 @@ type ScaledObjectSpec struct {
 	Fallback *Fallback `json:"fallback,omitempty"`
 	// +optional
 	InitialCooldownPeriod int32 `json:"initialCooldownPeriod,omitempty"`
+	// +optional
+	Followers []Follower `json:"followers,omitempty"`
+}
+
+// Follower is a scale target kept at a replica count derived from the replica count of the ScaledObject scale target,
+// computed as ceil(leader replicas * ratio) + offset and bounded by the min and max replica count.
+// A follower of a scale target scaled to zero is scaled to its minReplicaCount.
+type Follower struct {
+	ScaleTargetRef ScaleTarget `json:"scaleTargetRef"`
+	// Ratio is a decimal number greater than zero, it defaults to 1
+	// +optional
+	Ratio string `json:"ratio,omitempty"`
+	// +optional
+	Offset int32 `json:"offset,omitempty"`
+	// +optional
+	MinReplicaCount *int32 `json:"minReplicaCount,omitempty"`
+	// +optional
+	MaxReplicaCount *int32 `json:"maxReplicaCount,omitempty"`
 }
 
 // Fallback is the spec for fallback options
@@ -119,6 +140,10 @@ type AdvancedConfig struct {
 	RestoreToOriginalReplicaCount bool `json:"restoreToOriginalReplicaCount,omitempty"`
 	// +optional
 	ScalingModifiers ScalingModifiers `json:"scalingModifiers,omitempty"`
+	// +optional
+	CapacityGuard *CapacityGuard `json:"capacityGuard,omitempty"`
+	// +optional
+	PodDeletionCost *PodDeletionCost `json:"podDeletionCost,omitempty"`
 }
 
 // ScalingModifiers describes advanced scaling logic options like formula
@@ -177,6 +202,53 @@ type ScaledObjectStatus struct {
 	PausedReplicaCount *int32 `json:"pausedReplicaCount,omitempty"`
 	// +optional
 	HpaName string `json:"hpaName,omitempty"`
+	// +optional
+	Followers []FollowerStatus `json:"followers,omitempty"`
+	// CapacityLimitedReplicas is the replica count the HPA max replicas is temporarily capped to by the capacity guard
+	// +optional
+	CapacityLimitedReplicas *int32 `json:"capacityLimitedReplicas,omitempty"`
+	// Triggers is the live state of the triggers, in the order of spec.triggers
+	// +optional
+	Triggers []TriggerStatus `json:"triggers,omitempty"`
+	// LastScaleTime is the last time the scale target was scaled, by KEDA or by the HPA
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+	// DesiredReplicas is the replica count last requested for the scale target, by KEDA or by the HPA
+	// +optional
+	DesiredReplicas *int32 `json:"desiredReplicas,omitempty"`
+}
+
+// TriggerStatus is the live state of a trigger of a ScaledObject. The cpu and memory triggers are evaluated
+// by the HPA, only their target is reported.
+type TriggerStatus struct {
+	// +optional
+	Name string `json:"name,omitempty"`
+	Type string `json:"type"`
+	// +optional
+	MetricName string `json:"metricName,omitempty"`
+	// Value is the metric value of the last successful poll
+	// +optional
+	Value *resource.Quantity `json:"value,omitempty"`
+	// Target is the target value of the metric, or the target utilization for cpu and memory triggers
+	// +optional
+	Target *resource.Quantity `json:"target,omitempty"`
+	// Active is set when the last successful poll found the trigger active
+	// +optional
+	Active bool `json:"active,omitempty"`
+	// +optional
+	LastSuccessfulPollTime *metav1.Time `json:"lastSuccessfulPollTime,omitempty"`
+	// LastError is the error of the last poll, it's cleared by the next successful poll
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// Fallback is set when the metric of the trigger is replaced by the fallback replicas
+	// +optional
+	Fallback bool `json:"fallback,omitempty"`
+}
+
+// FollowerStatus holds the resolved scale target of a follower
+type FollowerStatus struct {
+	Name            string                   `json:"name"`
+	ScaleTargetGVKR GroupVersionKindResource `json:"scaleTargetGVKR"`
 }
 
 // +kubebuilder:object:root=true
@@ -250,6 +322,14 @@ func (so *ScaledObject) GetHPAMaxReplicas() int32 {
 	return defaultHPAMaxReplicas
 }
 
+// GetCapacityGuard returns the capacity guard of the ScaledObject or nil if it isn't defined
+func (so *ScaledObject) GetCapacityGuard() *CapacityGuard {
+	if so.Spec.Advanced == nil {
+		return nil
+	}
+	return so.Spec.Advanced.CapacityGuard
+}
+
 // checkReplicaCountBoundsAreValid checks that Idle/Min/Max ReplicaCount defined in ScaledObject are correctly specified
 // i.e. that Min is not greater than Max or Idle greater or equal to Min
 func CheckReplicaCountBoundsAreValid(scaledObject *ScaledObject) error {
@@ -270,6 +350,85 @@ func CheckReplicaCountBoundsAreValid(scaledObject *ScaledObject) error {
 	return nil
 }
 
+// CheckFollowersValid checks that the followers of the ScaledObject have a valid ratio and replica count bounds
+// and that none of them targets the ScaledObject scale target or is listed twice
+func CheckFollowersValid(scaledObject *ScaledObject) error {
+	seen := map[string]bool{}
+	if scaledObject.Spec.ScaleTargetRef != nil {
+		seen[scaleTargetKey(scaledObject.Spec.ScaleTargetRef)] = true
+	}
+	for i := range scaledObject.Spec.Followers {
+		follower := &scaledObject.Spec.Followers[i]
+		if follower.ScaleTargetRef.Name == "" {
+			return fmt.Errorf("followers[%d].scaleTargetRef.name is missing", i)
+		}
+		key := scaleTargetKey(&follower.ScaleTargetRef)
+		if seen[key] {
+			return fmt.Errorf("followers[%d] %s is the ScaledObject scale target or another follower", i, key)
+		}
+		seen[key] = true
+
+		if _, err := follower.GetRatio(); err != nil {
+			return fmt.Errorf("followers[%d]: %w", i, err)
+		}
+		if follower.MinReplicaCount != nil && *follower.MinReplicaCount < 0 {
+			return fmt.Errorf("followers[%d]: MinReplicaCount=%d must not be negative", i, *follower.MinReplicaCount)
+		}
+		if follower.MinReplicaCount != nil && follower.MaxReplicaCount != nil && *follower.MinReplicaCount > *follower.MaxReplicaCount {
+			return fmt.Errorf("followers[%d]: MinReplicaCount=%d must be less than MaxReplicaCount=%d", i, *follower.MinReplicaCount, *follower.MaxReplicaCount)
+		}
+	}
+	return nil
+}
+
+// GetRatio returns the parsed ratio of the follower
+func (f *Follower) GetRatio() (float64, error) {
+	if f.Ratio == "" {
+		return 1, nil
+	}
+	ratio, err := strconv.ParseFloat(f.Ratio, 64)
+	if err != nil {
+		return 0, fmt.Errorf("ratio %q is not a number: %w", f.Ratio, err)
+	}
+	if ratio <= 0 || math.IsInf(ratio, 0) || math.IsNaN(ratio) {
+		return 0, fmt.Errorf("ratio %q must be greater than zero", f.Ratio)
+	}
+	return ratio, nil
+}
+
+// GetDesiredReplicas returns the replica count of the follower for the given replica count of its leader
+func (f *Follower) GetDesiredReplicas(leaderReplicas int32) (int32, error) {
+	ratio, err := f.GetRatio()
+	if err != nil {
+		return 0, err
+	}
+
+	replicas := int32(0)
+	if leaderReplicas > 0 {
+		replicas = int32(math.Ceil(float64(leaderReplicas)*ratio)) + f.Offset
+	}
+	if f.MaxReplicaCount != nil && replicas > *f.MaxReplicaCount {
+		replicas = *f.MaxReplicaCount
+	}
+	minReplicas := int32(0)
+	if f.MinReplicaCount != nil {
+		minReplicas = *f.MinReplicaCount
+	}
+	if replicas < minReplicas {
+		replicas = minReplicas
+	}
+	return replicas, nil
+}
+
+// scaleTargetKey returns "kind/name" of the scale target, with the kind defaulted
+func scaleTargetKey(target *ScaleTarget) string {
+	kind := target.Kind
+	if kind == "" {
+		kind = defaultKind
+	}
+	return kind + "/" + target.Name
+}
+
 // CheckFallbackValid checks that the fallback supports scalers with an AverageValue metric target.
 // Consequently, it does not support CPU & memory scalers, or scalers targeting a Value metric type.
 func CheckFallbackValid(scaledObject *ScaledObject) error {
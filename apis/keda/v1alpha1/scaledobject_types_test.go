@@ -0,0 +1,297 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+)
+
+func TestFollowerGetDesiredReplicas(t *testing.T) {
+	tests := []struct {
+		name           string
+		follower       Follower
+		leaderReplicas int32
+		expected       int32
+		expectedError  bool
+	}{
+		{
+			name:           "ratio defaults to 1",
+			follower:       Follower{},
+			leaderReplicas: 3,
+			expected:       3,
+		},
+		{
+			name:           "fractional ratio is rounded up",
+			follower:       Follower{Ratio: "0.25"},
+			leaderReplicas: 5,
+			expected:       2,
+		},
+		{
+			name:           "ratio greater than 1",
+			follower:       Follower{Ratio: "2"},
+			leaderReplicas: 3,
+			expected:       6,
+		},
+		{
+			name:           "offset is added after the ratio",
+			follower:       Follower{Ratio: "0.25", Offset: 1},
+			leaderReplicas: 4,
+			expected:       2,
+		},
+		{
+			name:           "negative offset is bounded by zero",
+			follower:       Follower{Offset: -3},
+			leaderReplicas: 1,
+			expected:       0,
+		},
+		{
+			name:           "max replica count bounds the replicas",
+			follower:       Follower{Ratio: "2", MaxReplicaCount: int32Ptr(5)},
+			leaderReplicas: 10,
+			expected:       5,
+		},
+		{
+			name:           "min replica count bounds the replicas",
+			follower:       Follower{Ratio: "0.1", MinReplicaCount: int32Ptr(2)},
+			leaderReplicas: 1,
+			expected:       2,
+		},
+		{
+			name:           "leader scaled to zero scales the follower to zero",
+			follower:       Follower{Ratio: "0.25", Offset: 2},
+			leaderReplicas: 0,
+			expected:       0,
+		},
+		{
+			name:           "leader scaled to zero scales the follower to min replica count",
+			follower:       Follower{Offset: 2, MinReplicaCount: int32Ptr(1)},
+			leaderReplicas: 0,
+			expected:       1,
+		},
+		{
+			name:           "ratio which isn't a number",
+			follower:       Follower{Ratio: "a quarter"},
+			leaderReplicas: 1,
+			expectedError:  true,
+		},
+		{
+			name:           "ratio equal to zero",
+			follower:       Follower{Ratio: "0"},
+			leaderReplicas: 1,
+			expectedError:  true,
+		},
+		{
+			name:           "negative ratio",
+			follower:       Follower{Ratio: "-1"},
+			leaderReplicas: 1,
+			expectedError:  true,
+		},
+		{
+			name:           "infinite ratio",
+			follower:       Follower{Ratio: "+Inf"},
+			leaderReplicas: 1,
+			expectedError:  true,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			replicas, err := tt.follower.GetDesiredReplicas(tt.leaderReplicas)
+			if tt.expectedError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Expected no error but got %v", err)
+			}
+			if replicas != tt.expected {
+				t.Errorf("Expected %d replicas, got %d", tt.expected, replicas)
+			}
+		})
+	}
+}
+
+func TestCheckFollowersValid(t *testing.T) {
+	tests := []struct {
+		name          string
+		followers     []Follower
+		expectedError bool
+	}{
+		{
+			name: "valid followers",
+			followers: []Follower{
+				{ScaleTargetRef: ScaleTarget{Name: "gateway"}, Ratio: "0.25"},
+				{ScaleTargetRef: ScaleTarget{Name: "cache", Kind: "StatefulSet"}, MinReplicaCount: int32Ptr(1), MaxReplicaCount: int32Ptr(3)},
+			},
+		},
+		{
+			name:          "follower without name",
+			followers:     []Follower{{}},
+			expectedError: true,
+		},
+		{
+			name:          "follower targeting the scale target",
+			followers:     []Follower{{ScaleTargetRef: ScaleTarget{Name: "leader", Kind: "Deployment"}}},
+			expectedError: true,
+		},
+		{
+			name: "follower listed twice",
+			followers: []Follower{
+				{ScaleTargetRef: ScaleTarget{Name: "gateway"}},
+				{ScaleTargetRef: ScaleTarget{Name: "gateway", Kind: "Deployment"}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "same name with another kind",
+			followers: []Follower{
+				{ScaleTargetRef: ScaleTarget{Name: "leader", Kind: "StatefulSet"}},
+			},
+		},
+		{
+			name:          "invalid ratio",
+			followers:     []Follower{{ScaleTargetRef: ScaleTarget{Name: "gateway"}, Ratio: "0"}},
+			expectedError: true,
+		},
+		{
+			name:          "negative min replica count",
+			followers:     []Follower{{ScaleTargetRef: ScaleTarget{Name: "gateway"}, MinReplicaCount: int32Ptr(-1)}},
+			expectedError: true,
+		},
+		{
+			name:          "min replica count greater than max replica count",
+			followers:     []Follower{{ScaleTargetRef: ScaleTarget{Name: "gateway"}, MinReplicaCount: int32Ptr(5), MaxReplicaCount: int32Ptr(2)}},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			scaledObject := &ScaledObject{
+				Spec: ScaledObjectSpec{
+					ScaleTargetRef: &ScaleTarget{Name: "leader"},
+					Followers:      tt.followers,
+				},
+			}
+			err := CheckFollowersValid(scaledObject)
+			if tt.expectedError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectedError && err != nil {
+				t.Errorf("Expected no error but got %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckPodDeletionCostValid(t *testing.T) {
+	tests := []struct {
+		name          string
+		cost          *PodDeletionCost
+		scaleTarget   *ScaleTarget
+		expectedError bool
+	}{
+		{
+			name: "no pod deletion cost",
+		},
+		{
+			name: "pod-metrics trigger",
+			cost: &PodDeletionCost{TriggerName: "busy"},
+		},
+		{
+			name: "metadata",
+			cost: &PodDeletionCost{Metadata: map[string]string{"metricName": "busy", "port": "9090"}},
+		},
+		{
+			name:          "neither trigger nor metadata",
+			cost:          &PodDeletionCost{},
+			expectedError: true,
+		},
+		{
+			name:          "both trigger and metadata",
+			cost:          &PodDeletionCost{TriggerName: "busy", Metadata: map[string]string{"metricName": "busy"}},
+			expectedError: true,
+		},
+		{
+			name:          "trigger of another type",
+			cost:          &PodDeletionCost{TriggerName: "queue"},
+			expectedError: true,
+		},
+		{
+			name:          "unknown trigger",
+			cost:          &PodDeletionCost{TriggerName: "unknown"},
+			expectedError: true,
+		},
+		{
+			name:        "replicaset target",
+			cost:        &PodDeletionCost{TriggerName: "busy"},
+			scaleTarget: &ScaleTarget{Name: "worker", APIVersion: "apps/v1", Kind: "ReplicaSet"},
+		},
+		{
+			name:          "statefulset target",
+			cost:          &PodDeletionCost{TriggerName: "busy"},
+			scaleTarget:   &ScaleTarget{Name: "worker", APIVersion: "apps/v1", Kind: "StatefulSet"},
+			expectedError: true,
+		},
+		{
+			name:          "custom resource target",
+			cost:          &PodDeletionCost{TriggerName: "busy"},
+			scaleTarget:   &ScaleTarget{Name: "worker", APIVersion: "argoproj.io/v1alpha1", Kind: "Rollout"},
+			expectedError: true,
+		},
+		{
+			name: "metadata with authenticationRef",
+			cost: &PodDeletionCost{Metadata: map[string]string{"metricName": "busy"}, AuthenticationRef: &AuthenticationRef{Name: "metrics"}},
+		},
+		{
+			name:          "trigger with authenticationRef",
+			cost:          &PodDeletionCost{TriggerName: "busy", AuthenticationRef: &AuthenticationRef{Name: "metrics"}},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			scaleTarget := tt.scaleTarget
+			if scaleTarget == nil {
+				scaleTarget = &ScaleTarget{Name: "worker"}
+			}
+			scaledObject := &ScaledObject{
+				Spec: ScaledObjectSpec{
+					ScaleTargetRef: scaleTarget,
+					Advanced:       &AdvancedConfig{PodDeletionCost: tt.cost},
+					Triggers: []ScaleTriggers{
+						{Name: "busy", Type: "pod-metrics"},
+						{Name: "queue", Type: "rabbitmq"},
+					},
+				},
+			}
+			err := CheckPodDeletionCostValid(scaledObject)
+			if tt.expectedError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectedError && err != nil {
+				t.Errorf("Expected no error but got %v", err)
+			}
+		})
+	}
+}
@@ -29,6 +29,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -54,10 +55,33 @@ func (so *ScaledObject) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	restMapper = mgr.GetRESTMapper()
 	return ctrl.NewWebhookManagedBy(mgr).
 		WithValidator(&ScaledObjectCustomValidator{}).
+		WithDefaulter(&ScaledObjectCustomDefaulter{}).
 		For(so).
 		Complete()
 }
 
+// +kubebuilder:webhook:path=/mutate-keda-sh-v1alpha1-scaledobject,mutating=true,failurePolicy=ignore,sideEffects=None,groups=keda.sh,resources=scaledobjects,verbs=create;update,versions=v1alpha1,name=mscaledobject.kb.io,admissionReviewVersions=v1
+
+// ScaledObjectCustomDefaulter is a custom defaulter for ScaledObject objects
+type ScaledObjectCustomDefaulter struct{}
+
+// Default rewrites the stan triggers into nats-jetstream triggers when the migration is enabled by the StanMigrationAnnotation
+func (socd ScaledObjectCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	so := obj.(*ScaledObject)
+	if so.StanMigrationMode() != StanMigrationEnabled {
+		return nil
+	}
+
+	triggers, changes := so.MigrateStanTriggers()
+	for _, change := range changes {
+		scaledobjectlog.Info(fmt.Sprintf("migrating scaledobject %s/%s, %s", so.Namespace, so.Name, change))
+	}
+	so.Spec.Triggers = triggers
+	return nil
+}
+
+var _ webhook.CustomDefaulter = &ScaledObjectCustomDefaulter{}
+
 // +kubebuilder:webhook:path=/validate-keda-sh-v1alpha1-scaledobject,mutating=false,failurePolicy=ignore,sideEffects=None,groups=keda.sh,resources=scaledobjects,verbs=create;update,versions=v1alpha1,name=vscaledobject.kb.io,admissionReviewVersions=v1
 
 // ScaledObjectCustomValidator is a custom validator for ScaledObject objects
@@ -136,6 +160,8 @@ func validateWorkload(so *ScaledObject, action string, dryRun bool) (admission.W
 		verifyHpas,
 		verifyReplicaCount,
 		verifyFallback,
+		verifyFollowers,
+		verifyPodDeletionCost,
 	}
 
 	for i := range verifyFunctions {
@@ -147,6 +173,7 @@ func validateWorkload(so *ScaledObject, action string, dryRun bool) (admission.W
 
 	verifyCommonFunctions := []func(interface{}, string, bool) error{
 		verifyTriggers,
+		verifyAuthenticationRefs,
 	}
 
 	for i := range verifyCommonFunctions {
@@ -157,6 +184,12 @@ func validateWorkload(so *ScaledObject, action string, dryRun bool) (admission.W
 	}
 
 	scaledobjectlog.V(1).Info(fmt.Sprintf("scaledobject %s is valid", so.Name))
+
+	// the changes a stan migration would make are reported without being applied
+	if so.StanMigrationMode() == StanMigrationDryRun {
+		_, changes := so.MigrateStanTriggers()
+		return changes, nil
+	}
 	return nil, nil
 }
 
@@ -178,6 +211,24 @@ func verifyFallback(incomingSo *ScaledObject, action string, _ bool) error {
 	return nil
 }
 
+func verifyFollowers(incomingSo *ScaledObject, action string, _ bool) error {
+	err := CheckFollowersValid(incomingSo)
+	if err != nil {
+		scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "incorrect-followers")
+	}
+	return err
+}
+
+func verifyPodDeletionCost(incomingSo *ScaledObject, action string, _ bool) error {
+	err := CheckPodDeletionCostValid(incomingSo)
+	if err != nil {
+		scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "incorrect-pod-deletion-cost")
+	}
+	return err
+}
+
 func verifyTriggers(incomingObject interface{}, action string, _ bool) error {
 	var triggers []ScaleTriggers
 	var name string
@@ -187,6 +238,10 @@ func verifyTriggers(incomingObject interface{}, action string, _ bool) error {
 		triggers = obj.Spec.Triggers
 		name = obj.Name
 		namespace = obj.Namespace
+		// the pods scraped for the pod deletion cost are authenticated like the ones of a pod-metrics trigger
+		if cost := obj.GetPodDeletionCost(); cost != nil && cost.AuthenticationRef != nil {
+			triggers = append(triggers[:len(triggers):len(triggers)], ScaleTriggers{Type: "pod-metrics", AuthenticationRef: cost.AuthenticationRef})
+		}
 	case *ScaledJob:
 		triggers = obj.Spec.Triggers
 		name = obj.Name
@@ -203,6 +258,80 @@ func verifyTriggers(incomingObject interface{}, action string, _ bool) error {
 	return err
 }
 
+// verifyAuthenticationRefs checks that the ClusterTriggerAuthentications referenced by the triggers allow the
+// namespace and the type of the trigger, missing ClusterTriggerAuthentications are ignored, and that the
+// TriggerAuthentications referenced in other namespaces are granted by a TriggerAuthenticationGrant
+func verifyAuthenticationRefs(incomingObject interface{}, action string, _ bool) error {
+	var triggers []ScaleTriggers
+	var name string
+	var namespace string
+	switch obj := incomingObject.(type) {
+	case *ScaledObject:
+		triggers = obj.Spec.Triggers
+		name = obj.Name
+		namespace = obj.Namespace
+		// the pods scraped for the pod deletion cost are authenticated like the ones of a pod-metrics trigger
+		if cost := obj.GetPodDeletionCost(); cost != nil && cost.AuthenticationRef != nil {
+			triggers = append(triggers[:len(triggers):len(triggers)], ScaleTriggers{Type: "pod-metrics", AuthenticationRef: cost.AuthenticationRef})
+		}
+	case *ScaledJob:
+		triggers = obj.Spec.Triggers
+		name = obj.Name
+		namespace = obj.Namespace
+	default:
+		return fmt.Errorf("unknown scalable object type %v", incomingObject)
+	}
+
+	var ns *corev1.Namespace
+	for _, trigger := range triggers {
+		ref := trigger.AuthenticationRef
+		if ref == nil {
+			continue
+		}
+
+		if ref.Kind == "" || ref.Kind == "TriggerAuthentication" {
+			refNamespace := ref.GetNamespace(namespace)
+			if refNamespace == namespace {
+				continue
+			}
+			grants := &TriggerAuthenticationGrantList{}
+			if err := kc.List(context.Background(), grants, client.InNamespace(refNamespace)); err != nil {
+				return err
+			}
+			if !IsTriggerAuthenticationGranted(grants.Items, namespace, ref.Name) {
+				err := fmt.Errorf("%w: TriggerAuthentication %s/%s from namespace %s", ErrTriggerAuthenticationNotGranted, refNamespace, ref.Name, namespace)
+				scaledobjectlog.WithValues("name", name).Error(err, "validation error")
+				metricscollector.RecordScaledObjectValidatingErrors(namespace, action, "trigger-authentication-not-granted")
+				return err
+			}
+			continue
+		}
+
+		if ref.Kind != "ClusterTriggerAuthentication" {
+			continue
+		}
+		cta := &ClusterTriggerAuthentication{}
+		if err := kc.Get(context.Background(), types.NamespacedName{Name: ref.Name}, cta); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if ns == nil {
+			ns = &corev1.Namespace{}
+			if err := kc.Get(context.Background(), types.NamespacedName{Name: namespace}, ns); err != nil {
+				return err
+			}
+		}
+		if err := cta.CheckAccess(ns, trigger.Type); err != nil {
+			scaledobjectlog.WithValues("name", name).Error(err, "validation error")
+			metricscollector.RecordScaledObjectValidatingErrors(namespace, action, "cluster-trigger-authentication-not-allowed")
+			return err
+		}
+	}
+	return nil
+}
+
 func verifyHpas(incomingSo *ScaledObject, action string, _ bool) error {
 	hpaList := &autoscalingv2.HorizontalPodAutoscalerList{}
 	opt := &client.ListOptions{
@@ -220,6 +349,11 @@ func verifyHpas(incomingSo *ScaledObject, action string, _ bool) error {
 		return err
 	}
 
+	followerTargets, err := getFollowerTargets(incomingSo)
+	if err != nil {
+		return err
+	}
+
 	for _, hpa := range hpaList.Items {
 		if hpa.ObjectMeta.Annotations[ValidationsHpaOwnershipAnnotation] == "false" {
 			continue
@@ -233,6 +367,15 @@ func verifyHpas(incomingSo *ScaledObject, action string, _ bool) error {
 			return err
 		}
 
+		for _, follower := range followerTargets {
+			if hpaGckr.GVKString() == follower.gvkr.GVKString() && hpa.Spec.ScaleTargetRef.Name == follower.name {
+				err = fmt.Errorf("the follower '%s' of type '%s' is already managed by the hpa '%s'", follower.name, follower.gvkr.GVKString(), hpa.Name)
+				scaledobjectlog.Error(err, "validation error")
+				metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "other-hpa")
+				return err
+			}
+		}
+
 		if hpaGckr.GVKString() == incomingSoGckr.GVKString() &&
 			hpa.Spec.ScaleTargetRef.Name == incomingSo.Spec.ScaleTargetRef.Name {
 			owned := false
@@ -277,7 +420,14 @@ func verifyScaledObjects(incomingSo *ScaledObject, action string, _ bool) error
 		return err
 	}
 
-	for _, so := range soList.Items {
+	incomingFollowerTargets, err := getFollowerTargets(incomingSo)
+	if err != nil {
+		return err
+	}
+	incomingTargets := append([]scaledObjectTarget{{name: incomingSo.Spec.ScaleTargetRef.Name, gvkr: incomingSoGckr}}, incomingFollowerTargets...)
+
+	for i := range soList.Items {
+		so := &soList.Items[i]
 		if so.Name == incomingSo.Name {
 			continue
 		}
@@ -289,14 +439,23 @@ func verifyScaledObjects(incomingSo *ScaledObject, action string, _ bool) error
 			scaledobjectlog.Error(err, "Failed to parse Group, Version, Kind, Resource from ScaledObject", "soName", so.Name, "apiVersion", so.Spec.ScaleTargetRef.APIVersion, "kind", so.Spec.ScaleTargetRef.Kind)
 			return err
 		}
-
-		if soGckr.GVKString() == incomingSoGckr.GVKString() &&
-			so.Spec.ScaleTargetRef.Name == incomingSo.Spec.ScaleTargetRef.Name {
-			err = fmt.Errorf("the workload '%s' of type '%s' is already managed by the ScaledObject '%s'", so.Spec.ScaleTargetRef.Name, incomingSoGckr.GVKString(), so.Name)
-			scaledobjectlog.Error(err, "validation error")
-			metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "other-scaled-object")
+		soFollowerTargets, err := getFollowerTargets(so)
+		if err != nil {
 			return err
 		}
+
+		// the scale target and the followers of both ScaledObjects must not overlap
+		soTargets := append([]scaledObjectTarget{{name: so.Spec.ScaleTargetRef.Name, gvkr: soGckr}}, soFollowerTargets...)
+		for _, incomingTarget := range incomingTargets {
+			for _, soTarget := range soTargets {
+				if soTarget.gvkr.GVKString() == incomingTarget.gvkr.GVKString() && soTarget.name == incomingTarget.name {
+					err = fmt.Errorf("the workload '%s' of type '%s' is already managed by the ScaledObject '%s'", soTarget.name, incomingTarget.gvkr.GVKString(), so.Name)
+					scaledobjectlog.Error(err, "validation error")
+					metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "other-scaled-object")
+					return err
+				}
+			}
+		}
 	}
 
 	// verify ScalingModifiers structure if defined in ScaledObject
@@ -312,6 +471,26 @@ func verifyScaledObjects(incomingSo *ScaledObject, action string, _ bool) error
 	return nil
 }
 
+// scaledObjectTarget is a workload scaled by a ScaledObject, either its scale target or one of its followers
+type scaledObjectTarget struct {
+	name string
+	gvkr GroupVersionKindResource
+}
+
+// getFollowerTargets returns the followers of the ScaledObject with their parsed GVKR
+func getFollowerTargets(so *ScaledObject) ([]scaledObjectTarget, error) {
+	targets := make([]scaledObjectTarget, 0, len(so.Spec.Followers))
+	for _, follower := range so.Spec.Followers {
+		gvkr, err := ParseGVKR(restMapper, follower.ScaleTargetRef.APIVersion, follower.ScaleTargetRef.Kind)
+		if err != nil {
+			scaledobjectlog.Error(err, "Failed to parse Group, Version, Kind, Resource of follower", "soName", so.Name, "apiVersion", follower.ScaleTargetRef.APIVersion, "kind", follower.ScaleTargetRef.Kind)
+			return nil, err
+		}
+		targets = append(targets, scaledObjectTarget{name: follower.ScaleTargetRef.Name, gvkr: gvkr})
+	}
+	return targets, nil
+}
+
 func verifyCPUMemoryScalers(incomingSo *ScaledObject, action string, dryRun bool) error {
 	if dryRun {
 		return nil
@@ -444,10 +623,6 @@ func validateScalingModifiersFormula(so *ScaledObject) (*vm.Program, error) {
 	// defined (have names)
 	triggersMap := make(map[string]float64)
 	for _, trig := range so.Spec.Triggers {
-		// if resource metrics are given, skip
-		if trig.Type == cpuString || trig.Type == memoryString {
-			continue
-		}
 		if trig.Name != "" {
 			triggersMap[trig.Name] = dummyValue
 		}
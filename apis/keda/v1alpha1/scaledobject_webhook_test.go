@@ -1048,6 +1048,42 @@ var _ = It("should validate the so creation with ScalingModifiers.Formula - doub
 	}).ShouldNot(HaveOccurred())
 })
 
+var _ = It("should validate the so creation with ScalingModifiers.Formula - cpu trigger", func() {
+	namespaceName := "scaling-modifiers-formula-cpu-good"
+	namespace := createNamespace(namespaceName)
+	workload := createDeployment(namespaceName, true, false)
+
+	sm := ScalingModifiers{Target: "1", Formula: "max(workload_trig/10, cpu_trig/70)"}
+	triggers := []ScaleTriggers{
+		{
+			Type:       "cpu",
+			Name:       "cpu_trig",
+			MetricType: v2.UtilizationMetricType,
+			Metadata: map[string]string{
+				"value": "70",
+			},
+		},
+		{
+			Type: "kubernetes-workload",
+			Name: "workload_trig",
+			Metadata: map[string]string{
+				"podSelector": "pod=workload-test",
+				"value":       "1",
+			},
+		},
+	}
+
+	so := createScaledObjectScalingModifiers(namespaceName, sm, triggers)
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+	err = k8sClient.Create(context.Background(), workload)
+	Expect(err).ToNot(HaveOccurred())
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), so)
+	}).ShouldNot(HaveOccurred())
+})
+
 var _ = AfterSuite(func() {
 	cancel()
 	By("tearing down the test environment")
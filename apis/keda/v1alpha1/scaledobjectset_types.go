@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=scaledobjectsets,scope=Namespaced,shortName=soset
+// +kubebuilder:printcolumn:name="ScaleTargetKind",type="string",JSONPath=".spec.template.spec.scaleTargetRef.kind"
+// +kubebuilder:printcolumn:name="ScaledObjects",type="integer",JSONPath=".status.scaledObjectCount"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ScaledObjectSet generates a ScaledObject from a template for every workload matching a label selector
+type ScaledObjectSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ScaledObjectSetSpec `json:"spec"`
+	// +optional
+	Status ScaledObjectSetStatus `json:"status,omitempty"`
+}
+
+const (
+	// ScaledObjectSetNameLabel is set on every ScaledObject generated by a ScaledObjectSet
+	ScaledObjectSetNameLabel = "scaledobjectset.keda.sh/name"
+)
+
+// ScaledObjectSetSpec is the spec for a ScaledObjectSet resource
+type ScaledObjectSetSpec struct {
+	// Selector selects the workloads in the ScaledObjectSet namespace for which a ScaledObject is generated,
+	// the kind of the workloads is taken from the template scaleTargetRef and defaults to Deployment
+	Selector metav1.LabelSelector `json:"selector"`
+	// Template is the ScaledObject generated for every selected workload. The scaleTargetRef name,
+	// trigger names, trigger metadata, authenticationRef names and the template labels and annotations
+	// are Go templates rendered with the workload .Name, .Namespace, .Labels and .Annotations.
+	// The scaleTargetRef name is required, it is usually set to "{{ .Name }}"
+	Template ScaledObjectTemplate `json:"template"`
+}
+
+// ScaledObjectTemplate describes the ScaledObjects generated by a ScaledObjectSet
+type ScaledObjectTemplate struct {
+	// +optional
+	Metadata ScaledObjectTemplateMetadata `json:"metadata,omitempty"`
+	Spec     ScaledObjectSpec             `json:"spec"`
+}
+
+// ScaledObjectTemplateMetadata is the metadata of the generated ScaledObjects
+type ScaledObjectTemplateMetadata struct {
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ScaledObjectSetStatus is the status for a ScaledObjectSet resource
+// +optional
+type ScaledObjectSetStatus struct {
+	// +optional
+	ScaledObjects []string `json:"scaledObjects,omitempty"`
+	// +optional
+	ScaledObjectCount int32 `json:"scaledObjectCount,omitempty"`
+	// +optional
+	Conditions Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ScaledObjectSetList is a list of ScaledObjectSet resources
+type ScaledObjectSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ScaledObjectSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ScaledObjectSet{}, &ScaledObjectSetList{})
+}
+
+// GenerateIdentifier returns identifier for the object in for "kind.namespace.name"
+func (s *ScaledObjectSet) GenerateIdentifier() string {
+	return GenerateIdentifier("ScaledObjectSet", s.Namespace, s.Name)
+}
+
+// ScaleTargetKind returns the kind of the workloads selected by the ScaledObjectSet
+func (s *ScaledObjectSet) ScaleTargetKind() string {
+	if s.Spec.Template.Spec.ScaleTargetRef == nil || s.Spec.Template.Spec.ScaleTargetRef.Kind == "" {
+		return "Deployment"
+	}
+	return s.Spec.Template.Spec.ScaleTargetRef.Kind
+}
+
+// ScaledObjectNameForWorkload returns the name of the ScaledObject generated for the given workload
+func (s *ScaledObjectSet) ScaledObjectNameForWorkload(name string) string {
+	return fmt.Sprintf("%s-%s", s.Name, name)
+}
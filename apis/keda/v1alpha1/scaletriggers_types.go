@@ -44,11 +44,25 @@ type AuthenticationRef struct {
 	// Kind of the resource being referred to. Defaults to TriggerAuthentication.
 	// +optional
 	Kind string `json:"kind,omitempty"`
+	// Namespace of the TriggerAuthentication, defaults to the namespace of the ScaledObject or ScaledJob.
+	// A TriggerAuthentication in another namespace needs a TriggerAuthenticationGrant in its namespace
+	// allowing the namespace of the ScaledObject or ScaledJob.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// GetNamespace returns the namespace of the referenced TriggerAuthentication for a scalable object in the given namespace
+func (a *AuthenticationRef) GetNamespace(namespace string) string {
+	if a.Namespace != "" {
+		return a.Namespace
+	}
+	return namespace
 }
 
 // ValidateTriggers checks that general trigger metadata are valid, it checks:
 // - triggerNames in ScaledObject are unique
 // - useCachedMetrics is defined only for a supported triggers
+// - authenticationRef namespace is only set for a TriggerAuthentication
 func ValidateTriggers(triggers []ScaleTriggers) error {
 	triggersCount := len(triggers)
 
@@ -67,6 +81,10 @@ func ValidateTriggers(triggers []ScaleTriggers) error {
 				}
 			}
 
+			if ref := trigger.AuthenticationRef; ref != nil && ref.Namespace != "" && ref.Kind == "ClusterTriggerAuthentication" {
+				return fmt.Errorf("authenticationRef namespace can't be set for a ClusterTriggerAuthentication")
+			}
+
 			name := trigger.Name
 			if name != "" {
 				if _, found := triggerNames[name]; found {
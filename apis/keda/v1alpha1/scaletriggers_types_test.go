@@ -84,6 +84,28 @@ func TestValidateTriggers(t *testing.T) {
 			},
 			expectedErrMsg: "",
 		},
+		{
+			name: "authenticationRef namespace for a TriggerAuthentication",
+			triggers: []ScaleTriggers{
+				{
+					Name:              "trigger5",
+					Type:              "kafka",
+					AuthenticationRef: &AuthenticationRef{Name: "kafka-auth", Namespace: "shared-services"},
+				},
+			},
+			expectedErrMsg: "",
+		},
+		{
+			name: "authenticationRef namespace for a ClusterTriggerAuthentication",
+			triggers: []ScaleTriggers{
+				{
+					Name:              "trigger6",
+					Type:              "kafka",
+					AuthenticationRef: &AuthenticationRef{Name: "kafka-auth", Kind: "ClusterTriggerAuthentication", Namespace: "shared-services"},
+				},
+			},
+			expectedErrMsg: "authenticationRef namespace can't be set for a ClusterTriggerAuthentication",
+		},
 		{
 			name:           "empty triggers array should be blocked",
 			triggers:       []ScaleTriggers{},
@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// StanMigrationAnnotation rewrites the stan triggers of a ScaledObject into nats-jetstream triggers when set to
+	// "true", with "dry-run" the changes are only reported as warnings by the admission webhook
+	StanMigrationAnnotation = "scaledobject.keda.sh/migrate-stan-to-jetstream"
+	// StanMigrationAccountAnnotation is the JetStream account of the migrated triggers
+	StanMigrationAccountAnnotation = "scaledobject.keda.sh/jetstream-account"
+
+	StanMigrationEnabled = "true"
+	StanMigrationDryRun  = "dry-run"
+
+	// defaultJetStreamAccount is the global account of a NATS server without accounts
+	defaultJetStreamAccount = "$G"
+)
+
+// stanToJetStreamMetadata are the stan metadata kept as is by the migration
+var stanToJetStreamMetadata = []string{"natsServerMonitoringEndpoint", "lagThreshold", "activationLagThreshold", "useHttps"}
+
+// jetStreamNameReplacer replaces the characters JetStream doesn't allow in stream and consumer names
+var jetStreamNameReplacer = strings.NewReplacer(".", "_", "*", "_", ">", "_", " ", "_", "\t", "_")
+
+// StanMigrationMode returns the migration mode set by the StanMigrationAnnotation, or an empty string
+func (so *ScaledObject) StanMigrationMode() string {
+	switch mode := so.GetAnnotations()[StanMigrationAnnotation]; mode {
+	case StanMigrationEnabled, StanMigrationDryRun:
+		return mode
+	default:
+		return ""
+	}
+}
+
+// MigrateStanTriggers returns the triggers with every stan trigger rewritten into a nats-jetstream trigger and a
+// description of every rewritten trigger. The channel (subject) becomes the stream and the durable name the consumer.
+func (so *ScaledObject) MigrateStanTriggers() ([]ScaleTriggers, []string) {
+	account := so.GetAnnotations()[StanMigrationAccountAnnotation]
+	if account == "" {
+		account = defaultJetStreamAccount
+	}
+
+	var changes []string
+	triggers := make([]ScaleTriggers, len(so.Spec.Triggers))
+	for i, trigger := range so.Spec.Triggers {
+		triggers[i] = *trigger.DeepCopy()
+		if trigger.Type != "stan" {
+			continue
+		}
+
+		metadata := map[string]string{
+			"account":  account,
+			"stream":   jetStreamNameReplacer.Replace(trigger.Metadata["subject"]),
+			"consumer": jetStreamNameReplacer.Replace(trigger.Metadata["durableName"]),
+		}
+		for _, key := range stanToJetStreamMetadata {
+			if val, ok := trigger.Metadata[key]; ok {
+				metadata[key] = val
+			}
+		}
+		triggers[i].Type = "nats-jetstream"
+		triggers[i].Metadata = metadata
+
+		name := trigger.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+		changes = append(changes, fmt.Sprintf("trigger %s: stan channel %q with durable name %q is migrated to nats-jetstream stream %q with consumer %q in account %q",
+			name, trigger.Metadata["subject"], trigger.Metadata["durableName"], metadata["stream"], metadata["consumer"], account))
+	}
+	return triggers, changes
+}
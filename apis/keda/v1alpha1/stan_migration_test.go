@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func createStanScaledObject(annotations map[string]string) *ScaledObject {
+	return &ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "default", Annotations: annotations},
+		Spec: ScaledObjectSpec{
+			Triggers: []ScaleTriggers{
+				{
+					Type: "stan",
+					Name: "orders",
+					Metadata: map[string]string{
+						"natsServerMonitoringEndpoint": "nats.nats:8222",
+						"queueGroup":                   "grp1",
+						"durableName":                  "ImDurable",
+						"subject":                      "orders.created",
+						"lagThreshold":                 "10",
+					},
+					AuthenticationRef: &AuthenticationRef{Name: "nats-auth"},
+				},
+				{
+					Type:     "cpu",
+					Metadata: map[string]string{"value": "50"},
+				},
+			},
+		},
+	}
+}
+
+func TestMigrateStanTriggers(t *testing.T) {
+	so := createStanScaledObject(map[string]string{StanMigrationAccountAnnotation: "orders-account"})
+
+	triggers, changes := so.MigrateStanTriggers()
+
+	assert.Len(t, changes, 1)
+	assert.Equal(t, "nats-jetstream", triggers[0].Type)
+	assert.Equal(t, "orders", triggers[0].Name)
+	assert.Equal(t, map[string]string{
+		"natsServerMonitoringEndpoint": "nats.nats:8222",
+		"account":                      "orders-account",
+		"stream":                       "orders_created",
+		"consumer":                     "ImDurable",
+		"lagThreshold":                 "10",
+	}, triggers[0].Metadata)
+	assert.Equal(t, &AuthenticationRef{Name: "nats-auth"}, triggers[0].AuthenticationRef)
+	assert.Equal(t, so.Spec.Triggers[1], triggers[1])
+	// the ScaledObject itself isn't changed
+	assert.Equal(t, "stan", so.Spec.Triggers[0].Type)
+}
+
+func TestScaledObjectCustomDefaulter(t *testing.T) {
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		expectedType string
+	}{
+		{name: "migration not enabled", annotations: nil, expectedType: "stan"},
+		{name: "dry-run migration", annotations: map[string]string{StanMigrationAnnotation: StanMigrationDryRun}, expectedType: "stan"},
+		{name: "invalid migration mode", annotations: map[string]string{StanMigrationAnnotation: "yes"}, expectedType: "stan"},
+		{name: "migration enabled", annotations: map[string]string{StanMigrationAnnotation: StanMigrationEnabled}, expectedType: "nats-jetstream"},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			so := createStanScaledObject(tt.annotations)
+			err := ScaledObjectCustomDefaulter{}.Default(context.Background(), so)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedType, so.Spec.Triggers[0].Type)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ErrClusterTriggerAuthenticationNotAllowed is returned when a ClusterTriggerAuthentication is referenced
+// from a namespace or by a trigger type it doesn't allow
+var ErrClusterTriggerAuthenticationNotAllowed = errors.New("access to ClusterTriggerAuthentication not allowed")
+
+// CheckAccess returns ErrClusterTriggerAuthenticationNotAllowed when a trigger of triggerType in the namespace
+// isn't allowed to use the ClusterTriggerAuthentication
+func (cta *ClusterTriggerAuthentication) CheckAccess(namespace *corev1.Namespace, triggerType string) error {
+	if allowed := cta.Spec.AllowedTriggerTypes; len(allowed) > 0 && !slices.Contains(allowed, triggerType) {
+		return fmt.Errorf("%w: ClusterTriggerAuthentication %s doesn't allow trigger type %q", ErrClusterTriggerAuthenticationNotAllowed, cta.Name, triggerType)
+	}
+
+	allowed := cta.Spec.AllowedNamespaces
+	if allowed == nil || slices.Contains(allowed.Names, namespace.Name) {
+		return nil
+	}
+	if allowed.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(allowed.Selector)
+		if err != nil {
+			return fmt.Errorf("invalid allowedNamespaces selector of ClusterTriggerAuthentication %s: %w", cta.Name, err)
+		}
+		if selector.Matches(labels.Set(namespace.Labels)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: ClusterTriggerAuthentication %s doesn't allow namespace %s", ErrClusterTriggerAuthenticationNotAllowed, cta.Name, namespace.Name)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClusterTriggerAuthenticationCheckAccess(t *testing.T) {
+	tenantSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "platform"}}
+	tests := []struct {
+		name        string
+		spec        TriggerAuthenticationSpec
+		namespace   *corev1.Namespace
+		triggerType string
+		allowed     bool
+	}{
+		{
+			name:        "no restriction",
+			namespace:   &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+			triggerType: "kafka",
+			allowed:     true,
+		},
+		{
+			name:        "namespace allowed by name",
+			spec:        TriggerAuthenticationSpec{AllowedNamespaces: &AllowedNamespaces{Names: []string{"team-a"}, Selector: tenantSelector}},
+			namespace:   &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+			triggerType: "kafka",
+			allowed:     true,
+		},
+		{
+			name:        "namespace allowed by label",
+			spec:        TriggerAuthenticationSpec{AllowedNamespaces: &AllowedNamespaces{Names: []string{"team-a"}, Selector: tenantSelector}},
+			namespace:   &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"tenant": "platform"}}},
+			triggerType: "kafka",
+			allowed:     true,
+		},
+		{
+			name:        "namespace not allowed",
+			spec:        TriggerAuthenticationSpec{AllowedNamespaces: &AllowedNamespaces{Names: []string{"team-a"}, Selector: tenantSelector}},
+			namespace:   &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"tenant": "team-b"}}},
+			triggerType: "kafka",
+			allowed:     false,
+		},
+		{
+			name:        "empty allowedNamespaces allows no namespace",
+			spec:        TriggerAuthenticationSpec{AllowedNamespaces: &AllowedNamespaces{}},
+			namespace:   &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+			triggerType: "kafka",
+			allowed:     false,
+		},
+		{
+			name:        "trigger type allowed",
+			spec:        TriggerAuthenticationSpec{AllowedTriggerTypes: []string{"kafka", "rabbitmq"}},
+			namespace:   &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+			triggerType: "rabbitmq",
+			allowed:     true,
+		},
+		{
+			name:        "trigger type not allowed",
+			spec:        TriggerAuthenticationSpec{AllowedTriggerTypes: []string{"kafka", "rabbitmq"}},
+			namespace:   &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+			triggerType: "aws-sqs-queue",
+			allowed:     false,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			cta := &ClusterTriggerAuthentication{ObjectMeta: metav1.ObjectMeta{Name: "platform-auth"}, Spec: tt.spec}
+			err := cta.CheckAccess(tt.namespace, tt.triggerType)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrClusterTriggerAuthenticationNotAllowed)
+			}
+		})
+	}
+}
+
+func TestValidateAccessSpec(t *testing.T) {
+	restricted := TriggerAuthenticationSpec{
+		AllowedNamespaces:   &AllowedNamespaces{Names: []string{"team-a"}},
+		AllowedTriggerTypes: []string{"kafka"},
+	}
+	assert.NoError(t, validateAccessSpec(&restricted, true))
+	assert.Error(t, validateAccessSpec(&restricted, false))
+
+	invalidSelector := TriggerAuthenticationSpec{
+		AllowedNamespaces: &AllowedNamespaces{Selector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tenant", Operator: "Matches"}},
+		}},
+	}
+	assert.Error(t, validateAccessSpec(&invalidSelector, true))
+
+	emptyTriggerType := TriggerAuthenticationSpec{AllowedTriggerTypes: []string{""}}
+	assert.Error(t, validateAccessSpec(&emptyTriggerType, true))
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+)
+
+// authTemplateFuncs are the helpers available in the templated parameters to build URLs
+var authTemplateFuncs = template.FuncMap{
+	"urlPathEscape":  url.PathEscape,
+	"urlQueryEscape": url.QueryEscape,
+	// urlUserInfo escapes a user and a password for the user info part of a URL
+	"urlUserInfo": func(user, password string) string {
+		return url.UserPassword(user, password).String()
+	},
+}
+
+// Parse parses the template of the parameter
+func (p *AuthTemplatedParameter) Parse() (*template.Template, error) {
+	tmpl, err := template.New(p.Parameter).Funcs(authTemplateFuncs).Option("missingkey=error").Parse(p.Template)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the template of parameter %s: %w", p.Parameter, err)
+	}
+	return tmpl, nil
+}
+
+// Render renders the template of the parameter over the resolved parameters, a parameter
+// missing from the resolved parameters is an error
+func (p *AuthTemplatedParameter) Render(params map[string]string) (string, error) {
+	tmpl, err := p.Parse()
+	if err != nil {
+		return "", err
+	}
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, params); err != nil {
+		return "", fmt.Errorf("error rendering the template of parameter %s: %w", p.Parameter, err)
+	}
+	return rendered.String(), nil
+}
@@ -95,6 +95,29 @@ type TriggerAuthenticationSpec struct {
 
 	// +optional
 	AwsSecretManager *AwsSecretManager `json:"awsSecretManager,omitempty"`
+
+	// TemplatedParameters are rendered from the parameters resolved from the other sources
+	// +optional
+	TemplatedParameters []AuthTemplatedParameter `json:"templatedParameters,omitempty"`
+
+	// AllowedNamespaces restricts the namespaces whose ScaledObjects and ScaledJobs can use a
+	// ClusterTriggerAuthentication, every namespace is allowed when it isn't set
+	// +optional
+	AllowedNamespaces *AllowedNamespaces `json:"allowedNamespaces,omitempty"`
+
+	// AllowedTriggerTypes restricts the trigger types that can use a ClusterTriggerAuthentication,
+	// every trigger type is allowed when it's empty
+	// +optional
+	AllowedTriggerTypes []string `json:"allowedTriggerTypes,omitempty"`
+}
+
+// AllowedNamespaces selects namespaces by name or by label, a namespace is allowed when it matches either of them
+type AllowedNamespaces struct {
+	// +optional
+	Names []string `json:"names,omitempty"`
+
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
 }
 
 // TriggerAuthenticationStatus defines the observed state of TriggerAuthentication
@@ -210,6 +233,13 @@ type AuthEnvironment struct {
 	ContainerName string `json:"containerName,omitempty"`
 }
 
+// AuthTemplatedParameter is used to authenticate using a Go template rendered over the parameters
+// resolved from the other sources, e.g. "amqp://{{ urlUserInfo .user .password }}@{{ .host }}/"
+type AuthTemplatedParameter struct {
+	Parameter string `json:"parameter"`
+	Template  string `json:"template"`
+}
+
 // HashiCorpVault is used to authenticate using Hashicorp Vault
 type HashiCorpVault struct {
 	Address        string              `json:"address"`
@@ -55,6 +55,9 @@ var _ webhook.Validator = &TriggerAuthentication{}
 func (ta *TriggerAuthentication) ValidateCreate() (admission.Warnings, error) {
 	val, _ := json.MarshalIndent(ta, "", "  ")
 	triggerauthenticationlog.Info(fmt.Sprintf("validating triggerauthentication creation for %s", string(val)))
+	if err := validateAccessSpec(&ta.Spec, false); err != nil {
+		return nil, err
+	}
 	return validateSpec(&ta.Spec)
 }
 
@@ -67,6 +70,9 @@ func (ta *TriggerAuthentication) ValidateUpdate(old runtime.Object) (admission.W
 		triggerauthenticationlog.V(1).Info("finalizer removal, skipping validation")
 		return nil, nil
 	}
+	if err := validateAccessSpec(&ta.Spec, false); err != nil {
+		return nil, err
+	}
 	return validateSpec(&ta.Spec)
 }
 
@@ -82,6 +88,9 @@ var _ webhook.Validator = &ClusterTriggerAuthentication{}
 func (cta *ClusterTriggerAuthentication) ValidateCreate() (admission.Warnings, error) {
 	val, _ := json.MarshalIndent(cta, "", "  ")
 	triggerauthenticationlog.Info(fmt.Sprintf("validating clustertriggerauthentication creation for %s", string(val)))
+	if err := validateAccessSpec(&cta.Spec, true); err != nil {
+		return nil, err
+	}
 	return validateSpec(&cta.Spec)
 }
 
@@ -94,7 +103,9 @@ func (cta *ClusterTriggerAuthentication) ValidateUpdate(old runtime.Object) (adm
 		triggerauthenticationlog.V(1).Info("finalizer removal, skipping validation")
 		return nil, nil
 	}
-
+	if err := validateAccessSpec(&cta.Spec, true); err != nil {
+		return nil, err
+	}
 	return validateSpec(&cta.Spec)
 }
 
@@ -111,7 +122,50 @@ func isTriggerAuthenticationRemovingFinalizer(om metav1.ObjectMeta, oldOm metav1
 	return len(om.Finalizers) == 0 && len(oldOm.Finalizers) == 1 && taSpecString == oldTaSpecString
 }
 
+// validateAccessSpec checks the consumers restrictions, which are only allowed on a ClusterTriggerAuthentication
+func validateAccessSpec(spec *TriggerAuthenticationSpec, clusterScoped bool) error {
+	if !clusterScoped {
+		if spec.AllowedNamespaces != nil || len(spec.AllowedTriggerTypes) > 0 {
+			return fmt.Errorf("allowedNamespaces and allowedTriggerTypes can only be set on a ClusterTriggerAuthentication")
+		}
+		return nil
+	}
+	if spec.AllowedNamespaces != nil && spec.AllowedNamespaces.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(spec.AllowedNamespaces.Selector); err != nil {
+			return fmt.Errorf("invalid allowedNamespaces selector: %w", err)
+		}
+	}
+	for _, triggerType := range spec.AllowedTriggerTypes {
+		if triggerType == "" {
+			return fmt.Errorf("allowedTriggerTypes can't contain an empty trigger type")
+		}
+	}
+	return nil
+}
+
+// validateTemplatedParameters checks that the templated parameters are unique and their templates can be parsed
+func validateTemplatedParameters(spec *TriggerAuthenticationSpec) error {
+	parameters := make(map[string]bool, len(spec.TemplatedParameters))
+	for i := range spec.TemplatedParameters {
+		parameter := &spec.TemplatedParameters[i]
+		if parameter.Parameter == "" {
+			return fmt.Errorf("parameter of templatedParameters[%d] should not be empty", i)
+		}
+		if parameters[parameter.Parameter] {
+			return fmt.Errorf("templated parameter %s is defined multiple times", parameter.Parameter)
+		}
+		parameters[parameter.Parameter] = true
+		if _, err := parameter.Parse(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func validateSpec(spec *TriggerAuthenticationSpec) (admission.Warnings, error) {
+	if err := validateTemplatedParameters(spec); err != nil {
+		return nil, err
+	}
 	if spec.PodIdentity != nil {
 		switch spec.PodIdentity.Provider {
 		case PodIdentityProviderAzureWorkload:
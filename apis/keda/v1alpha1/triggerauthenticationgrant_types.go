@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrTriggerAuthenticationNotGranted is returned when a TriggerAuthentication in another namespace is referenced
+// without a TriggerAuthenticationGrant allowing it
+var ErrTriggerAuthenticationNotGranted = errors.New("no TriggerAuthenticationGrant allows the reference")
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=triggerauthenticationgrants,scope=Namespaced,shortName=tag;triggerauthgrant
+// +kubebuilder:printcolumn:name="From",type="string",JSONPath=".spec.from[*].namespace"
+// +kubebuilder:printcolumn:name="To",type="string",JSONPath=".spec.to[*].name"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// TriggerAuthenticationGrant allows the ScaledObjects and ScaledJobs of other namespaces to reference
+// TriggerAuthentications of its namespace
+type TriggerAuthenticationGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TriggerAuthenticationGrantSpec `json:"spec"`
+}
+
+// TriggerAuthenticationGrantSpec is the spec for a TriggerAuthenticationGrant resource
+type TriggerAuthenticationGrantSpec struct {
+	// From lists the namespaces allowed to reference the granted TriggerAuthentications
+	// +kubebuilder:validation:MinItems=1
+	From []TriggerAuthenticationGrantFrom `json:"from"`
+	// To lists the granted TriggerAuthentications, every TriggerAuthentication of the namespace
+	// is granted when it's empty
+	// +optional
+	To []TriggerAuthenticationGrantTo `json:"to,omitempty"`
+}
+
+// TriggerAuthenticationGrantFrom is a namespace allowed to reference the granted TriggerAuthentications
+type TriggerAuthenticationGrantFrom struct {
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+}
+
+// TriggerAuthenticationGrantTo is a granted TriggerAuthentication
+type TriggerAuthenticationGrantTo struct {
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// +kubebuilder:object:root=true
+
+// TriggerAuthenticationGrantList is a list of TriggerAuthenticationGrant resources
+type TriggerAuthenticationGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []TriggerAuthenticationGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TriggerAuthenticationGrant{}, &TriggerAuthenticationGrantList{})
+}
+
+// Allows checks if the grant allows the namespace to reference the TriggerAuthentication
+func (g *TriggerAuthenticationGrant) Allows(namespace, triggerAuthenticationName string) bool {
+	fromAllowed := false
+	for _, from := range g.Spec.From {
+		if from.Namespace == namespace {
+			fromAllowed = true
+			break
+		}
+	}
+	if !fromAllowed {
+		return false
+	}
+	if len(g.Spec.To) == 0 {
+		return true
+	}
+	for _, to := range g.Spec.To {
+		if to.Name == triggerAuthenticationName {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTriggerAuthenticationGranted checks if one of the grants allows the namespace to reference the TriggerAuthentication
+func IsTriggerAuthenticationGranted(grants []TriggerAuthenticationGrant, namespace, triggerAuthenticationName string) bool {
+	for i := range grants {
+		if grants[i].Allows(namespace, triggerAuthenticationName) {
+			return true
+		}
+	}
+	return false
+}
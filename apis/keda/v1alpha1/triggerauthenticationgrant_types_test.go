@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTriggerAuthenticationGranted(t *testing.T) {
+	grants := []TriggerAuthenticationGrant{
+		{
+			Spec: TriggerAuthenticationGrantSpec{
+				From: []TriggerAuthenticationGrantFrom{{Namespace: "team-a"}},
+				To:   []TriggerAuthenticationGrantTo{{Name: "kafka-auth"}},
+			},
+		},
+		{
+			Spec: TriggerAuthenticationGrantSpec{
+				From: []TriggerAuthenticationGrantFrom{{Namespace: "team-b"}, {Namespace: "team-c"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		namespace string
+		name      string
+		granted   bool
+	}{
+		{namespace: "team-a", name: "kafka-auth", granted: true},
+		{namespace: "team-a", name: "rabbitmq-auth", granted: false},
+		{namespace: "team-c", name: "rabbitmq-auth", granted: true},
+		{namespace: "team-d", name: "kafka-auth", granted: false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.granted, IsTriggerAuthenticationGranted(grants, tt.namespace, tt.name), "%s from %s", tt.name, tt.namespace)
+	}
+	assert.False(t, IsTriggerAuthenticationGranted(nil, "team-a", "kafka-auth"))
+}
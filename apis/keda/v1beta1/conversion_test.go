@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestScaledObjectConversion(t *testing.T) {
+	minReplicaCount := int32(1)
+	so := &ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "default"},
+		Spec: ScaledObjectSpec{
+			ScaleTargetRef:  &kedav1alpha1.ScaleTarget{Name: "orders"},
+			MinReplicaCount: &minReplicaCount,
+			Triggers: []ScaleTriggers{
+				{
+					Type:              "apache-kafka",
+					Config:            &apiextensionsv1.JSON{Raw: []byte(`{"bootstrapServers":["kafka-0:9092","kafka-1:9092"],"consumerGroup":"orders","lagThreshold":50}`)},
+					AuthenticationRef: &kedav1alpha1.AuthenticationRef{Name: "kafka-auth"},
+				},
+				{
+					Type:     "cron",
+					Metadata: map[string]string{"start": "0 6 * * *", "end": "0 20 * * *"},
+				},
+			},
+		},
+	}
+
+	hub := &kedav1alpha1.ScaledObject{}
+	assert.NoError(t, so.ConvertTo(hub))
+	assert.Equal(t, "orders", hub.Name)
+	assert.Equal(t, &minReplicaCount, hub.Spec.MinReplicaCount)
+	assert.Equal(t, map[string]string{
+		"bootstrapServers": "kafka-0:9092,kafka-1:9092",
+		"consumerGroup":    "orders",
+		"lagThreshold":     "50",
+	}, hub.Spec.Triggers[0].Metadata)
+	assert.Equal(t, &kedav1alpha1.AuthenticationRef{Name: "kafka-auth"}, hub.Spec.Triggers[0].AuthenticationRef)
+	assert.Equal(t, so.Spec.Triggers[1].Metadata, hub.Spec.Triggers[1].Metadata)
+
+	converted := &ScaledObject{}
+	assert.NoError(t, converted.ConvertFrom(hub))
+	assert.Equal(t, so.ObjectMeta, converted.ObjectMeta)
+	assert.Nil(t, converted.Spec.Triggers[0].Metadata)
+	assert.JSONEq(t, string(so.Spec.Triggers[0].Config.Raw), string(converted.Spec.Triggers[0].Config.Raw))
+	assert.Equal(t, so.Spec.Triggers[1], converted.Spec.Triggers[1])
+}
+
+func TestScaledJobConversion(t *testing.T) {
+	sj := &ScaledJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "default"},
+		Spec: ScaledJobSpec{
+			RolloutStrategy: "gradual",
+			Triggers: []ScaleTriggers{
+				{
+					Type:   "prometheus",
+					Config: &apiextensionsv1.JSON{Raw: []byte(`{"serverAddress":"http://prometheus:9090","query":"up","threshold":1.5,"ignoreNullValues":false}`)},
+				},
+			},
+		},
+	}
+
+	hub := &kedav1alpha1.ScaledJob{}
+	assert.NoError(t, sj.ConvertTo(hub))
+	assert.Equal(t, "gradual", hub.Spec.RolloutStrategy)
+	assert.Equal(t, map[string]string{
+		"serverAddress":    "http://prometheus:9090",
+		"query":            "up",
+		"threshold":        "1.5",
+		"ignoreNullValues": "false",
+	}, hub.Spec.Triggers[0].Metadata)
+
+	converted := &ScaledJob{}
+	assert.NoError(t, converted.ConvertFrom(hub))
+	assert.Equal(t, "gradual", converted.Spec.RolloutStrategy)
+	assert.JSONEq(t, string(sj.Spec.Triggers[0].Config.Raw), string(converted.Spec.Triggers[0].Config.Raw))
+
+	invalid := &ScaledJob{Spec: ScaledJobSpec{Triggers: []ScaleTriggers{
+		{Type: "prometheus", Config: &apiextensionsv1.JSON{Raw: []byte(`{"query":[["nested"]]}`)}},
+	}}}
+	assert.Error(t, invalid.ConvertTo(&kedav1alpha1.ScaledJob{}))
+}
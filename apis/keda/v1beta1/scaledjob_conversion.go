@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+var _ conversion.Convertible = &ScaledJob{}
+
+// ConvertTo converts the ScaledJob to the keda.sh/v1alpha1 hub version
+func (s *ScaledJob) ConvertTo(hub conversion.Hub) error {
+	dst := hub.(*kedav1alpha1.ScaledJob)
+	triggers, err := convertTriggersToHub(s.Spec.Triggers)
+	if err != nil {
+		return err
+	}
+	dst.ObjectMeta = s.ObjectMeta
+	dst.Spec = kedav1alpha1.ScaledJobSpec{
+		JobTargetRef:               s.Spec.JobTargetRef,
+		PollingInterval:            s.Spec.PollingInterval,
+		SuccessfulJobsHistoryLimit: s.Spec.SuccessfulJobsHistoryLimit,
+		FailedJobsHistoryLimit:     s.Spec.FailedJobsHistoryLimit,
+		RolloutStrategy:            s.Spec.RolloutStrategy,
+		Rollout:                    s.Spec.Rollout,
+		EnvSourceContainerName:     s.Spec.EnvSourceContainerName,
+		MinReplicaCount:            s.Spec.MinReplicaCount,
+		MaxReplicaCount:            s.Spec.MaxReplicaCount,
+		ScalingStrategy:            s.Spec.ScalingStrategy,
+		CapacityGuard:              s.Spec.CapacityGuard,
+		Triggers:                   triggers,
+	}
+	dst.Status = s.Status
+	return nil
+}
+
+// ConvertFrom converts the keda.sh/v1alpha1 hub version to a ScaledJob
+func (s *ScaledJob) ConvertFrom(hub conversion.Hub) error {
+	src := hub.(*kedav1alpha1.ScaledJob)
+	s.ObjectMeta = src.ObjectMeta
+	s.Spec = ScaledJobSpec{
+		JobTargetRef:               src.Spec.JobTargetRef,
+		PollingInterval:            src.Spec.PollingInterval,
+		SuccessfulJobsHistoryLimit: src.Spec.SuccessfulJobsHistoryLimit,
+		FailedJobsHistoryLimit:     src.Spec.FailedJobsHistoryLimit,
+		RolloutStrategy:            src.Spec.RolloutStrategy,
+		Rollout:                    src.Spec.Rollout,
+		EnvSourceContainerName:     src.Spec.EnvSourceContainerName,
+		MinReplicaCount:            src.Spec.MinReplicaCount,
+		MaxReplicaCount:            src.Spec.MaxReplicaCount,
+		ScalingStrategy:            src.Spec.ScalingStrategy,
+		CapacityGuard:              src.Spec.CapacityGuard,
+		Triggers:                   convertTriggersFromHub(src.Spec.Triggers),
+	}
+	s.Status = src.Status
+	return nil
+}
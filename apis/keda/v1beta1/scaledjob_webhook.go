@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var scaledjoblog = logf.Log.WithName("scaledjob-v1beta1-validation-webhook")
+
+// SetupWebhookWithManager registers the conversion webhook and the validation webhook of the typed trigger configs
+func (s *ScaledJob) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(s).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-keda-sh-v1beta1-scaledjob,mutating=false,failurePolicy=ignore,sideEffects=None,groups=keda.sh,resources=scaledjobs,verbs=create;update,versions=v1beta1,name=vscaledjob.v1beta1.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &ScaledJob{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (s *ScaledJob) ValidateCreate() (admission.Warnings, error) {
+	scaledjoblog.V(1).Info(fmt.Sprintf("validating scaledjob %s/%s creation", s.Namespace, s.Name))
+	return validateTriggerConfigs(s.Spec.Triggers)
+}
+
+// ValidateUpdate implements webhook.Validator, the triggers are only validated when they change
+func (s *ScaledJob) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	scaledjoblog.V(1).Info(fmt.Sprintf("validating scaledjob %s/%s update", s.Namespace, s.Name))
+	if reflect.DeepEqual(s.Spec.Triggers, old.(*ScaledJob).Spec.Triggers) {
+		return nil, nil
+	}
+	return validateTriggerConfigs(s.Spec.Triggers)
+}
+
+// ValidateDelete implements webhook.Validator
+func (s *ScaledJob) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+var _ conversion.Convertible = &ScaledObject{}
+
+// ConvertTo converts the ScaledObject to the keda.sh/v1alpha1 hub version
+func (so *ScaledObject) ConvertTo(hub conversion.Hub) error {
+	dst := hub.(*kedav1alpha1.ScaledObject)
+	triggers, err := convertTriggersToHub(so.Spec.Triggers)
+	if err != nil {
+		return err
+	}
+	dst.ObjectMeta = so.ObjectMeta
+	dst.Spec = kedav1alpha1.ScaledObjectSpec{
+		ScaleTargetRef:        so.Spec.ScaleTargetRef,
+		PollingInterval:       so.Spec.PollingInterval,
+		CooldownPeriod:        so.Spec.CooldownPeriod,
+		IdleReplicaCount:      so.Spec.IdleReplicaCount,
+		MinReplicaCount:       so.Spec.MinReplicaCount,
+		MaxReplicaCount:       so.Spec.MaxReplicaCount,
+		Advanced:              so.Spec.Advanced,
+		Triggers:              triggers,
+		Fallback:              so.Spec.Fallback,
+		InitialCooldownPeriod: so.Spec.InitialCooldownPeriod,
+		Followers:             so.Spec.Followers,
+	}
+	dst.Status = so.Status
+	return nil
+}
+
+// ConvertFrom converts the keda.sh/v1alpha1 hub version to a ScaledObject
+func (so *ScaledObject) ConvertFrom(hub conversion.Hub) error {
+	src := hub.(*kedav1alpha1.ScaledObject)
+	so.ObjectMeta = src.ObjectMeta
+	so.Spec = ScaledObjectSpec{
+		ScaleTargetRef:        src.Spec.ScaleTargetRef,
+		PollingInterval:       src.Spec.PollingInterval,
+		CooldownPeriod:        src.Spec.CooldownPeriod,
+		IdleReplicaCount:      src.Spec.IdleReplicaCount,
+		MinReplicaCount:       src.Spec.MinReplicaCount,
+		MaxReplicaCount:       src.Spec.MaxReplicaCount,
+		Advanced:              src.Spec.Advanced,
+		Triggers:              convertTriggersFromHub(src.Spec.Triggers),
+		Fallback:              src.Spec.Fallback,
+		InitialCooldownPeriod: src.Spec.InitialCooldownPeriod,
+		Followers:             src.Spec.Followers,
+	}
+	so.Status = src.Status
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var scaledobjectlog = logf.Log.WithName("scaledobject-v1beta1-validation-webhook")
+
+// SetupWebhookWithManager registers the conversion webhook and the validation webhook of the typed trigger configs
+func (so *ScaledObject) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(so).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-keda-sh-v1beta1-scaledobject,mutating=false,failurePolicy=ignore,sideEffects=None,groups=keda.sh,resources=scaledobjects,verbs=create;update,versions=v1beta1,name=vscaledobject.v1beta1.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &ScaledObject{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (so *ScaledObject) ValidateCreate() (admission.Warnings, error) {
+	scaledobjectlog.V(1).Info(fmt.Sprintf("validating scaledobject %s/%s creation", so.Namespace, so.Name))
+	return validateTriggerConfigs(so.Spec.Triggers)
+}
+
+// ValidateUpdate implements webhook.Validator, the triggers are only validated when they change
+func (so *ScaledObject) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	scaledobjectlog.V(1).Info(fmt.Sprintf("validating scaledobject %s/%s update", so.Namespace, so.Name))
+	if reflect.DeepEqual(so.Spec.Triggers, old.(*ScaledObject).Spec.Triggers) {
+		return nil, nil
+	}
+	return validateTriggerConfigs(so.Spec.Triggers)
+}
+
+// ValidateDelete implements webhook.Validator
+func (so *ScaledObject) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateTriggerConfigs checks the typed configs of the triggers, the rest of the spec is validated
+// by the keda.sh/v1alpha1 webhooks
+func validateTriggerConfigs(triggers []ScaleTriggers) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	for i := range triggers {
+		triggerWarnings, err := validateTriggerConfig(&triggers[i])
+		if err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, triggerWarnings...)
+	}
+	return warnings, nil
+}
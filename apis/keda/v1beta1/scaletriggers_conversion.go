@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// convertTriggersToHub converts the triggers to keda.sh/v1alpha1, a typed config is flattened into the metadata
+func convertTriggersToHub(triggers []ScaleTriggers) ([]kedav1alpha1.ScaleTriggers, error) {
+	if triggers == nil {
+		return nil, nil
+	}
+	converted := make([]kedav1alpha1.ScaleTriggers, 0, len(triggers))
+	for i := range triggers {
+		trigger := &triggers[i]
+		metadata := trigger.Metadata
+		if trigger.Config != nil {
+			var err error
+			if metadata, err = configToMetadata(trigger.Config); err != nil {
+				return nil, fmt.Errorf("error converting the config of trigger %d (%s): %w", i, trigger.Type, err)
+			}
+		}
+		converted = append(converted, kedav1alpha1.ScaleTriggers{
+			Type:              trigger.Type,
+			Name:              trigger.Name,
+			UseCachedMetrics:  trigger.UseCachedMetrics,
+			Metadata:          metadata,
+			AuthenticationRef: trigger.AuthenticationRef,
+			MetricType:        trigger.MetricType,
+		})
+	}
+	return converted, nil
+}
+
+// convertTriggersFromHub converts the triggers from keda.sh/v1alpha1, the metadata of a trigger type with a typed config
+// is converted to a typed config when it converts back to exactly the same metadata
+func convertTriggersFromHub(triggers []kedav1alpha1.ScaleTriggers) []ScaleTriggers {
+	if triggers == nil {
+		return nil
+	}
+	converted := make([]ScaleTriggers, 0, len(triggers))
+	for i := range triggers {
+		trigger := &triggers[i]
+		converted = append(converted, ScaleTriggers{
+			Type:              trigger.Type,
+			Name:              trigger.Name,
+			UseCachedMetrics:  trigger.UseCachedMetrics,
+			Metadata:          trigger.Metadata,
+			AuthenticationRef: trigger.AuthenticationRef,
+			MetricType:        trigger.MetricType,
+		})
+		if config := metadataToConfig(trigger.Type, trigger.Metadata); config != nil {
+			converted[i].Metadata = nil
+			converted[i].Config = config
+		}
+	}
+	return converted
+}
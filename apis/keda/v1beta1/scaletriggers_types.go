@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// ScaleTriggers reference the scaler that will be used
+type ScaleTriggers struct {
+	Type string `json:"type"`
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	UseCachedMetrics bool `json:"useCachedMetrics,omitempty"`
+
+	// Metadata of the trigger as plain strings, like in keda.sh/v1alpha1.
+	// It can't be set together with config.
+	// +optional
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// Config is the typed config of the trigger, its numbers, booleans, arrays and objects are
+	// validated against the parameters of the trigger type. It's only supported by the trigger types
+	// with a typed config and can't be set together with metadata.
+	// +optional
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Config *apiextensionsv1.JSON `json:"config,omitempty"`
+	// +optional
+	AuthenticationRef *kedav1alpha1.AuthenticationRef `json:"authenticationRef,omitempty"`
+	// +optional
+	MetricType autoscalingv2.MetricTargetType `json:"metricType,omitempty"`
+}
+
+// HasTypedConfig checks if the trigger type supports a typed config
+func HasTypedConfig(triggerType string) bool {
+	_, ok := triggerConfigSchemas[triggerType]
+	return ok
+}
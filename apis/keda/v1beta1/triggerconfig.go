@@ -0,0 +1,401 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// JSON types of the typed config parameters, they match the scalersconfig.ParamType values
+const (
+	paramTypeString  = "string"
+	paramTypeInteger = "integer"
+	paramTypeNumber  = "number"
+	paramTypeBoolean = "boolean"
+	paramTypeArray   = "array"
+	paramTypeObject  = "object"
+)
+
+// separators of the array items and object entries in the trigger metadata
+const (
+	elemSeparator       = ","
+	elemKeyValSeparator = "="
+	rangeSeparator      = "-"
+	fromEnvSuffix       = "FromEnv"
+)
+
+// triggerConfigParam describes a parameter of a typed trigger config, see scalersconfig.ParamSchema
+type triggerConfigParam struct {
+	Type       string
+	ItemsType  string
+	Range      bool
+	Required   bool
+	Enum       []string
+	Deprecated string
+	FromEnv    bool
+	EnvOnly    bool
+}
+
+// triggerConfigSchema maps the parameter names of a typed trigger config to their description
+type triggerConfigSchema map[string]triggerConfigParam
+
+// lookup returns the parameter of the config key, the key can be the name of the parameter
+// or the <name>FromEnv key of a parameter which can be read from the container environment
+func (s triggerConfigSchema) lookup(key string) (triggerConfigParam, bool) {
+	if param, ok := s[key]; ok && !param.EnvOnly {
+		return param, true
+	}
+	if name, found := strings.CutSuffix(key, fromEnvSuffix); found {
+		if param, ok := s[name]; ok && param.FromEnv {
+			return triggerConfigParam{Type: paramTypeString}, true
+		}
+	}
+	return triggerConfigParam{}, false
+}
+
+// decodeConfig decodes the typed config keeping the numbers as they are written
+func decodeConfig(config *apiextensionsv1.JSON) (map[string]any, error) {
+	values := map[string]any{}
+	if config == nil || len(config.Raw) == 0 {
+		return values, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(config.Raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&values); err != nil {
+		return nil, fmt.Errorf("config must be an object: %w", err)
+	}
+	return values, nil
+}
+
+// validateTriggerConfig checks the typed config of the trigger against the parameters of its trigger type,
+// it returns warnings for the keys which aren't parameters of the trigger type
+func validateTriggerConfig(trigger *ScaleTriggers) (admission.Warnings, error) {
+	if trigger.Config == nil {
+		return nil, nil
+	}
+	if trigger.Metadata != nil {
+		return nil, fmt.Errorf("trigger %q can't set both metadata and config", trigger.Type)
+	}
+	schema, ok := triggerConfigSchemas[trigger.Type]
+	if !ok {
+		return nil, fmt.Errorf("trigger type %q doesn't support a typed config, use metadata instead", trigger.Type)
+	}
+	values, err := decodeConfig(trigger.Config)
+	if err != nil {
+		return nil, fmt.Errorf("trigger %q: %w", trigger.Type, err)
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var warnings admission.Warnings
+	for _, key := range keys {
+		param, ok := schema.lookup(key)
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("trigger %q: unknown config parameter %q", trigger.Type, key))
+			if _, err := flattenConfigValue(values[key]); err != nil {
+				return nil, fmt.Errorf("trigger %q: parameter %q: %w", trigger.Type, key, err)
+			}
+			continue
+		}
+		if param.Deprecated != "" {
+			message := ""
+			if param.Deprecated != "deprecated" {
+				message = ": " + param.Deprecated
+			}
+			return nil, fmt.Errorf("trigger %q: parameter %q is deprecated%s", trigger.Type, key, message)
+		}
+		if err := validateConfigValue(param, values[key]); err != nil {
+			return nil, fmt.Errorf("trigger %q: parameter %q: %w", trigger.Type, key, err)
+		}
+	}
+
+	names := make([]string, 0, len(schema))
+	for name := range schema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		param := schema[name]
+		if !param.Required {
+			continue
+		}
+		if _, ok := values[name]; !ok {
+			return nil, fmt.Errorf("trigger %q: missing required parameter %q", trigger.Type, name)
+		}
+	}
+	return warnings, nil
+}
+
+// validateConfigValue checks the type and the allowed values of a typed config parameter
+func validateConfigValue(param triggerConfigParam, value any) error {
+	switch param.Type {
+	case paramTypeArray:
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("must be an array")
+		}
+		for i, item := range items {
+			if err := validateConfigScalar(param.ItemsType, param.Enum, param.Range, item); err != nil {
+				return fmt.Errorf("item %d %w", i, err)
+			}
+		}
+		return nil
+	case paramTypeObject:
+		entries, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("must be an object")
+		}
+		for key, entry := range entries {
+			if err := validateConfigScalar(param.ItemsType, nil, false, entry); err != nil {
+				return fmt.Errorf("key %q %w", key, err)
+			}
+		}
+		return nil
+	default:
+		if err := validateConfigScalar(param.Type, param.Enum, false, value); err != nil {
+			return fmt.Errorf("value %w", err)
+		}
+		return nil
+	}
+}
+
+// validateConfigScalar checks the type and the allowed values of a typed config value which isn't an array or an object
+func validateConfigScalar(paramType string, enum []string, allowRange bool, value any) error {
+	switch paramType {
+	case paramTypeInteger:
+		if number, ok := value.(json.Number); ok {
+			if _, err := number.Int64(); err == nil {
+				return nil
+			}
+		}
+		if s, ok := value.(string); ok && allowRange && isIntegerRange(s) {
+			return nil
+		}
+		if allowRange {
+			return fmt.Errorf("must be an integer or a range of integers like 1-5")
+		}
+		return fmt.Errorf("must be an integer")
+	case paramTypeNumber:
+		if number, ok := value.(json.Number); ok {
+			if _, err := number.Float64(); err == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be a number")
+	case paramTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("must be a boolean")
+		}
+		return nil
+	default:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("must be a string")
+		}
+		if len(enum) > 0 && !slices.Contains(enum, s) {
+			return fmt.Errorf("%q must be one of %v", s, enum)
+		}
+		return nil
+	}
+}
+
+// isIntegerRange checks if the value is a range of integers like 1-5
+func isIntegerRange(s string) bool {
+	start, end, found := strings.Cut(s, rangeSeparator)
+	if !found {
+		return false
+	}
+	if _, err := strconv.ParseInt(start, 10, 64); err != nil {
+		return false
+	}
+	_, err := strconv.ParseInt(end, 10, 64)
+	return err == nil
+}
+
+// configToMetadata converts a typed config to the trigger metadata of keda.sh/v1alpha1, the numbers and booleans
+// are written as they are, the arrays as comma separated items and the objects as comma separated key=value entries
+func configToMetadata(config *apiextensionsv1.JSON) (map[string]string, error) {
+	values, err := decodeConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	metadata := make(map[string]string, len(values))
+	for key, value := range values {
+		if value == nil {
+			continue
+		}
+		s, err := flattenConfigValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", key, err)
+		}
+		metadata[key] = s
+	}
+	return metadata, nil
+}
+
+// flattenConfigValue converts a typed config value to its trigger metadata string
+func flattenConfigValue(value any) (string, error) {
+	switch v := value.(type) {
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			s, err := flattenConfigScalar(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, elemSeparator), nil
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		entries := make([]string, 0, len(v))
+		for _, key := range keys {
+			s, err := flattenConfigScalar(v[key])
+			if err != nil {
+				return "", err
+			}
+			entries = append(entries, key+elemKeyValSeparator+s)
+		}
+		return strings.Join(entries, elemSeparator), nil
+	default:
+		return flattenConfigScalar(value)
+	}
+}
+
+// flattenConfigScalar converts a typed config value which isn't an array or an object to its trigger metadata string
+func flattenConfigScalar(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return "", fmt.Errorf("nested arrays, objects and null values aren't supported")
+	}
+}
+
+// metadataToConfig converts the trigger metadata of keda.sh/v1alpha1 to a typed config when the trigger type
+// has one. It returns nil when the typed config wouldn't convert back to exactly the same metadata, the
+// trigger then keeps its metadata.
+func metadataToConfig(triggerType string, metadata map[string]string) *apiextensionsv1.JSON {
+	schema, ok := triggerConfigSchemas[triggerType]
+	if !ok || len(metadata) == 0 {
+		return nil
+	}
+	values := make(map[string]any, len(metadata))
+	for key, s := range metadata {
+		param, ok := schema.lookup(key)
+		if !ok {
+			param = triggerConfigParam{Type: paramTypeString}
+		}
+		value, ok := typedConfigValue(param, s)
+		if !ok {
+			return nil
+		}
+		values[key] = value
+	}
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return nil
+	}
+	config := &apiextensionsv1.JSON{Raw: raw}
+	roundTrip, err := configToMetadata(config)
+	if err != nil || !reflect.DeepEqual(roundTrip, metadata) {
+		return nil
+	}
+	return config
+}
+
+// typedConfigValue parses the trigger metadata string of a typed config parameter
+func typedConfigValue(param triggerConfigParam, s string) (any, bool) {
+	switch param.Type {
+	case paramTypeArray:
+		split := strings.Split(s, elemSeparator)
+		items := make([]any, 0, len(split))
+		for _, item := range split {
+			value, ok := typedConfigScalar(param.ItemsType, item)
+			if !ok && param.Range && isIntegerRange(item) {
+				value, ok = item, true
+			}
+			if !ok {
+				return nil, false
+			}
+			items = append(items, value)
+		}
+		return items, true
+	case paramTypeObject:
+		entries := map[string]any{}
+		for _, entry := range strings.Split(s, elemSeparator) {
+			key, item, found := strings.Cut(entry, elemKeyValSeparator)
+			if !found {
+				return nil, false
+			}
+			value, ok := typedConfigScalar(param.ItemsType, item)
+			if !ok {
+				return nil, false
+			}
+			entries[key] = value
+		}
+		return entries, true
+	default:
+		return typedConfigScalar(param.Type, s)
+	}
+}
+
+// typedConfigScalar parses the trigger metadata string of a value which isn't an array or an object
+func typedConfigScalar(paramType, s string) (any, bool) {
+	switch paramType {
+	case paramTypeInteger:
+		if _, err := strconv.ParseInt(s, 10, 64); err != nil {
+			return nil, false
+		}
+		return json.Number(s), true
+	case paramTypeNumber:
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return nil, false
+		}
+		return json.Number(s), true
+	case paramTypeBoolean:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, false
+		}
+		return b, true
+	default:
+		return s, true
+	}
+}
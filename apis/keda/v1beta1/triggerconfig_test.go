@@ -0,0 +1,189 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestValidateTriggerConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		trigger       ScaleTriggers
+		expectedError string
+		warnings      int
+	}{
+		{
+			name:    "metadata only",
+			trigger: ScaleTriggers{Type: "cron", Metadata: map[string]string{"start": "0 6 * * *"}},
+		},
+		{
+			name: "valid config",
+			trigger: ScaleTriggers{Type: "apache-kafka", Config: &apiextensionsv1.JSON{Raw: []byte(
+				`{"bootstrapServers": ["kafka-0:9092", "kafka-1:9092"], "consumerGroup": "orders", "lagThreshold": 50, "partitionLimitation": [1, "4-6"], "offsetResetPolicy": "earliest", "allowIdleConsumers": true}`)}},
+		},
+		{
+			name: "unknown parameter is a warning",
+			trigger: ScaleTriggers{Type: "prometheus", Config: &apiextensionsv1.JSON{Raw: []byte(
+				`{"serverAddress": "http://prometheus:9090", "query": "sum(rate(http_requests_total[2m]))", "threshold": 100.5, "extra": "value"}`)}},
+			warnings: 1,
+		},
+		{
+			name: "FromEnv parameter",
+			trigger: ScaleTriggers{Type: "apache-kafka", Config: &apiextensionsv1.JSON{Raw: []byte(
+				`{"bootstrapServersFromEnv": "KAFKA_BROKERS", "consumerGroup": "orders"}`)}},
+		},
+		{
+			name:          "metadata and config",
+			trigger:       ScaleTriggers{Type: "prometheus", Metadata: map[string]string{}, Config: &apiextensionsv1.JSON{Raw: []byte(`{}`)}},
+			expectedError: "can't set both metadata and config",
+		},
+		{
+			name:          "trigger type without typed config",
+			trigger:       ScaleTriggers{Type: "cron", Config: &apiextensionsv1.JSON{Raw: []byte(`{"start": "0 6 * * *"}`)}},
+			expectedError: "doesn't support a typed config",
+		},
+		{
+			name: "wrong type",
+			trigger: ScaleTriggers{Type: "prometheus", Config: &apiextensionsv1.JSON{Raw: []byte(
+				`{"serverAddress": "http://prometheus:9090", "query": "up", "threshold": "100"}`)}},
+			expectedError: `parameter "threshold": value must be a number`,
+		},
+		{
+			name: "integer with a fraction",
+			trigger: ScaleTriggers{Type: "apache-kafka", Config: &apiextensionsv1.JSON{Raw: []byte(
+				`{"bootstrapServers": ["kafka-0:9092"], "consumerGroup": "orders", "lagThreshold": 1.5}`)}},
+			expectedError: `parameter "lagThreshold": value must be an integer`,
+		},
+		{
+			name: "value not in enum",
+			trigger: ScaleTriggers{Type: "apache-kafka", Config: &apiextensionsv1.JSON{Raw: []byte(
+				`{"bootstrapServers": ["kafka-0:9092"], "consumerGroup": "orders", "offsetResetPolicy": "newest"}`)}},
+			expectedError: `parameter "offsetResetPolicy": value "newest" must be one of [earliest latest]`,
+		},
+		{
+			name: "invalid array item",
+			trigger: ScaleTriggers{Type: "apache-kafka", Config: &apiextensionsv1.JSON{Raw: []byte(
+				`{"bootstrapServers": ["kafka-0:9092"], "consumerGroup": "orders", "partitionLimitation": [1, "a-b"]}`)}},
+			expectedError: `parameter "partitionLimitation": item 1 must be an integer or a range of integers like 1-5`,
+		},
+		{
+			name: "missing required parameter",
+			trigger: ScaleTriggers{Type: "prometheus", Config: &apiextensionsv1.JSON{Raw: []byte(
+				`{"serverAddress": "http://prometheus:9090", "threshold": 100}`)}},
+			expectedError: `missing required parameter "query"`,
+		},
+		{
+			name: "deprecated parameter",
+			trigger: ScaleTriggers{Type: "prometheus", Config: &apiextensionsv1.JSON{Raw: []byte(
+				`{"serverAddress": "http://prometheus:9090", "query": "up", "threshold": 100, "cortexOrgID": "tenant"}`)}},
+			expectedError: `parameter "cortexOrgID" is deprecated: use customHeaders instead`,
+		},
+		{
+			name: "nested unknown parameter",
+			trigger: ScaleTriggers{Type: "prometheus", Config: &apiextensionsv1.JSON{Raw: []byte(
+				`{"serverAddress": "http://prometheus:9090", "query": "up", "threshold": 100, "extra": {"nested": {}}}`)}},
+			expectedError: "nested arrays, objects and null values aren't supported",
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := validateTriggerConfig(&tt.trigger)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, warnings, tt.warnings)
+		})
+	}
+}
+
+func TestConfigToMetadata(t *testing.T) {
+	metadata, err := configToMetadata(&apiextensionsv1.JSON{Raw: []byte(
+		`{"bootstrapServers": ["kafka-0:9092", "kafka-1:9092"], "lagThreshold": 50, "threshold": 1.5, "allowIdleConsumers": true, "customHeaders": {"b": "2", "a": "1"}, "unset": null}`)})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"bootstrapServers":   "kafka-0:9092,kafka-1:9092",
+		"lagThreshold":       "50",
+		"threshold":          "1.5",
+		"allowIdleConsumers": "true",
+		"customHeaders":      "a=1,b=2",
+	}, metadata)
+
+	_, err = configToMetadata(&apiextensionsv1.JSON{Raw: []byte(`{"topics": [["a"]]}`)})
+	assert.Error(t, err)
+}
+
+func TestMetadataToConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		triggerType string
+		metadata    map[string]string
+		config      string
+	}{
+		{
+			name:        "typed parameters",
+			triggerType: "apache-kafka",
+			metadata:    map[string]string{"bootstrapServers": "kafka-0:9092,kafka-1:9092", "consumerGroup": "orders", "lagThreshold": "50", "partitionLimitation": "1,4-6", "allowIdleConsumers": "true"},
+			config:      `{"allowIdleConsumers":true,"bootstrapServers":["kafka-0:9092","kafka-1:9092"],"consumerGroup":"orders","lagThreshold":50,"partitionLimitation":[1,"4-6"]}`,
+		},
+		{
+			name:        "unknown parameters are kept as strings",
+			triggerType: "prometheus",
+			metadata:    map[string]string{"serverAddress": "http://prometheus:9090", "threshold": "100.5", "awsRegion": "eu-west-1"},
+			config:      `{"awsRegion":"eu-west-1","serverAddress":"http://prometheus:9090","threshold":100.5}`,
+		},
+		{
+			name:        "trigger type without typed config",
+			triggerType: "cron",
+			metadata:    map[string]string{"start": "0 6 * * *"},
+		},
+		{
+			name:        "value which isn't a valid number",
+			triggerType: "prometheus",
+			metadata:    map[string]string{"threshold": "100m"},
+		},
+		{
+			name:        "value which wouldn't convert back to the same string",
+			triggerType: "apache-kafka",
+			metadata:    map[string]string{"lagThreshold": "050"},
+		},
+		{
+			name:        "array with spaces",
+			triggerType: "apache-kafka",
+			metadata:    map[string]string{"partitionLimitation": "1, 2"},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			config := metadataToConfig(tt.triggerType, tt.metadata)
+			if tt.config == "" {
+				assert.Nil(t, config)
+				return
+			}
+			assert.JSONEq(t, tt.config, string(config.Raw))
+		})
+	}
+}
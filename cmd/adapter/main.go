@@ -85,6 +85,11 @@ func (a *Adapter) makeProvider(ctx context.Context) (provider.ExternalMetricsPro
 		logger.Error(err, "failed to get watch namespace")
 		return nil, nil, fmt.Errorf("failed to get watch namespace (%s)", err)
 	}
+	namespaceSelector, err := kedautil.GetWatchNamespaceSelector()
+	if err != nil {
+		logger.Error(err, "failed to get watch namespace selector")
+		return nil, nil, fmt.Errorf("failed to get watch namespace selector (%s)", err)
+	}
 
 	leaseDuration, err := kedautil.ResolveOsEnvDuration("KEDA_METRICS_LEADER_ELECTION_LEASE_DURATION")
 	if err != nil {
@@ -144,7 +149,8 @@ func (a *Adapter) makeProvider(ctx context.Context) (provider.ExternalMetricsPro
 			close(stopCh)
 		}
 	}()
-	return kedaprovider.NewProvider(ctx, logger, mgr.GetClient(), *grpcClient), stopCh, nil
+	watchScope := kedautil.NewWatchScope(mgr.GetClient(), namespaces, namespaceSelector)
+	return kedaprovider.NewProvider(ctx, logger, mgr.GetClient(), *grpcClient, watchScope), stopCh, nil
 }
 
 // getMetricHandler returns a http handler that exposes metrics from controller-runtime and apiserver
@@ -30,8 +30,10 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/tools/cache"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -47,6 +49,8 @@ import (
 	"github.com/kedacore/keda/v2/pkg/k8s"
 	"github.com/kedacore/keda/v2/pkg/metricscollector"
 	"github.com/kedacore/keda/v2/pkg/metricsservice"
+	"github.com/kedacore/keda/v2/pkg/otlpreceiver"
+	"github.com/kedacore/keda/v2/pkg/scalers"
 	"github.com/kedacore/keda/v2/pkg/scaling"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 	//+kubebuilder:scaffold:imports
@@ -59,6 +63,7 @@ var (
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(metricsv1beta1.AddToScheme(scheme))
 
 	utilruntime.Must(kedav1alpha1.AddToScheme(scheme))
 	utilruntime.Must(eventingv1alpha1.AddToScheme(scheme))
@@ -71,6 +76,11 @@ func main() {
 	var metricsAddr string
 	var probeAddr string
 	var metricsServiceAddr string
+	var webhookTriggerAddr string
+	var otlpGRPCAddr string
+	var otlpHTTPAddr string
+	var otlpRetention time.Duration
+	var otlpMaxSeries int
 	var profilingAddr string
 	var enableLeaderElection bool
 	var adapterClientRequestQPS float32
@@ -85,12 +95,19 @@ func main() {
 	var k8sClusterDomain string
 	var enableCertRotation bool
 	var validatingWebhookName string
+	var mutatingWebhookName string
+	var conversionCRDNames []string
 	var caDirs []string
 	pflag.BoolVar(&enablePrometheusMetrics, "enable-prometheus-metrics", true, "Enable the prometheus metric of keda-operator.")
 	pflag.BoolVar(&enableOpenTelemetryMetrics, "enable-opentelemetry-metrics", false, "Enable the opentelemetry metric of keda-operator.")
 	pflag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the prometheus metric endpoint binds to.")
 	pflag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	pflag.StringVar(&metricsServiceAddr, "metrics-service-bind-address", ":9666", "The address the gRPRC Metrics Service endpoint binds to.")
+	pflag.StringVar(&webhookTriggerAddr, "webhook-trigger-bind-address", "", "The address the endpoints of the webhook triggers bind to, e.g. :8090. They are disabled by default, served by the leader over plain HTTP and TLS has to be terminated in front of them.")
+	pflag.StringVar(&otlpGRPCAddr, "otlp-grpc-bind-address", "", "The address the OTLP/gRPC metrics receiver of the otel triggers binds to, empty to disable it.")
+	pflag.StringVar(&otlpHTTPAddr, "otlp-http-bind-address", "", "The address the OTLP/HTTP metrics receiver of the otel triggers binds to, empty to disable it.")
+	pflag.DurationVar(&otlpRetention, "otlp-retention", 10*time.Minute, "How long the OTLP receiver keeps the received samples. Defaults to 10m")
+	pflag.IntVar(&otlpMaxSeries, "otlp-max-series", 10000, "The maximum number of series kept by the OTLP receiver, the samples of new series are rejected above it. Defaults to 10000")
 	pflag.StringVar(&profilingAddr, "profiling-bind-address", "", "The address the profiling would be exposed on.")
 	pflag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
@@ -107,6 +124,8 @@ func main() {
 	pflag.StringVar(&k8sClusterDomain, "k8s-cluster-domain", "cluster.local", "Kubernetes cluster domain. Defaults to cluster.local")
 	pflag.BoolVar(&enableCertRotation, "enable-cert-rotation", false, "enable automatic generation and rotation of TLS certificates/keys")
 	pflag.StringVar(&validatingWebhookName, "validating-webhook-name", "keda-admission", "ValidatingWebhookConfiguration name. Defaults to keda-admission")
+	pflag.StringVar(&mutatingWebhookName, "mutating-webhook-name", "keda-admission-mutation", "MutatingWebhookConfiguration name, empty to not manage its certificates. Defaults to keda-admission-mutation")
+	pflag.StringSliceVar(&conversionCRDNames, "conversion-crd-names", []string{"scaledobjects.keda.sh", "scaledjobs.keda.sh"}, "CustomResourceDefinitions with a conversion webhook, empty to not manage their certificates. Defaults to scaledobjects.keda.sh,scaledjobs.keda.sh")
 	pflag.StringArrayVar(&caDirs, "ca-dir", []string{"/custom/ca"}, "Directory with CA certificates for scalers to authenticate TLS connections. Can be specified multiple times. Defaults to /custom/ca")
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
@@ -120,6 +139,11 @@ func main() {
 		setupLog.Error(err, "failed to get watch namespace")
 		os.Exit(1)
 	}
+	namespaceSelector, err := kedautil.GetWatchNamespaceSelector()
+	if err != nil {
+		setupLog.Error(err, "failed to get watch namespace selector")
+		os.Exit(1)
+	}
 
 	leaseDuration, err := kedautil.ResolveOsEnvDuration("KEDA_OPERATOR_LEADER_ELECTION_LEASE_DURATION")
 	if err != nil {
@@ -160,6 +184,12 @@ func main() {
 		Cache: ctrlcache.Options{
 			DefaultNamespaces: namespaces,
 		},
+		Client: client.Options{
+			Cache: &client.CacheOptions{
+				// the metrics.k8s.io API doesn't support watch, the cpu/memory metric sources read it directly
+				DisableFor: []client.Object{&metricsv1beta1.PodMetrics{}},
+			},
+		},
 		HealthProbeBindAddress:  probeAddr,
 		PprofBindAddress:        profilingAddr,
 		LeaderElection:          enableLeaderElection,
@@ -217,7 +247,11 @@ func main() {
 		os.Exit(1)
 	}
 
-	scaledHandler := scaling.NewScaleHandler(mgr.GetClient(), scaleClient, mgr.GetScheme(), globalHTTPTimeout, eventRecorder, secretInformer.Lister())
+	// the selector isn't applied to the caches: they keep watching all the namespaces of the list, or the whole
+	// cluster when it's empty, and the reconcilers only manage the ScaledObjects and ScaledJobs of the matching ones
+	watchScope := kedautil.NewWatchScope(mgr.GetClient(), namespaces, namespaceSelector)
+
+	scaledHandler := scaling.NewScaleHandler(mgr.GetClient(), mgr.GetAPIReader(), scaleClient, mgr.GetScheme(), globalHTTPTimeout, eventRecorder, secretInformer.Lister())
 	eventEmitter := eventemitter.NewEventEmitter(mgr.GetClient(), eventRecorder, k8sClusterName, secretInformer.Lister())
 
 	if err = (&kedacontrollers.ScaledObjectReconciler{
@@ -227,6 +261,7 @@ func main() {
 		ScaleClient:  scaleClient,
 		ScaleHandler: scaledHandler,
 		EventEmitter: eventEmitter,
+		WatchScope:   watchScope,
 	}).SetupWithManager(mgr, controller.Options{
 		MaxConcurrentReconciles: scaledObjectMaxReconciles,
 	}); err != nil {
@@ -240,12 +275,29 @@ func main() {
 		Recorder:          eventRecorder,
 		SecretsLister:     secretInformer.Lister(),
 		SecretsSynced:     secretInformer.Informer().HasSynced,
+		WatchScope:        watchScope,
 	}).SetupWithManager(mgr, controller.Options{
 		MaxConcurrentReconciles: scaledJobMaxReconciles,
 	}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ScaledJob")
 		os.Exit(1)
 	}
+	if err = (&kedacontrollers.ScaledGroupReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: eventRecorder,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ScaledGroup")
+		os.Exit(1)
+	}
+	if err = (&kedacontrollers.ScaledObjectSetReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: eventRecorder,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ScaledObjectSet")
+		os.Exit(1)
+	}
 	if err = (&kedacontrollers.TriggerAuthenticationReconciler{
 		Client:        mgr.GetClient(),
 		EventRecorder: eventRecorder,
@@ -290,6 +342,8 @@ func main() {
 			CAName:                "KEDA",
 			CAOrganization:        "KEDAORG",
 			ValidatingWebhookName: validatingWebhookName,
+			MutatingWebhookName:   mutatingWebhookName,
+			ConversionCRDNames:    conversionCRDNames,
 			APIServiceName:        "v1beta1.external.metrics.k8s.io",
 			Logger:                setupLog,
 			Ready:                 certReady,
@@ -310,6 +364,27 @@ func main() {
 		os.Exit(1)
 	}
 
+	if webhookTriggerAddr != "" {
+		webhookTriggerServer := scalers.NewWebhookTriggerServer(mgr.GetClient(), webhookTriggerAddr, kedautil.GetPodName(), kedautil.GetPodNamespace(), ctrl.Log.WithName("webhooktriggerserver"))
+		// a pod which was the leader before a restart keeps the label until it's removed
+		if err := webhookTriggerServer.SetLeaderLabel(ctx, false); err != nil {
+			setupLog.Error(err, "unable to remove the webhook triggers leader label of the pod")
+		}
+		if err := mgr.Add(webhookTriggerServer); err != nil {
+			setupLog.Error(err, "unable to set up webhook trigger server")
+			os.Exit(1)
+		}
+	}
+
+	if otlpGRPCAddr != "" || otlpHTTPAddr != "" {
+		otlpStore := otlpreceiver.NewStore(otlpRetention, otlpMaxSeries)
+		otlpreceiver.SetDefaultStore(otlpStore)
+		if err := mgr.Add(otlpreceiver.NewReceiver(otlpStore, otlpGRPCAddr, otlpHTTPAddr)); err != nil {
+			setupLog.Error(err, "unable to set up OTLP receiver")
+			os.Exit(1)
+		}
+	}
+
 	kedautil.PrintWelcome(setupLog, kubeVersion, "manager")
 
 	kubeInformerFactory.Start(ctx.Done())
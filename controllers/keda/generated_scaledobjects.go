@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// ensureGeneratedScaledObject creates a ScaledObject generated by another KEDA resource or updates
+// the existing one if it differs. The existing ScaledObject has to be controlled by the same owner.
+// Labels, annotations and owner references set on the existing ScaledObject are kept, except for the
+// managedAnnotations, which are removed if they aren't present on the generated ScaledObject anymore.
+func ensureGeneratedScaledObject(ctx context.Context, logger logr.Logger, c client.Client, scaledObject *kedav1alpha1.ScaledObject, managedAnnotations ...string) error {
+	found := &kedav1alpha1.ScaledObject{}
+	err := c.Get(ctx, types.NamespacedName{Name: scaledObject.Name, Namespace: scaledObject.Namespace}, found)
+	if errors.IsNotFound(err) {
+		logger.Info("Creating a new generated ScaledObject", "ScaledObject.Name", scaledObject.Name)
+		return c.Create(ctx, scaledObject)
+	} else if err != nil {
+		return err
+	}
+
+	owner, foundOwner := metav1.GetControllerOf(scaledObject), metav1.GetControllerOf(found)
+	if owner == nil || foundOwner == nil || foundOwner.UID != owner.UID {
+		return fmt.Errorf("ScaledObject %s already exists and is not generated by the same resource", found.Name)
+	}
+
+	annotationsChanged := !equality.Semantic.DeepDerivative(scaledObject.Annotations, found.Annotations)
+	for _, key := range managedAnnotations {
+		_, wanted := scaledObject.Annotations[key]
+		_, present := found.Annotations[key]
+		if present && !wanted {
+			annotationsChanged = true
+		}
+	}
+
+	// the spec is owned by the generator, a field removed from the generated spec is removed from the existing one too
+	if equality.Semantic.DeepEqual(scaledObject.Spec, found.Spec) &&
+		equality.Semantic.DeepDerivative(scaledObject.Labels, found.Labels) &&
+		equality.Semantic.DeepEqual(mergeOwnerReferences(found.OwnerReferences, scaledObject.OwnerReferences), found.OwnerReferences) &&
+		!annotationsChanged {
+		return nil
+	}
+
+	found.Spec = scaledObject.Spec
+	found.OwnerReferences = mergeOwnerReferences(found.OwnerReferences, scaledObject.OwnerReferences)
+	if found.Labels == nil {
+		found.Labels = map[string]string{}
+	}
+	for key, value := range scaledObject.Labels {
+		found.Labels[key] = value
+	}
+	for _, key := range managedAnnotations {
+		delete(found.Annotations, key)
+	}
+	if len(scaledObject.Annotations) > 0 && found.Annotations == nil {
+		found.Annotations = map[string]string{}
+	}
+	for key, value := range scaledObject.Annotations {
+		found.Annotations[key] = value
+	}
+
+	logger.Info("Updating generated ScaledObject", "ScaledObject.Name", found.Name)
+	return c.Update(ctx, found)
+}
+
+// mergeOwnerReferences returns the existing owner references with the wanted ones added,
+// a wanted owner reference replaces the existing one with the same UID
+func mergeOwnerReferences(existing, wanted []metav1.OwnerReference) []metav1.OwnerReference {
+	merged := make([]metav1.OwnerReference, len(existing), len(existing)+len(wanted))
+	copy(merged, existing)
+	for _, ref := range wanted {
+		found := false
+		for i := range merged {
+			if merged[i].UID == ref.UID {
+				merged[i] = ref
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, ref)
+		}
+	}
+	return merged
+}
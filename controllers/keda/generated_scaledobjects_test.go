@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func newGeneratedTestScaledObject() *kedav1alpha1.ScaledObject {
+	return &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "orders-consumer",
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ScaledGroup", Name: "orders", UID: types.UID("group-uid"), Controller: ptr.To(true)}},
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "orders-consumer"},
+			CooldownPeriod: ptr.To[int32](60),
+			Fallback:       &kedav1alpha1.Fallback{FailureThreshold: 3, Replicas: 2},
+			Triggers: []kedav1alpha1.ScaleTriggers{{
+				Type:     "rabbitmq",
+				Metadata: map[string]string{"queueName": "orders", "value": "20", "mode": "QueueLength"},
+			}},
+		},
+	}
+}
+
+func TestEnsureGeneratedScaledObjectRemovesFields(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newGeneratedTestScaledObject()).Build()
+
+	generated := newGeneratedTestScaledObject()
+	generated.Spec.CooldownPeriod = nil
+	generated.Spec.Fallback = nil
+	delete(generated.Spec.Triggers[0].Metadata, "mode")
+	require.NoError(t, ensureGeneratedScaledObject(ctx, logr.Discard(), c, generated))
+
+	found := &kedav1alpha1.ScaledObject{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "orders-consumer", Namespace: "default"}, found))
+	assert.Nil(t, found.Spec.CooldownPeriod)
+	assert.Nil(t, found.Spec.Fallback)
+	assert.Equal(t, map[string]string{"queueName": "orders", "value": "20"}, found.Spec.Triggers[0].Metadata)
+}
+
+func TestEnsureGeneratedScaledObjectUnchanged(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newGeneratedTestScaledObject()).Build()
+
+	before := &kedav1alpha1.ScaledObject{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "orders-consumer", Namespace: "default"}, before))
+	require.NoError(t, ensureGeneratedScaledObject(ctx, logr.Discard(), c, newGeneratedTestScaledObject()))
+
+	after := &kedav1alpha1.ScaledObject{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "orders-consumer", Namespace: "default"}, after))
+	assert.Equal(t, before.ResourceVersion, after.ResourceVersion)
+}
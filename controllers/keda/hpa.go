@@ -101,6 +101,13 @@ func (r *ScaledObjectReconciler) newHPAForScaledObject(ctx context.Context, logg
 
 	minReplicas := scaledObject.GetHPAMinReplicas()
 	maxReplicas := scaledObject.GetHPAMaxReplicas()
+	// the capacity guard temporarily caps the max replicas to the cluster capacity
+	if limited := scaledObject.Status.CapacityLimitedReplicas; limited != nil && scaledObject.GetCapacityGuard().IsCapacityGuardEnabled() && *limited < maxReplicas {
+		maxReplicas = *limited
+		if minReplicas != nil && *minReplicas > maxReplicas {
+			minReplicas = &maxReplicas
+		}
+	}
 
 	pausedCount, err := executor.GetPausedReplicaCount(scaledObject)
 	if err != nil {
@@ -303,9 +310,9 @@ func (r *ScaledObjectReconciler) getScaledObjectMetricSpecs(ctx context.Context,
 
 			// overwrite external metrics in returned array with composite metric ONLY (keep resource metrics)
 			finalHpaSpecs := []autoscalingv2.MetricSpec{}
-			// keep resource specs
+			// keep resource specs of the cpu/memory triggers which aren't referenced by the formula
 			for _, rm := range scaledObjectMetricSpecs {
-				if rm.Resource != nil {
+				if rm.Resource != nil || rm.ContainerResource != nil {
 					finalHpaSpecs = append(finalHpaSpecs, rm)
 				}
 			}
@@ -18,13 +18,19 @@ package keda
 
 import (
 	"context"
+	"testing"
 
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	v2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
@@ -154,3 +160,62 @@ func setupTest(health map[string]v1alpha1.HealthStatus, scaler *mock_scalers.Moc
 
 	return scaledObject
 }
+
+func TestGetScaledObjectMetricSpecsKeepsResourceMetricsNotInFormula(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	scaleHandler := mock_scaling.NewMockScaleHandler(ctrl)
+	externalScaler := mock_scalers.NewMockScaler(ctrl)
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	scaledObject := &v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{Name: "cpu-formula", Namespace: "default"},
+		Spec: v1alpha1.ScaledObjectSpec{
+			Advanced: &v1alpha1.AdvancedConfig{
+				ScalingModifiers: v1alpha1.ScalingModifiers{Formula: "max(cpu_trig/70, queue_trig)", Target: "1"},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(scaledObject).WithStatusSubresource(scaledObject).Build()
+	reconciler := ScaledObjectReconciler{Client: c, ScaleHandler: scaleHandler}
+
+	newResourceScaler := func(resourceName corev1.ResourceName, metadata map[string]string, asMetricSource bool) cache.ScalerBuilder {
+		config := &scalersconfig.ScalerConfig{TriggerMetadata: metadata, MetricType: v2.UtilizationMetricType, AsMetricSource: asMetricSource}
+		scaler, err := scalers.NewCPUMemoryScaler(resourceName, nil, config)
+		require.NoError(t, err)
+		return cache.ScalerBuilder{Scaler: scaler, ScalerConfig: *config}
+	}
+	externalScaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{{
+		Type:     v2.ExternalMetricSourceType,
+		External: &v2.ExternalMetricSource{Metric: v2.MetricIdentifier{Name: "s2-queue"}},
+	}})
+	scalersCache := &cache.ScalersCache{
+		Scalers: []cache.ScalerBuilder{
+			newResourceScaler(corev1.ResourceCPU, map[string]string{"value": "70"}, true),
+			newResourceScaler(corev1.ResourceMemory, map[string]string{"value": "80"}, false),
+			newResourceScaler(corev1.ResourceCPU, map[string]string{"value": "60", "containerName": "sidecar"}, false),
+			{Scaler: externalScaler},
+		},
+	}
+	scaleHandler.EXPECT().GetScalersCache(gomock.Any(), gomock.Any()).Return(scalersCache, nil)
+
+	metricSpecs, err := reconciler.getScaledObjectMetricSpecs(context.Background(), logr.Discard(), scaledObject)
+	require.NoError(t, err)
+
+	require.Len(t, metricSpecs, 3)
+	var resourceNames []corev1.ResourceName
+	var externalNames []string
+	for _, metricSpec := range metricSpecs {
+		switch {
+		case metricSpec.Resource != nil:
+			resourceNames = append(resourceNames, metricSpec.Resource.Name)
+		case metricSpec.ContainerResource != nil:
+			assert.Equal(t, "sidecar", metricSpec.ContainerResource.Container)
+			resourceNames = append(resourceNames, metricSpec.ContainerResource.Name)
+		case metricSpec.External != nil:
+			externalNames = append(externalNames, metricSpec.External.Metric.Name)
+		}
+	}
+	assert.ElementsMatch(t, []corev1.ResourceName{corev1.ResourceMemory, corev1.ResourceCPU}, resourceNames)
+	assert.Equal(t, []string{v1alpha1.CompositeMetricName}, externalNames)
+}
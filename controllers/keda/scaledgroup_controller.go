@@ -0,0 +1,390 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	kedastatus "github.com/kedacore/keda/v2/pkg/status"
+	"github.com/kedacore/keda/v2/pkg/util"
+)
+
+// +kubebuilder:rbac:groups=keda.sh,resources=scaledgroups;scaledgroups/finalizers;scaledgroups/status,verbs="*"
+
+// ScaledGroupReconciler reconciles a ScaledGroup object.
+// Every member of the group gets its own ScaledObject, owned by the ScaledGroup, which
+// idles the member to zero replicas and restores its original replica count once the shared triggers are active.
+type ScaledGroupReconciler struct {
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// groupMemberCandidate is a workload selected by the ScaledGroup
+type groupMemberCandidate struct {
+	kind     string
+	name     string
+	labels   map[string]string
+	replicas *int32
+}
+
+// SetupWithManager initializes the ScaledGroupReconciler instance and starts a new controller managed by the passed Manager instance.
+func (r *ScaledGroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// workloads joining or leaving the group only change their labels, so we enqueue every group in the namespace
+	workloadPredicate := builder.WithPredicates(predicate.LabelChangedPredicate{})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kedav1alpha1.ScaledGroup{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&kedav1alpha1.ScaledObject{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&appsv1.Deployment{}, handler.EnqueueRequestsFromMapFunc(r.scaledGroupsInNamespace), workloadPredicate).
+		Watches(&appsv1.StatefulSet{}, handler.EnqueueRequestsFromMapFunc(r.scaledGroupsInNamespace), workloadPredicate).
+		WithEventFilter(util.IgnoreOtherNamespaces()).
+		Complete(r)
+}
+
+// Reconcile performs reconciliation on the identified ScaledGroup resource based on the request information passed, returns the result and an error (if any).
+func (r *ScaledGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx)
+
+	scaledGroup := &kedav1alpha1.ScaledGroup{}
+	err := r.Client.Get(ctx, req.NamespacedName, scaledGroup)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// generated ScaledObjects are owned by the ScaledGroup and are garbage collected
+			return ctrl.Result{}, nil
+		}
+		reqLogger.Error(err, "failed to get ScaledGroup")
+		return ctrl.Result{}, err
+	}
+
+	if scaledGroup.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	reqLogger.Info("Reconciling ScaledGroup")
+
+	status := scaledGroup.Status.DeepCopy()
+	if !status.Conditions.AreInitialized() {
+		status.Conditions = *kedav1alpha1.GetInitializedConditions()
+	}
+
+	members, msg, err := r.reconcileScaledGroup(ctx, reqLogger, scaledGroup)
+	if members != nil {
+		status.Members = members
+		status.MemberCount = int32(len(members))
+	}
+	if err != nil {
+		reqLogger.Error(err, msg)
+		status.Conditions.SetReadyCondition(metav1.ConditionFalse, "ScaledGroupCheckFailed", msg)
+		r.Recorder.Event(scaledGroup, corev1.EventTypeWarning, eventreason.ScaledGroupCheckFailed, msg)
+	} else {
+		status.Conditions.SetReadyCondition(metav1.ConditionTrue, "ScaledGroupReady", msg)
+	}
+
+	if !equality.Semantic.DeepEqual(&scaledGroup.Status, status) {
+		if updateErr := kedastatus.UpdateScaledGroupStatus(ctx, r.Client, reqLogger, scaledGroup, status); updateErr != nil {
+			return ctrl.Result{}, updateErr
+		}
+	}
+
+	return ctrl.Result{}, err
+}
+
+// reconcileScaledGroup ensures a ScaledObject exists for every member of the group and
+// removes the ScaledObjects of workloads which left the group. A member for which the ScaledObject
+// can't be ensured doesn't block the other members, the failure is returned once all of them are processed.
+func (r *ScaledGroupReconciler) reconcileScaledGroup(ctx context.Context, logger logr.Logger, scaledGroup *kedav1alpha1.ScaledGroup) ([]kedav1alpha1.ScaledGroupMember, string, error) {
+	if len(scaledGroup.Spec.Triggers) == 0 {
+		return nil, "ScaledGroup doesn't have any triggers", fmt.Errorf("no triggers defined in the ScaledGroup")
+	}
+	if err := kedav1alpha1.ValidateTriggers(scaledGroup.Spec.Triggers); err != nil {
+		return nil, "ScaledGroup doesn't have correct triggers specification", err
+	}
+
+	candidates, err := r.getGroupCandidates(ctx, scaledGroup)
+	if err != nil {
+		return nil, "failed to list ScaledGroup members", err
+	}
+
+	scaledObjects := &kedav1alpha1.ScaledObjectList{}
+	if err := r.Client.List(ctx, scaledObjects, client.InNamespace(scaledGroup.Namespace)); err != nil {
+		return nil, "failed to list ScaledObjects", err
+	}
+
+	members, defaulted, err := buildScaledGroupMembers(scaledGroup, candidates, scaledObjects.Items)
+	if err != nil {
+		return nil, "ScaledGroup doesn't have correct wakeOrder specification", err
+	}
+	for _, name := range defaulted {
+		r.Recorder.Eventf(scaledGroup, corev1.EventTypeWarning, eventreason.ScaledGroupOriginalReplicasDefaulted,
+			"Original replica count of %s isn't known, it is woken up to 1 replica", name)
+	}
+
+	var failed []string
+	var lastErr error
+	wanted := make(map[string]bool, len(members))
+	for i := range members {
+		wanted[members[i].ScaledObjectName] = true
+		scaledObject, err := r.newScaledObjectForMember(scaledGroup, members, &members[i])
+		if err == nil {
+			err = ensureGeneratedScaledObject(ctx, logger, r.Client, scaledObject, kedav1alpha1.ScaledGroupWakeAfterAnnotation)
+		}
+		if err != nil {
+			logger.Error(err, "failed to ensure ScaledObject for ScaledGroup member", "member", members[i].Name)
+			failed = append(failed, members[i].Kind+"/"+members[i].Name)
+			lastErr = err
+		}
+	}
+
+	for i := range scaledObjects.Items {
+		scaledObject := &scaledObjects.Items[i]
+		if !metav1.IsControlledBy(scaledObject, scaledGroup) || wanted[scaledObject.Name] {
+			continue
+		}
+		logger.Info("Deleting ScaledObject of a workload which left the ScaledGroup", "ScaledObject.Name", scaledObject.Name)
+		if err := r.Client.Delete(ctx, scaledObject); err != nil && !errors.IsNotFound(err) {
+			return nil, "failed to delete ScaledObject of a former ScaledGroup member", err
+		}
+	}
+
+	if lastErr != nil {
+		return members, fmt.Sprintf("failed to ensure ScaledObjects for ScaledGroup members: %s", strings.Join(failed, ", ")), lastErr
+	}
+	return members, fmt.Sprintf("ScaledGroup manages %d members", len(members)), nil
+}
+
+// getGroupCandidates returns all Deployments and StatefulSets matching the ScaledGroup selector
+func (r *ScaledGroupReconciler) getGroupCandidates(ctx context.Context, scaledGroup *kedav1alpha1.ScaledGroup) ([]groupMemberCandidate, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&scaledGroup.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	opts := []client.ListOption{client.InNamespace(scaledGroup.Namespace), client.MatchingLabelsSelector{Selector: selector}}
+
+	var candidates []groupMemberCandidate
+	deployments := &appsv1.DeploymentList{}
+	if err := r.Client.List(ctx, deployments, opts...); err != nil {
+		return nil, err
+	}
+	for _, deployment := range deployments.Items {
+		candidates = append(candidates, groupMemberCandidate{kind: "Deployment", name: deployment.Name, labels: deployment.Labels, replicas: deployment.Spec.Replicas})
+	}
+
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := r.Client.List(ctx, statefulSets, opts...); err != nil {
+		return nil, err
+	}
+	for _, statefulSet := range statefulSets.Items {
+		candidates = append(candidates, groupMemberCandidate{kind: "StatefulSet", name: statefulSet.Name, labels: statefulSet.Labels, replicas: statefulSet.Spec.Replicas})
+	}
+
+	return candidates, nil
+}
+
+// buildScaledGroupMembers turns the selected workloads into ScaledGroup members, workloads already
+// scaled by a ScaledObject which isn't managed by this group are skipped. The original replica count
+// of existing members is taken from the status or from the generated ScaledObject, so it survives the
+// member being idled. The names of the members whose original replica count defaulted to 1 are returned too.
+func buildScaledGroupMembers(scaledGroup *kedav1alpha1.ScaledGroup, candidates []groupMemberCandidate, scaledObjects []kedav1alpha1.ScaledObject) ([]kedav1alpha1.ScaledGroupMember, []string, error) {
+	foreignTargets := map[string]bool{}
+	recordedReplicas := map[string]int32{}
+	for i := range scaledObjects {
+		scaledObject := &scaledObjects[i]
+		if metav1.IsControlledBy(scaledObject, scaledGroup) {
+			if replicas, err := strconv.ParseInt(scaledObject.Annotations[kedav1alpha1.ScaledGroupOriginalReplicasAnnotation], 10, 32); err == nil && replicas > 0 {
+				recordedReplicas[scaledObject.Name] = int32(replicas)
+			}
+			continue
+		}
+		if scaledObject.Spec.ScaleTargetRef == nil {
+			continue
+		}
+		kind := scaledObject.Spec.ScaleTargetRef.Kind
+		if kind == "" {
+			kind = "Deployment"
+		}
+		foreignTargets[kind+"/"+scaledObject.Spec.ScaleTargetRef.Name] = true
+	}
+
+	stageSelectors := make([]labels.Selector, len(scaledGroup.Spec.WakeOrder))
+	for i, stage := range scaledGroup.Spec.WakeOrder {
+		selector, err := metav1.LabelSelectorAsSelector(&stage.Selector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("wakeOrder[%d]: %w", i, err)
+		}
+		stageSelectors[i] = selector
+	}
+
+	var defaulted []string
+	members := make([]kedav1alpha1.ScaledGroupMember, 0, len(candidates))
+	for _, candidate := range candidates {
+		if foreignTargets[candidate.kind+"/"+candidate.name] {
+			continue
+		}
+
+		member := kedav1alpha1.ScaledGroupMember{
+			Kind:             candidate.kind,
+			Name:             candidate.name,
+			ScaledObjectName: scaledGroup.ScaledObjectNameForMember(candidate.kind, candidate.name),
+			WakeStage:        getWakeStage(stageSelectors, candidate.labels),
+		}
+		existing := scaledGroup.GetMember(candidate.kind, candidate.name)
+		recorded, isRecorded := recordedReplicas[member.ScaledObjectName]
+		switch {
+		case existing != nil:
+			member.OriginalReplicaCount = existing.OriginalReplicaCount
+		case isRecorded:
+			member.OriginalReplicaCount = recorded
+		case candidate.replicas != nil && *candidate.replicas > 0:
+			member.OriginalReplicaCount = *candidate.replicas
+		default:
+			// the workload joined the group already scaled to zero
+			member.OriginalReplicaCount = 1
+			defaulted = append(defaulted, candidate.kind+"/"+candidate.name)
+		}
+		members = append(members, member)
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].WakeStage != members[j].WakeStage {
+			return members[i].WakeStage < members[j].WakeStage
+		}
+		return members[i].ScaledObjectName < members[j].ScaledObjectName
+	})
+	return members, defaulted, nil
+}
+
+// getWakeStage returns the index of the first wake stage matching the labels,
+// workloads not matching any stage are placed after the last one
+func getWakeStage(stageSelectors []labels.Selector, workloadLabels map[string]string) int32 {
+	for i, selector := range stageSelectors {
+		if selector.Matches(labels.Set(workloadLabels)) {
+			return int32(i)
+		}
+	}
+	return int32(len(stageSelectors))
+}
+
+// getWakeAfter returns the names of the ScaledObjects of the closest preceding wake stage with members
+func getWakeAfter(members []kedav1alpha1.ScaledGroupMember, member *kedav1alpha1.ScaledGroupMember) []string {
+	previousStage := int32(-1)
+	for _, m := range members {
+		if m.WakeStage < member.WakeStage && m.WakeStage > previousStage {
+			previousStage = m.WakeStage
+		}
+	}
+	if previousStage < 0 {
+		return nil
+	}
+
+	var names []string
+	for _, m := range members {
+		if m.WakeStage == previousStage {
+			names = append(names, m.ScaledObjectName)
+		}
+	}
+	return names
+}
+
+// newScaledObjectForMember returns the ScaledObject which idles the member to zero replicas
+// and wakes it up to its original replica count
+func (r *ScaledGroupReconciler) newScaledObjectForMember(scaledGroup *kedav1alpha1.ScaledGroup, members []kedav1alpha1.ScaledGroupMember, member *kedav1alpha1.ScaledGroupMember) (*kedav1alpha1.ScaledObject, error) {
+	idleReplicas := int32(0)
+	replicas := member.OriginalReplicaCount
+
+	var triggers []kedav1alpha1.ScaleTriggers
+	for _, trigger := range scaledGroup.Spec.Triggers {
+		triggers = append(triggers, *trigger.DeepCopy())
+	}
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      member.ScaledObjectName,
+			Namespace: scaledGroup.Namespace,
+			Labels: map[string]string{
+				kedav1alpha1.ScaledGroupNameLabel: scaledGroup.Name,
+			},
+			Annotations: map[string]string{
+				kedav1alpha1.ScaledGroupOriginalReplicasAnnotation: strconv.Itoa(int(replicas)),
+			},
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				Name:       member.Name,
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       member.Kind,
+			},
+			PollingInterval:       scaledGroup.Spec.PollingInterval,
+			CooldownPeriod:        scaledGroup.Spec.CooldownPeriod,
+			InitialCooldownPeriod: scaledGroup.Spec.InitialCooldownPeriod,
+			IdleReplicaCount:      &idleReplicas,
+			MinReplicaCount:       &replicas,
+			MaxReplicaCount:       &replicas,
+			Advanced: &kedav1alpha1.AdvancedConfig{
+				RestoreToOriginalReplicaCount: true,
+			},
+			Triggers: triggers,
+		},
+	}
+	if wakeAfter := getWakeAfter(members, member); len(wakeAfter) > 0 {
+		scaledObject.Annotations[kedav1alpha1.ScaledGroupWakeAfterAnnotation] = strings.Join(wakeAfter, ",")
+	}
+
+	if err := controllerutil.SetControllerReference(scaledGroup, scaledObject, r.Scheme); err != nil {
+		return nil, err
+	}
+	return scaledObject, nil
+}
+
+// scaledGroupsInNamespace maps a workload to all ScaledGroups in its namespace
+func (r *ScaledGroupReconciler) scaledGroupsInNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	scaledGroups := &kedav1alpha1.ScaledGroupList{}
+	if err := r.Client.List(ctx, scaledGroups, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list ScaledGroups", "namespace", obj.GetNamespace())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(scaledGroups.Items))
+	for _, scaledGroup := range scaledGroups.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: scaledGroup.Name, Namespace: scaledGroup.Namespace}})
+	}
+	return requests
+}
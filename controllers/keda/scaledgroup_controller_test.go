@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+var _ = Describe("ScaledGroupController", func() {
+	var scaledGroup *kedav1alpha1.ScaledGroup
+
+	BeforeEach(func() {
+		scaledGroup = &kedav1alpha1.ScaledGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "preview", Namespace: "default", UID: types.UID("group-uid")},
+			Spec: kedav1alpha1.ScaledGroupSpec{
+				WakeOrder: []kedav1alpha1.WakeStage{
+					{Selector: metav1.LabelSelector{MatchLabels: map[string]string{"tier": "db"}}},
+					{Selector: metav1.LabelSelector{MatchLabels: map[string]string{"tier": "backend"}}},
+				},
+			},
+		}
+	})
+
+	It("assigns wake stages and original replicas", func() {
+		candidates := []groupMemberCandidate{
+			{kind: "Deployment", name: "frontend", labels: map[string]string{"tier": "frontend"}, replicas: ptr.To[int32](3)},
+			{kind: "StatefulSet", name: "postgres", labels: map[string]string{"tier": "db"}, replicas: ptr.To[int32](0)},
+			{kind: "Deployment", name: "api", labels: map[string]string{"tier": "backend"}},
+		}
+
+		members, defaulted, err := buildScaledGroupMembers(scaledGroup, candidates, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(members).To(HaveLen(3))
+		Expect(defaulted).To(ConsistOf("StatefulSet/postgres", "Deployment/api"))
+
+		Expect(members[0].Name).To(Equal("postgres"))
+		Expect(members[0].WakeStage).To(Equal(int32(0)))
+		Expect(members[0].OriginalReplicaCount).To(Equal(int32(1)))
+		Expect(members[0].ScaledObjectName).To(Equal("preview-statefulset-postgres"))
+
+		Expect(members[1].Name).To(Equal("api"))
+		Expect(members[1].WakeStage).To(Equal(int32(1)))
+
+		Expect(members[2].Name).To(Equal("frontend"))
+		Expect(members[2].WakeStage).To(Equal(int32(2)))
+		Expect(members[2].OriginalReplicaCount).To(Equal(int32(3)))
+
+		Expect(getWakeAfter(members, &members[0])).To(BeEmpty())
+		Expect(getWakeAfter(members, &members[1])).To(Equal([]string{"preview-statefulset-postgres"}))
+		Expect(getWakeAfter(members, &members[2])).To(Equal([]string{"preview-deployment-api"}))
+	})
+
+	It("keeps the recorded original replicas of existing members", func() {
+		scaledGroup.Status.Members = []kedav1alpha1.ScaledGroupMember{
+			{Kind: "Deployment", Name: "frontend", OriginalReplicaCount: 5},
+		}
+		candidates := []groupMemberCandidate{
+			{kind: "Deployment", name: "frontend", replicas: ptr.To[int32](0)},
+		}
+
+		members, _, err := buildScaledGroupMembers(scaledGroup, candidates, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(members).To(HaveLen(1))
+		Expect(members[0].OriginalReplicaCount).To(Equal(int32(5)))
+	})
+
+	It("recovers the original replicas from the generated ScaledObject", func() {
+		candidates := []groupMemberCandidate{
+			{kind: "Deployment", name: "frontend", replicas: ptr.To[int32](0)},
+		}
+		scaledObjects := []kedav1alpha1.ScaledObject{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "preview-deployment-frontend",
+					Namespace:       "default",
+					Annotations:     map[string]string{kedav1alpha1.ScaledGroupOriginalReplicasAnnotation: "5"},
+					OwnerReferences: []metav1.OwnerReference{{UID: scaledGroup.UID, Controller: ptr.To(true)}},
+				},
+				Spec: kedav1alpha1.ScaledObjectSpec{ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "frontend", Kind: "Deployment"}},
+			},
+		}
+
+		members, defaulted, err := buildScaledGroupMembers(scaledGroup, candidates, scaledObjects)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(defaulted).To(BeEmpty())
+		Expect(members).To(HaveLen(1))
+		Expect(members[0].OriginalReplicaCount).To(Equal(int32(5)))
+	})
+
+	It("skips workloads scaled by other ScaledObjects", func() {
+		candidates := []groupMemberCandidate{
+			{kind: "Deployment", name: "frontend"},
+			{kind: "Deployment", name: "worker"},
+		}
+		scaledObjects := []kedav1alpha1.ScaledObject{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"},
+				Spec:       kedav1alpha1.ScaledObjectSpec{ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "worker"}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "preview-deployment-frontend",
+					Namespace:       "default",
+					OwnerReferences: []metav1.OwnerReference{{UID: scaledGroup.UID, Controller: ptr.To(true)}},
+				},
+				Spec: kedav1alpha1.ScaledObjectSpec{ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "frontend", Kind: "Deployment"}},
+			},
+		}
+
+		members, _, err := buildScaledGroupMembers(scaledGroup, candidates, scaledObjects)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(members).To(HaveLen(1))
+		Expect(members[0].Name).To(Equal("frontend"))
+	})
+})
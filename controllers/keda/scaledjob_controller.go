@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
@@ -36,8 +37,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
@@ -57,6 +60,7 @@ type ScaledJobReconciler struct {
 	Scheme            *runtime.Scheme
 	GlobalHTTPTimeout time.Duration
 	Recorder          record.EventRecorder
+	WatchScope        *util.WatchScope
 
 	scaledJobGenerations *sync.Map
 	scaleHandler         scaling.ScaleHandler
@@ -81,9 +85,9 @@ func init() {
 
 // SetupWithManager initializes the ScaledJobReconciler instance and starts a new controller managed by the passed Manager instance.
 func (r *ScaledJobReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
-	r.scaleHandler = scaling.NewScaleHandler(mgr.GetClient(), nil, mgr.GetScheme(), r.GlobalHTTPTimeout, mgr.GetEventRecorderFor("scale-handler"), r.SecretsLister)
+	r.scaleHandler = scaling.NewScaleHandler(mgr.GetClient(), mgr.GetAPIReader(), nil, mgr.GetScheme(), r.GlobalHTTPTimeout, mgr.GetEventRecorderFor("scale-handler"), r.SecretsLister)
 	r.scaledJobGenerations = &sync.Map{}
-	return ctrl.NewControllerManagedBy(mgr).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
 		// Ignore updates to ScaledJob Status (in this case metadata.Generation does not change)
 		// so reconcile loop is not started on Status updates
@@ -92,8 +96,28 @@ func (r *ScaledJobReconciler) SetupWithManager(mgr ctrl.Manager, options control
 				kedacontrollerutil.PausedPredicate{},
 				predicate.GenerationChangedPredicate{},
 			))).
-		WithEventFilter(util.IgnoreOtherNamespaces()).
-		Complete(r)
+		WithEventFilter(util.IgnoreOtherNamespaces())
+	if r.WatchScope.IsDynamic() {
+		// start or stop the scale loops of the ScaledJobs of a namespace labelled in or out of the watch scope
+		controllerBuilder = controllerBuilder.Watches(&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.scaledJobsInNamespace),
+			builder.WithPredicates(r.WatchScope.ScopeChangedPredicate()))
+	}
+	return controllerBuilder.Complete(r)
+}
+
+// scaledJobsInNamespace returns the requests to reconcile the ScaledJobs of the namespace
+func (r *ScaledJobReconciler) scaledJobsInNamespace(ctx context.Context, namespace client.Object) []reconcile.Request {
+	scaledJobs := &kedav1alpha1.ScaledJobList{}
+	if err := r.Client.List(ctx, scaledJobs, client.InNamespace(namespace.GetName())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list ScaledJobs", "namespace", namespace.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(scaledJobs.Items))
+	for _, scaledJob := range scaledJobs.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: scaledJob.Namespace, Name: scaledJob.Name}})
+	}
+	return requests
 }
 
 // Reconcile performs reconciliation on the identified ScaledJob resource based on the request information passed, returns the result and an error (if any).
@@ -122,6 +146,17 @@ func (r *ScaledJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	if scaledJob.GetDeletionTimestamp() != nil {
 		return ctrl.Result{}, r.finalizeScaledJob(ctx, reqLogger, scaledJob, req.NamespacedName.String())
 	}
+
+	inScope, err := r.WatchScope.Contains(ctx, req.Namespace)
+	if err != nil {
+		reqLogger.Error(err, "Failed to check the watch scope of the namespace")
+		return ctrl.Result{}, err
+	}
+	if !inScope {
+		reqLogger.Info("Namespace is out of the watch scope, stopping the scale loop")
+		r.updatePromMetricsOnDelete(req.NamespacedName.String())
+		return ctrl.Result{}, r.stopScaleLoop(ctx, reqLogger, scaledJob)
+	}
 	r.updatePromMetrics(scaledJob, req.NamespacedName.String())
 
 	// ensure finalizer is set on this CR
@@ -257,21 +292,22 @@ func (r *ScaledJobReconciler) checkIfPaused(ctx context.Context, logger logr.Log
 
 // Delete Jobs owned by the previous version of the scaledJob based on the rolloutStrategy given for this scaledJob, if any
 func (r *ScaledJobReconciler) deletePreviousVersionScaleJobs(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob) (string, error) {
-	var rolloutStrategy string
 	if len(scaledJob.Spec.RolloutStrategy) > 0 {
 		logger.Info("RolloutStrategy is deprecated, please us Rollout.Strategy in order to define the desired strategy for job rollouts")
-		rolloutStrategy = scaledJob.Spec.RolloutStrategy
-	} else {
-		rolloutStrategy = scaledJob.Spec.Rollout.Strategy
 	}
 
-	switch rolloutStrategy {
+	version := scaledJob.Version()
+	if err := r.updateRolloutVersion(ctx, logger, scaledJob, version); err != nil {
+		return "Failed to update the rollout status of the scaledJob", err
+	}
+
+	switch scaledJob.GetRolloutStrategy() {
 	case "gradual":
 		logger.Info("RolloutStrategy: gradual, Not deleting jobs owned by the previous version of the scaleJob")
 	default:
 		opts := []client.ListOption{
 			client.InNamespace(scaledJob.GetNamespace()),
-			client.MatchingLabels(map[string]string{"scaledjob.keda.sh/name": scaledJob.GetName()}),
+			client.MatchingLabels(map[string]string{kedav1alpha1.ScaledJobNameLabel: scaledJob.GetName()}),
 		}
 		jobs := &batchv1.JobList{}
 		err := r.Client.List(ctx, jobs, opts...)
@@ -296,7 +332,27 @@ func (r *ScaledJobReconciler) deletePreviousVersionScaleJobs(ctx context.Context
 		}
 		return fmt.Sprintf("RolloutStrategy: immediate, deleted jobs owned by the previous version of the scaleJob: %d jobs deleted", len(jobs.Items)), nil
 	}
-	return fmt.Sprintf("RolloutStrategy: %s", scaledJob.Spec.RolloutStrategy), nil
+	return fmt.Sprintf("RolloutStrategy: %s", scaledJob.GetRolloutStrategy()), nil
+}
+
+// updateRolloutVersion records the version of the scaledJob and the time it was rolled out at in its status,
+// when the job template has changed
+func (r *ScaledJobReconciler) updateRolloutVersion(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, version string) error {
+	rollout := scaledJob.Status.Rollout
+	if rollout != nil && rollout.CurrentVersion == version {
+		return nil
+	}
+
+	now := metav1.Now()
+	updated := &kedav1alpha1.ScaledJobRolloutStatus{
+		CurrentVersion: version,
+		RolloutTime:    &now,
+	}
+	if rollout != nil {
+		updated.Versions = rollout.Versions
+	}
+	logger.Info("Rolling out a new version of the scaledJob", "version", version)
+	return kedastatus.UpdateScaledJobRolloutStatus(ctx, r.Client, logger, scaledJob, updated)
 }
 
 // requestScaleLoop request ScaleLoop handler for the respective ScaledJob
@@ -26,6 +26,7 @@ import (
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -39,8 +40,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	eventingv1alpha1 "github.com/kedacore/keda/v2/apis/eventing/v1alpha1"
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
@@ -59,12 +62,16 @@ import (
 // +kubebuilder:rbac:groups="",resources=configmaps;configmaps/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs="*"
 // +kubebuilder:rbac:groups="",resources=pods;services;services;secrets;external,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups="*",resources="*/scale",verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups="",resources="serviceaccounts",verbs=list;watch
 // +kubebuilder:rbac:groups="*",resources="*",verbs=get
 // +kubebuilder:rbac:groups="apps",resources=deployments;statefulsets,verbs=list;watch
 // +kubebuilder:rbac:groups="coordination.k8s.io",namespace=keda,resources=leases,verbs="*"
 // +kubebuilder:rbac:groups="",resources="limitranges",verbs=list;watch
+// +kubebuilder:rbac:groups="metrics.k8s.io",resources=pods,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=pods,verbs=patch
 
 // ScaledObjectReconciler reconciles a ScaledObject object
 type ScaledObjectReconciler struct {
@@ -74,6 +81,7 @@ type ScaledObjectReconciler struct {
 	ScaleClient  scale.ScalesGetter
 	ScaleHandler scaling.ScaleHandler
 	EventEmitter eventemitter.EventHandler
+	WatchScope   *util.WatchScope
 
 	restMapper               meta.RESTMapper
 	scaledObjectsGenerations *sync.Map
@@ -123,7 +131,7 @@ func (r *ScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options cont
 		return fmt.Errorf("ScaledObjectReconciler.Recorder is not initialized")
 	}
 	// Start controller
-	return ctrl.NewControllerManagedBy(mgr).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
 		// predicate.GenerationChangedPredicate{} ignore updates to ScaledObject Status
 		// (in this case metadata.Generation does not change)
@@ -144,8 +152,28 @@ func (r *ScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options cont
 				predicate.LabelChangedPredicate{},
 				predicate.AnnotationChangedPredicate{},
 				kedacontrollerutil.HPASpecChangedPredicate{},
-			))).
-		Complete(r)
+			)))
+	if r.WatchScope.IsDynamic() {
+		// start or stop the scale loops of the ScaledObjects of a namespace labelled in or out of the watch scope
+		controllerBuilder = controllerBuilder.Watches(&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.scaledObjectsInNamespace),
+			builder.WithPredicates(r.WatchScope.ScopeChangedPredicate()))
+	}
+	return controllerBuilder.Complete(r)
+}
+
+// scaledObjectsInNamespace returns the requests to reconcile the ScaledObjects of the namespace
+func (r *ScaledObjectReconciler) scaledObjectsInNamespace(ctx context.Context, namespace client.Object) []reconcile.Request {
+	scaledObjects := &kedav1alpha1.ScaledObjectList{}
+	if err := r.Client.List(ctx, scaledObjects, client.InNamespace(namespace.GetName())); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list ScaledObjects", "namespace", namespace.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(scaledObjects.Items))
+	for _, scaledObject := range scaledObjects.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: scaledObject.Namespace, Name: scaledObject.Name}})
+	}
+	return requests
 }
 
 // Reconcile performs reconciliation on the identified ScaledObject resource based on the request information passed, returns the result and an error (if any).
@@ -173,6 +201,22 @@ func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	if scaledObject.GetDeletionTimestamp() != nil {
 		return ctrl.Result{}, r.finalizeScaledObject(ctx, reqLogger, scaledObject, req.NamespacedName.String())
 	}
+
+	inScope, err := r.WatchScope.Contains(ctx, req.Namespace)
+	if err != nil {
+		reqLogger.Error(err, "failed to check the watch scope of the namespace")
+		return ctrl.Result{}, err
+	}
+	if !inScope {
+		reqLogger.Info("Namespace is out of the watch scope, stopping the scale loop and deleting the HPA")
+		r.updatePromMetricsOnDelete(req.NamespacedName.String())
+		if err := r.stopScaleLoop(ctx, reqLogger, scaledObject); err != nil {
+			return ctrl.Result{}, err
+		}
+		// the metrics of the HPA aren't served out of the watch scope, it's created again once the namespace is back in
+		_, err := r.ensureHPAForScaledObjectIsDeleted(ctx, reqLogger, scaledObject)
+		return ctrl.Result{}, err
+	}
 	r.updatePromMetrics(scaledObject, req.NamespacedName.String())
 
 	// ensure finalizer is set on this CR
@@ -274,6 +318,17 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 		return "ScaledObject doesn't have correct Idle/Min/Max Replica Counts specification", err
 	}
 
+	err = kedav1alpha1.CheckFollowersValid(scaledObject)
+	if err != nil {
+		return "ScaledObject doesn't have correct followers specification", err
+	}
+
+	// Check if resources targeted by followers exist and expose /scale subresource
+	err = r.checkFollowersAreScalable(ctx, logger, scaledObject)
+	if err != nil {
+		return message.ScaleTargetErrMsg, err
+	}
+
 	err = kedav1alpha1.ValidateTriggers(scaledObject.Spec.Triggers)
 	if err != nil {
 		return "ScaledObject doesn't have correct triggers specification", err
@@ -433,6 +488,41 @@ func (r *ScaledObjectReconciler) checkTargetResourceIsScalable(ctx context.Conte
 	return gvkr, nil
 }
 
+// checkFollowersAreScalable checks that the resources targeted by followers expose /scale subresource
+// and stores their discovered GVKR in the ScaledObject Status, so the scale executor can scale them
+func (r *ScaledObjectReconciler) checkFollowersAreScalable(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) error {
+	followers := make([]kedav1alpha1.FollowerStatus, 0, len(scaledObject.Spec.Followers))
+	for _, follower := range scaledObject.Spec.Followers {
+		gvkr, err := kedav1alpha1.ParseGVKR(r.restMapper, follower.ScaleTargetRef.APIVersion, follower.ScaleTargetRef.Kind)
+		if err != nil {
+			logger.Error(err, "Failed to parse Group, Version, Kind, Resource of follower", "apiVersion", follower.ScaleTargetRef.APIVersion, "kind", follower.ScaleTargetRef.Kind)
+			return err
+		}
+
+		gr := gvkr.GroupResource()
+		if _, isScalable := isScalableCache.Load(gr.String()); !isScalable {
+			if _, err := (r.ScaleClient).Scales(scaledObject.Namespace).Get(ctx, gr, follower.ScaleTargetRef.Name, metav1.GetOptions{}); err != nil {
+				logger.Error(err, message.ScaleTargetNoSubresourceMsg, "resource", gvkr.GVKString(), "name", follower.ScaleTargetRef.Name)
+				r.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.ScaledObjectCheckFailed, message.ScaleTargetNoSubresourceMsg)
+				return err
+			}
+			isScalableCache.Store(gr.String(), true)
+		}
+		followers = append(followers, kedav1alpha1.FollowerStatus{Name: follower.ScaleTargetRef.Name, ScaleTargetGVKR: gvkr})
+	}
+
+	if equality.Semantic.DeepEqual(followers, scaledObject.Status.Followers) {
+		return nil
+	}
+
+	status := scaledObject.Status.DeepCopy()
+	status.Followers = followers
+	if len(followers) == 0 {
+		status.Followers = nil
+	}
+	return kedastatus.UpdateScaledObjectStatus(ctx, r.Client, logger, scaledObject, status)
+}
+
 // ensureHPAForScaledObjectExists ensures that in cluster exist up-to-date HPA for specified ScaledObject, returns true if a new HPA was created
 func (r *ScaledObjectReconciler) ensureHPAForScaledObjectExists(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, gvkr *kedav1alpha1.GroupVersionKindResource) (bool, error) {
 	hpaName := getHPANameOnEnsure(scaledObject)
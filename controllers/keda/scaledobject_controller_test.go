@@ -19,11 +19,15 @@ package keda
 import (
 	"context"
 	"fmt"
+	"sync"
 	"sync/atomic"
+	"testing"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
@@ -31,7 +35,12 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
@@ -1470,3 +1479,35 @@ func generateDeployment(name string) *appsv1.Deployment {
 		},
 	}
 }
+
+func TestReconcileOutOfWatchScopeDeletesHPA(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kedav1alpha1.AddToScheme(scheme))
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "out-of-scope"}}
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "so", Namespace: namespace.Name, Finalizers: []string{"finalizer.keda.sh"}},
+		Spec:       kedav1alpha1.ScaledObjectSpec{ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "deployment"}},
+	}
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Name: getHPAName(scaledObject), Namespace: namespace.Name}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(namespace, scaledObject, hpa).Build()
+
+	selector, err := labels.Parse("keda=enabled")
+	require.NoError(t, err)
+	scaleHandler := mock_scaling.NewMockScaleHandler(gomock.NewController(t))
+	scaleHandler.EXPECT().DeleteScalableObject(gomock.Any(), gomock.Any()).Return(nil)
+	reconciler := &ScaledObjectReconciler{
+		Client:                   c,
+		ScaleHandler:             scaleHandler,
+		WatchScope:               util.NewWatchScope(c, nil, selector),
+		scaledObjectsGenerations: &sync.Map{},
+	}
+
+	_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: scaledObject.Name, Namespace: namespace.Name}})
+	require.NoError(t, err)
+
+	err = c.Get(ctx, types.NamespacedName{Name: hpa.Name, Namespace: namespace.Name}, &autoscalingv2.HorizontalPodAutoscaler{})
+	assert.True(t, errors.IsNotFound(err), "expected the HPA to be deleted, got %v", err)
+}
@@ -0,0 +1,379 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	kedastatus "github.com/kedacore/keda/v2/pkg/status"
+	"github.com/kedacore/keda/v2/pkg/util"
+)
+
+// +kubebuilder:rbac:groups=keda.sh,resources=scaledobjectsets;scaledobjectsets/finalizers;scaledobjectsets/status,verbs="*"
+
+// ScaledObjectSetReconciler reconciles a ScaledObjectSet object.
+// Every selected workload gets a ScaledObject rendered from the template, owned by the ScaledObjectSet
+// and by the workload, so the ScaledObject is garbage collected once the workload is deleted.
+type ScaledObjectSetReconciler struct {
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// scaledObjectTemplateData is the data the ScaledObjectSet template is rendered with
+type scaledObjectTemplateData struct {
+	Name        string
+	Namespace   string
+	Labels      map[string]string
+	Annotations map[string]string
+
+	uid        types.UID
+	apiVersion string
+}
+
+// SetupWithManager initializes the ScaledObjectSetReconciler instance and starts a new controller managed by the passed Manager instance.
+func (r *ScaledObjectSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// the template is rendered from the workload labels and annotations, so only changes to those are relevant,
+	// and only for the ScaledObjectSets selecting the workload
+	workloadPredicate := builder.WithPredicates(predicate.Or(predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{}))
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kedav1alpha1.ScaledObjectSet{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&kedav1alpha1.ScaledObject{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&appsv1.Deployment{}, handler.EnqueueRequestsFromMapFunc(r.scaledObjectSetsForWorkload), workloadPredicate).
+		Watches(&appsv1.StatefulSet{}, handler.EnqueueRequestsFromMapFunc(r.scaledObjectSetsForWorkload), workloadPredicate).
+		WithEventFilter(util.IgnoreOtherNamespaces()).
+		Complete(r)
+}
+
+// Reconcile performs reconciliation on the identified ScaledObjectSet resource based on the request information passed, returns the result and an error (if any).
+func (r *ScaledObjectSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx)
+
+	scaledObjectSet := &kedav1alpha1.ScaledObjectSet{}
+	err := r.Client.Get(ctx, req.NamespacedName, scaledObjectSet)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// generated ScaledObjects are owned by the ScaledObjectSet and are garbage collected
+			return ctrl.Result{}, nil
+		}
+		reqLogger.Error(err, "failed to get ScaledObjectSet")
+		return ctrl.Result{}, err
+	}
+
+	if scaledObjectSet.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	reqLogger.Info("Reconciling ScaledObjectSet")
+
+	status := scaledObjectSet.Status.DeepCopy()
+	if !status.Conditions.AreInitialized() {
+		status.Conditions = *kedav1alpha1.GetInitializedConditions()
+	}
+
+	scaledObjectNames, msg, err := r.reconcileScaledObjectSet(ctx, reqLogger, scaledObjectSet)
+	if scaledObjectNames != nil {
+		status.ScaledObjects = scaledObjectNames
+		status.ScaledObjectCount = int32(len(scaledObjectNames))
+	}
+	if err != nil {
+		reqLogger.Error(err, msg)
+		status.Conditions.SetReadyCondition(metav1.ConditionFalse, "ScaledObjectSetCheckFailed", msg)
+		r.Recorder.Event(scaledObjectSet, corev1.EventTypeWarning, eventreason.ScaledObjectSetCheckFailed, msg)
+	} else {
+		status.Conditions.SetReadyCondition(metav1.ConditionTrue, "ScaledObjectSetReady", msg)
+	}
+
+	if !equality.Semantic.DeepEqual(&scaledObjectSet.Status, status) {
+		if updateErr := kedastatus.UpdateScaledObjectSetStatus(ctx, r.Client, reqLogger, scaledObjectSet, status); updateErr != nil {
+			return ctrl.Result{}, updateErr
+		}
+	}
+
+	return ctrl.Result{}, err
+}
+
+// reconcileScaledObjectSet ensures a ScaledObject exists for every selected workload and removes
+// the ScaledObjects of workloads which aren't selected anymore. A workload for which the template
+// can't be rendered doesn't block the other workloads, the failure is returned once all of them are processed.
+func (r *ScaledObjectSetReconciler) reconcileScaledObjectSet(ctx context.Context, logger logr.Logger, scaledObjectSet *kedav1alpha1.ScaledObjectSet) ([]string, string, error) {
+	if len(scaledObjectSet.Spec.Template.Spec.Triggers) == 0 {
+		return nil, "ScaledObjectSet template doesn't have any triggers", fmt.Errorf("no triggers defined in the ScaledObjectSet template")
+	}
+	if err := kedav1alpha1.ValidateTriggers(scaledObjectSet.Spec.Template.Spec.Triggers); err != nil {
+		return nil, "ScaledObjectSet template doesn't have correct triggers specification", err
+	}
+
+	workloads, err := r.getSelectedWorkloads(ctx, scaledObjectSet)
+	if err != nil {
+		return nil, "failed to list ScaledObjectSet workloads", err
+	}
+
+	var generated, failed []string
+	var lastErr error
+	wanted := make(map[string]bool, len(workloads))
+	for i := range workloads {
+		name := scaledObjectSet.ScaledObjectNameForWorkload(workloads[i].Name)
+		wanted[name] = true
+
+		scaledObject, err := renderScaledObjectTemplate(scaledObjectSet, &workloads[i])
+		if err == nil {
+			err = controllerutil.SetControllerReference(scaledObjectSet, scaledObject, r.Scheme)
+		}
+		if err == nil {
+			err = ensureGeneratedScaledObject(ctx, logger, r.Client, scaledObject)
+		}
+		if err != nil {
+			logger.Error(err, "failed to generate ScaledObject", "workload", workloads[i].Name)
+			failed = append(failed, workloads[i].Name)
+			lastErr = err
+			continue
+		}
+		generated = append(generated, name)
+	}
+
+	scaledObjects := &kedav1alpha1.ScaledObjectList{}
+	if err := r.Client.List(ctx, scaledObjects, client.InNamespace(scaledObjectSet.Namespace), client.MatchingLabels{kedav1alpha1.ScaledObjectSetNameLabel: scaledObjectSet.Name}); err != nil {
+		return nil, "failed to list ScaledObjects", err
+	}
+	for i := range scaledObjects.Items {
+		scaledObject := &scaledObjects.Items[i]
+		if !metav1.IsControlledBy(scaledObject, scaledObjectSet) || wanted[scaledObject.Name] {
+			continue
+		}
+		logger.Info("Deleting ScaledObject of a workload which isn't selected anymore", "ScaledObject.Name", scaledObject.Name)
+		if err := r.Client.Delete(ctx, scaledObject); err != nil && !errors.IsNotFound(err) {
+			return nil, "failed to delete ScaledObject of a workload which isn't selected anymore", err
+		}
+	}
+
+	sort.Strings(generated)
+	if generated == nil {
+		generated = []string{}
+	}
+
+	if lastErr != nil {
+		return generated, fmt.Sprintf("failed to generate ScaledObjects for workloads: %s", strings.Join(failed, ", ")), lastErr
+	}
+	return generated, fmt.Sprintf("ScaledObjectSet generates %d ScaledObjects", len(generated)), nil
+}
+
+// getSelectedWorkloads returns the workloads of the template scaleTargetRef kind matching the ScaledObjectSet selector
+func (r *ScaledObjectSetReconciler) getSelectedWorkloads(ctx context.Context, scaledObjectSet *kedav1alpha1.ScaledObjectSet) ([]scaledObjectTemplateData, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&scaledObjectSet.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	opts := []client.ListOption{client.InNamespace(scaledObjectSet.Namespace), client.MatchingLabelsSelector{Selector: selector}}
+
+	var objects []metav1.ObjectMeta
+	switch kind := scaledObjectSet.ScaleTargetKind(); kind {
+	case "Deployment":
+		deployments := &appsv1.DeploymentList{}
+		if err := r.Client.List(ctx, deployments, opts...); err != nil {
+			return nil, err
+		}
+		for _, deployment := range deployments.Items {
+			objects = append(objects, deployment.ObjectMeta)
+		}
+	case "StatefulSet":
+		statefulSets := &appsv1.StatefulSetList{}
+		if err := r.Client.List(ctx, statefulSets, opts...); err != nil {
+			return nil, err
+		}
+		for _, statefulSet := range statefulSets.Items {
+			objects = append(objects, statefulSet.ObjectMeta)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported scaleTargetRef kind %s, only Deployment and StatefulSet are supported", kind)
+	}
+
+	workloads := make([]scaledObjectTemplateData, 0, len(objects))
+	for _, object := range objects {
+		if object.DeletionTimestamp != nil {
+			continue
+		}
+		workloads = append(workloads, scaledObjectTemplateData{
+			Name:        object.Name,
+			Namespace:   object.Namespace,
+			Labels:      object.Labels,
+			Annotations: object.Annotations,
+			uid:         object.UID,
+			apiVersion:  appsv1.SchemeGroupVersion.String(),
+		})
+	}
+	return workloads, nil
+}
+
+// renderScaledObjectTemplate returns the ScaledObject generated for the workload from the ScaledObjectSet template
+func renderScaledObjectTemplate(scaledObjectSet *kedav1alpha1.ScaledObjectSet, workload *scaledObjectTemplateData) (*kedav1alpha1.ScaledObject, error) {
+	spec := scaledObjectSet.Spec.Template.Spec.DeepCopy()
+	if spec.ScaleTargetRef == nil {
+		return nil, fmt.Errorf("scaleTargetRef is missing in the ScaledObjectSet template")
+	}
+	spec.ScaleTargetRef.Kind = scaledObjectSet.ScaleTargetKind()
+
+	var err error
+	if spec.ScaleTargetRef.Name, err = renderTemplateValue("scaleTargetRef.name", spec.ScaleTargetRef.Name, workload); err != nil {
+		return nil, err
+	}
+	if spec.ScaleTargetRef.Name == "" {
+		return nil, fmt.Errorf("scaleTargetRef.name of the ScaledObjectSet template is empty for workload %s", workload.Name)
+	}
+
+	for i := range spec.Triggers {
+		trigger := &spec.Triggers[i]
+		if trigger.Name, err = renderTemplateValue(fmt.Sprintf("triggers[%d].name", i), trigger.Name, workload); err != nil {
+			return nil, err
+		}
+		if trigger.Metadata, err = renderTemplateMap(fmt.Sprintf("triggers[%d].metadata", i), trigger.Metadata, workload); err != nil {
+			return nil, err
+		}
+		if trigger.AuthenticationRef != nil {
+			if trigger.AuthenticationRef.Name, err = renderTemplateValue(fmt.Sprintf("triggers[%d].authenticationRef.name", i), trigger.AuthenticationRef.Name, workload); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	scaledObjectLabels, err := renderTemplateMap("metadata.labels", scaledObjectSet.Spec.Template.Metadata.Labels, workload)
+	if err != nil {
+		return nil, err
+	}
+	if scaledObjectLabels == nil {
+		scaledObjectLabels = map[string]string{}
+	}
+	scaledObjectLabels[kedav1alpha1.ScaledObjectSetNameLabel] = scaledObjectSet.Name
+
+	scaledObjectAnnotations, err := renderTemplateMap("metadata.annotations", scaledObjectSet.Spec.Template.Metadata.Annotations, workload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        scaledObjectSet.ScaledObjectNameForWorkload(workload.Name),
+			Namespace:   scaledObjectSet.Namespace,
+			Labels:      scaledObjectLabels,
+			Annotations: scaledObjectAnnotations,
+			// the workload owns the ScaledObject too, so it is garbage collected together with the workload
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: workload.apiVersion,
+				Kind:       spec.ScaleTargetRef.Kind,
+				Name:       workload.Name,
+				UID:        workload.uid,
+			}},
+		},
+		Spec: *spec,
+	}, nil
+}
+
+// renderTemplateMap renders every value of the map as a template
+func renderTemplateMap(field string, values map[string]string, workload *scaledObjectTemplateData) (map[string]string, error) {
+	if values == nil {
+		return nil, nil
+	}
+	rendered := make(map[string]string, len(values))
+	for key, value := range values {
+		renderedValue, err := renderTemplateValue(field+"."+key, value, workload)
+		if err != nil {
+			return nil, err
+		}
+		rendered[key] = renderedValue
+	}
+	return rendered, nil
+}
+
+// renderTemplateValue renders the value as a template, referencing a missing label or annotation is an error
+func renderTemplateValue(field, value string, workload *scaledObjectTemplateData) (string, error) {
+	if !strings.Contains(value, "{{") {
+		return value, nil
+	}
+	tmpl, err := template.New(field).Option("missingkey=error").Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("error parsing template of %s: %w", field, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, workload); err != nil {
+		return "", fmt.Errorf("error rendering template of %s for workload %s: %w", field, workload.Name, err)
+	}
+	return buf.String(), nil
+}
+
+// scaledObjectSetsForWorkload maps a workload to the ScaledObjectSets in its namespace which select it
+// or which generated a ScaledObject for it, so a workload which isn't selected anymore is cleaned up
+func (r *ScaledObjectSetReconciler) scaledObjectSetsForWorkload(ctx context.Context, obj client.Object) []reconcile.Request {
+	scaledObjectSets := &kedav1alpha1.ScaledObjectSetList{}
+	if err := r.Client.List(ctx, scaledObjectSets, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list ScaledObjectSets", "namespace", obj.GetNamespace())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range scaledObjectSets.Items {
+		scaledObjectSet := &scaledObjectSets.Items[i]
+		if !isWorkloadRelevantToScaledObjectSet(scaledObjectSet, obj) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: scaledObjectSet.Name, Namespace: scaledObjectSet.Namespace}})
+	}
+	return requests
+}
+
+// isWorkloadRelevantToScaledObjectSet checks whether the workload is selected by the ScaledObjectSet
+// or has a ScaledObject generated by it
+func isWorkloadRelevantToScaledObjectSet(scaledObjectSet *kedav1alpha1.ScaledObjectSet, obj client.Object) bool {
+	scaledObjectName := scaledObjectSet.ScaledObjectNameForWorkload(obj.GetName())
+	for _, name := range scaledObjectSet.Status.ScaledObjects {
+		if name == scaledObjectName {
+			return true
+		}
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&scaledObjectSet.Spec.Selector)
+	if err != nil {
+		// the error is reported by the reconciliation of the ScaledObjectSet
+		return true
+	}
+	return selector.Matches(labels.Set(obj.GetLabels()))
+}
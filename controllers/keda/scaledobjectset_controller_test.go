@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+var _ = Describe("ScaledObjectSetController", func() {
+	var scaledObjectSet *kedav1alpha1.ScaledObjectSet
+	var workload *scaledObjectTemplateData
+
+	BeforeEach(func() {
+		scaledObjectSet = &kedav1alpha1.ScaledObjectSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "consumers", Namespace: "default"},
+			Spec: kedav1alpha1.ScaledObjectSetSpec{
+				Selector: metav1.LabelSelector{MatchLabels: map[string]string{"role": "consumer"}},
+				Template: kedav1alpha1.ScaledObjectTemplate{
+					Metadata: kedav1alpha1.ScaledObjectTemplateMetadata{
+						Labels: map[string]string{"queue": "{{ .Labels.queue }}"},
+					},
+					Spec: kedav1alpha1.ScaledObjectSpec{
+						ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "{{ .Name }}"},
+						Triggers: []kedav1alpha1.ScaleTriggers{
+							{
+								Type: "rabbitmq",
+								Metadata: map[string]string{
+									"queueName": "{{ .Labels.queue }}",
+									"value":     "{{ index .Annotations \"example.com/queue-length\" }}",
+									"mode":      "QueueLength",
+								},
+								AuthenticationRef: &kedav1alpha1.AuthenticationRef{Name: "{{ .Labels.queue }}-auth"},
+							},
+						},
+					},
+				},
+			},
+		}
+		workload = &scaledObjectTemplateData{
+			Name:        "orders-consumer",
+			Namespace:   "default",
+			Labels:      map[string]string{"role": "consumer", "queue": "orders"},
+			Annotations: map[string]string{"example.com/queue-length": "20"},
+			uid:         types.UID("workload-uid"),
+			apiVersion:  "apps/v1",
+		}
+	})
+
+	It("renders the template from the workload labels and annotations", func() {
+		scaledObject, err := renderScaledObjectTemplate(scaledObjectSet, workload)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(scaledObject.Name).To(Equal("consumers-orders-consumer"))
+		Expect(scaledObject.Labels).To(HaveKeyWithValue("queue", "orders"))
+		Expect(scaledObject.Labels).To(HaveKeyWithValue(kedav1alpha1.ScaledObjectSetNameLabel, "consumers"))
+		Expect(scaledObject.OwnerReferences).To(HaveLen(1))
+		Expect(scaledObject.OwnerReferences[0].UID).To(Equal(types.UID("workload-uid")))
+
+		Expect(scaledObject.Spec.ScaleTargetRef.Name).To(Equal("orders-consumer"))
+		Expect(scaledObject.Spec.ScaleTargetRef.Kind).To(Equal("Deployment"))
+		trigger := scaledObject.Spec.Triggers[0]
+		Expect(trigger.Metadata).To(Equal(map[string]string{"queueName": "orders", "value": "20", "mode": "QueueLength"}))
+		Expect(trigger.AuthenticationRef.Name).To(Equal("orders-auth"))
+
+		// the template itself is left untouched
+		Expect(scaledObjectSet.Spec.Template.Spec.Triggers[0].Metadata["queueName"]).To(Equal("{{ .Labels.queue }}"))
+	})
+
+	It("fails when the workload misses a referenced label", func() {
+		delete(workload.Labels, "queue")
+		_, err := renderScaledObjectTemplate(scaledObjectSet, workload)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("orders-consumer"))
+	})
+
+	It("fails when the scaleTargetRef name is rendered empty", func() {
+		scaledObjectSet.Spec.Template.Spec.ScaleTargetRef.Name = ""
+		_, err := renderScaledObjectTemplate(scaledObjectSet, workload)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("maps only the workloads selected by the ScaledObjectSet or with a generated ScaledObject", func() {
+		selected := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "orders-consumer", Labels: map[string]string{"role": "consumer"}}}
+		other := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "frontend", Labels: map[string]string{"role": "web"}}}
+		formerlySelected := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "payments-consumer", Labels: map[string]string{"role": "web"}}}
+		scaledObjectSet.Status.ScaledObjects = []string{"consumers-payments-consumer"}
+
+		Expect(isWorkloadRelevantToScaledObjectSet(scaledObjectSet, selected)).To(BeTrue())
+		Expect(isWorkloadRelevantToScaledObjectSet(scaledObjectSet, other)).To(BeFalse())
+		Expect(isWorkloadRelevantToScaledObjectSet(scaledObjectSet, formerlySelected)).To(BeTrue())
+	})
+
+	It("keeps the owner references added by other tools", func() {
+		existing := []metav1.OwnerReference{
+			{Kind: "ScaledObjectSet", Name: "consumers", UID: types.UID("set-uid")},
+			{Kind: "Application", Name: "orders", UID: types.UID("app-uid")},
+		}
+		wanted := []metav1.OwnerReference{
+			{Kind: "ScaledObjectSet", Name: "consumers", UID: types.UID("set-uid"), Controller: ptr.To(true)},
+			{Kind: "Deployment", Name: "orders-consumer", UID: types.UID("workload-uid")},
+		}
+
+		merged := mergeOwnerReferences(existing, wanted)
+		Expect(merged).To(Equal([]metav1.OwnerReference{wanted[0], existing[1], wanted[1]}))
+		Expect(existing[0].Controller).To(BeNil())
+	})
+})
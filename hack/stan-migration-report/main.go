@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// stan-migration-report lists every ScaledObject with stan triggers and how the admission webhook would
+// rewrite them into nats-jetstream triggers, without changing anything in the cluster
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func main() {
+	var namespace string
+	pflag.StringVar(&namespace, "namespace", "", "Namespace of the ScaledObjects to report, all namespaces by default")
+	pflag.Parse()
+
+	if err := report(context.Background(), namespace); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func report(ctx context.Context, namespace string) error {
+	scheme := apimachineryruntime.NewScheme()
+	utilruntime.Must(kedav1alpha1.AddToScheme(scheme))
+
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		return fmt.Errorf("error getting the kubeconfig: %w", err)
+	}
+	kubeClient, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("error creating the kubernetes client: %w", err)
+	}
+
+	scaledObjects := &kedav1alpha1.ScaledObjectList{}
+	if err := kubeClient.List(ctx, scaledObjects, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("error listing the ScaledObjects: %w", err)
+	}
+
+	count := 0
+	for i := range scaledObjects.Items {
+		so := &scaledObjects.Items[i]
+		_, changes := so.MigrateStanTriggers()
+		if len(changes) == 0 {
+			continue
+		}
+		count++
+
+		mode := so.StanMigrationMode()
+		if mode == "" {
+			mode = "not enabled"
+		}
+		fmt.Printf("%s/%s (migration %s)\n", so.Namespace, so.Name, mode)
+		for _, change := range changes {
+			fmt.Printf("  - %s\n", change)
+		}
+	}
+	fmt.Printf("%d ScaledObject(s) with stan triggers would change\n", count)
+	return nil
+}
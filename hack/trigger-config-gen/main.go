@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// trigger-config-gen generates the typed trigger configs of the keda.sh/v1beta1 API from the scalersconfig
+// typed structs of the scalers
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"sort"
+
+	"github.com/spf13/pflag"
+
+	"github.com/kedacore/keda/v2/pkg/scalers"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+func main() {
+	var headerFile, output string
+	pflag.StringVar(&headerFile, "header-file", "hack/boilerplate.go.txt", "File with the license header of the generated file")
+	pflag.StringVar(&output, "output", "apis/keda/v1beta1/zz_generated.triggerconfigs.go", "Generated file")
+	pflag.Parse()
+
+	if err := generate(headerFile, output); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func generate(headerFile, output string) error {
+	header, err := os.ReadFile(headerFile)
+	if err != nil {
+		return fmt.Errorf("error reading the header file: %w", err)
+	}
+
+	triggerTypes := make([]string, 0, len(scalers.TypedConfigs))
+	for triggerType := range scalers.TypedConfigs {
+		triggerTypes = append(triggerTypes, triggerType)
+	}
+	sort.Strings(triggerTypes)
+
+	var buf bytes.Buffer
+	buf.Write(header)
+	buf.WriteString("\n// Code generated by trigger-config-gen. DO NOT EDIT.\n\npackage v1beta1\n\n")
+	buf.WriteString("// triggerConfigSchemas are the typed configs of the trigger types, generated from the scalersconfig typed structs\n")
+	buf.WriteString("var triggerConfigSchemas = map[string]triggerConfigSchema{\n")
+	for _, triggerType := range triggerTypes {
+		schema, err := scalersconfig.TypedConfigSchema(scalers.TypedConfigs[triggerType])
+		if err != nil {
+			return fmt.Errorf("error reading the typed config of trigger type %s: %w", triggerType, err)
+		}
+		fmt.Fprintf(&buf, "%q: {\n", triggerType)
+		seen := map[string]bool{}
+		for _, param := range schema {
+			if seen[param.Name] {
+				return fmt.Errorf("trigger type %s declares parameter %s more than once", triggerType, param.Name)
+			}
+			seen[param.Name] = true
+			fmt.Fprintf(&buf, "%q: {Type: %q", param.Name, param.Type)
+			if param.ItemsType != "" {
+				fmt.Fprintf(&buf, ", ItemsType: %q", param.ItemsType)
+			}
+			if param.Range {
+				buf.WriteString(", Range: true")
+			}
+			if param.Required {
+				buf.WriteString(", Required: true")
+			}
+			if len(param.Enum) > 0 {
+				fmt.Fprintf(&buf, ", Enum: %#v", param.Enum)
+			}
+			if param.Deprecated != "" {
+				fmt.Fprintf(&buf, ", Deprecated: %q", param.Deprecated)
+			}
+			if param.FromEnv {
+				buf.WriteString(", FromEnv: true")
+			}
+			if param.EnvOnly {
+				buf.WriteString(", EnvOnly: true")
+			}
+			buf.WriteString("},\n")
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("error formatting the generated file: %w", err)
+	}
+	return os.WriteFile(output, src, 0644)
+}
@@ -35,6 +35,8 @@ import (
 
 // +kubebuilder:rbac:groups=apiregistration.k8s.io,resources=apiservices,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups="",namespace=keda,resources=secrets,verbs=get;list;watch;create;update;patch;delete
 
 type CertManager struct {
@@ -47,6 +49,8 @@ type CertManager struct {
 	CAName                string
 	CAOrganization        string
 	ValidatingWebhookName string
+	MutatingWebhookName   string
+	ConversionCRDNames    []string
 	APIServiceName        string
 	Logger                logr.Logger
 	Ready                 chan struct{}
@@ -64,6 +68,18 @@ func (cm CertManager) AddCertificateRotation(ctx context.Context, mgr manager.Ma
 			Type: rotator.APIService,
 		},
 	}
+	if cm.MutatingWebhookName != "" {
+		rotatorHooks = append(rotatorHooks, rotator.WebhookInfo{
+			Name: cm.MutatingWebhookName,
+			Type: rotator.Mutating,
+		})
+	}
+	for _, crdName := range cm.ConversionCRDNames {
+		rotatorHooks = append(rotatorHooks, rotator.WebhookInfo{
+			Name: crdName,
+			Type: rotator.CRDConversion,
+		})
+	}
 
 	err := cm.ensureSecret(ctx, mgr, cm.SecretName)
 	if err != nil {
@@ -29,6 +29,15 @@ const (
 	// ScaledJobCheckFailed is for event when ScaledJob validation check fails
 	ScaledJobCheckFailed = "ScaledJobCheckFailed"
 
+	// ScaledGroupCheckFailed is for event when ScaledGroup validation check fails
+	ScaledGroupCheckFailed = "ScaledGroupCheckFailed"
+
+	// ScaledGroupOriginalReplicasDefaulted is for event when the original replica count of a ScaledGroup member isn't known and defaults to 1
+	ScaledGroupOriginalReplicasDefaulted = "ScaledGroupOriginalReplicasDefaulted"
+
+	// ScaledObjectSetCheckFailed is for event when ScaledObjectSet validation check fails
+	ScaledObjectSetCheckFailed = "ScaledObjectSetCheckFailed"
+
 	// ScaledObjectUpdateFailed is for event when ScaledObject update status fails
 	ScaledObjectUpdateFailed = "ScaledObjectUpdateFailed"
 
@@ -68,6 +77,9 @@ const (
 	// KEDAJobsCreated is for event when jobs for ScaledJob are created
 	KEDAJobsCreated = "KEDAJobsCreated"
 
+	// KEDAPreviousVersionJobsStopped is for event when jobs of the previous versions of ScaledJob are stopped
+	KEDAPreviousVersionJobsStopped = "KEDAPreviousVersionJobsStopped"
+
 	// TriggerAuthenticationDeleted is for event when a TriggerAuthentication is deleted
 	TriggerAuthenticationDeleted = "TriggerAuthenticationDeleted"
 
@@ -77,6 +89,9 @@ const (
 	// TriggerAuthenticationFailed is for event when a TriggerAuthentication occurs error
 	TriggerAuthenticationFailed = "TriggerAuthenticationFailed"
 
+	// TriggerAuthenticationNotGranted is for event when a TriggerAuthentication in another namespace is referenced without a TriggerAuthenticationGrant
+	TriggerAuthenticationNotGranted = "TriggerAuthenticationNotGranted"
+
 	// ClusterTriggerAuthenticationDeleted is for event when a ClusterTriggerAuthentication is deleted
 	ClusterTriggerAuthenticationDeleted = "ClusterTriggerAuthenticationDeleted"
 
@@ -85,4 +100,7 @@ const (
 
 	// ClusterTriggerAuthenticationFailed is for event when a ClusterTriggerAuthentication occurs error
 	ClusterTriggerAuthenticationFailed = "ClusterTriggerAuthenticationFailed"
+
+	// ClusterTriggerAuthenticationNotAllowed is for event when a ClusterTriggerAuthentication doesn't allow the namespace or the trigger type
+	ClusterTriggerAuthenticationNotAllowed = "ClusterTriggerAuthenticationNotAllowed"
 )
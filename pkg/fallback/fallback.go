@@ -96,6 +96,17 @@ func fallbackExistsInScaledObject(scaledObject *kedav1alpha1.ScaledObject, metri
 	return false
 }
 
+// IsFallbackActive checks if the metric of the trigger is currently replaced by the fallback replicas
+func IsFallbackActive(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2.MetricSpec, metricName string) bool {
+	if scaledObject.Spec.Fallback == nil || metricSpec.External == nil ||
+		metricSpec.External.Target.Type != v2.AverageValueMetricType || !validateFallback(scaledObject) {
+		return false
+	}
+	healthStatus, ok := scaledObject.Status.Health[metricName]
+	return ok && healthStatus.Status == kedav1alpha1.HealthStatusFailing && healthStatus.NumberOfFailures != nil &&
+		*healthStatus.NumberOfFailures > scaledObject.Spec.Fallback.FailureThreshold
+}
+
 func validateFallback(scaledObject *kedav1alpha1.ScaledObject) bool {
 	modifierChecking := true
 	if scaledObject.IsUsingModifiers() {
@@ -32,6 +32,10 @@ func (c *FakeKedaV1alpha1) ClusterTriggerAuthentications() v1alpha1.ClusterTrigg
 	return &FakeClusterTriggerAuthentications{c}
 }
 
+func (c *FakeKedaV1alpha1) ScaledGroups(namespace string) v1alpha1.ScaledGroupInterface {
+	return &FakeScaledGroups{c, namespace}
+}
+
 func (c *FakeKedaV1alpha1) ScaledJobs(namespace string) v1alpha1.ScaledJobInterface {
 	return &FakeScaledJobs{c, namespace}
 }
@@ -40,10 +44,18 @@ func (c *FakeKedaV1alpha1) ScaledObjects(namespace string) v1alpha1.ScaledObject
 	return &FakeScaledObjects{c, namespace}
 }
 
+func (c *FakeKedaV1alpha1) ScaledObjectSets(namespace string) v1alpha1.ScaledObjectSetInterface {
+	return &FakeScaledObjectSets{c, namespace}
+}
+
 func (c *FakeKedaV1alpha1) TriggerAuthentications(namespace string) v1alpha1.TriggerAuthenticationInterface {
 	return &FakeTriggerAuthentications{c, namespace}
 }
 
+func (c *FakeKedaV1alpha1) TriggerAuthenticationGrants(namespace string) v1alpha1.TriggerAuthenticationGrantInterface {
+	return &FakeTriggerAuthenticationGrants{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeKedaV1alpha1) RESTClient() rest.Interface {
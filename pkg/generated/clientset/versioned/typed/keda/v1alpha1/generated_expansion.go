@@ -20,8 +20,14 @@ package v1alpha1
 
 type ClusterTriggerAuthenticationExpansion interface{}
 
+type ScaledGroupExpansion interface{}
+
 type ScaledJobExpansion interface{}
 
 type ScaledObjectExpansion interface{}
 
+type ScaledObjectSetExpansion interface{}
+
 type TriggerAuthenticationExpansion interface{}
+
+type TriggerAuthenticationGrantExpansion interface{}
@@ -29,9 +29,12 @@ import (
 type KedaV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	ClusterTriggerAuthenticationsGetter
+	ScaledGroupsGetter
 	ScaledJobsGetter
 	ScaledObjectsGetter
+	ScaledObjectSetsGetter
 	TriggerAuthenticationsGetter
+	TriggerAuthenticationGrantsGetter
 }
 
 // KedaV1alpha1Client is used to interact with features provided by the keda group.
@@ -43,6 +46,10 @@ func (c *KedaV1alpha1Client) ClusterTriggerAuthentications() ClusterTriggerAuthe
 	return newClusterTriggerAuthentications(c)
 }
 
+func (c *KedaV1alpha1Client) ScaledGroups(namespace string) ScaledGroupInterface {
+	return newScaledGroups(c, namespace)
+}
+
 func (c *KedaV1alpha1Client) ScaledJobs(namespace string) ScaledJobInterface {
 	return newScaledJobs(c, namespace)
 }
@@ -51,10 +58,18 @@ func (c *KedaV1alpha1Client) ScaledObjects(namespace string) ScaledObjectInterfa
 	return newScaledObjects(c, namespace)
 }
 
+func (c *KedaV1alpha1Client) ScaledObjectSets(namespace string) ScaledObjectSetInterface {
+	return newScaledObjectSets(c, namespace)
+}
+
 func (c *KedaV1alpha1Client) TriggerAuthentications(namespace string) TriggerAuthenticationInterface {
 	return newTriggerAuthentications(c, namespace)
 }
 
+func (c *KedaV1alpha1Client) TriggerAuthenticationGrants(namespace string) TriggerAuthenticationGrantInterface {
+	return newTriggerAuthenticationGrants(c, namespace)
+}
+
 // NewForConfig creates a new KedaV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -55,12 +55,18 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=keda, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithResource("clustertriggerauthentications"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().ClusterTriggerAuthentications().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("scaledgroups"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().ScaledGroups().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("scaledjobs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().ScaledJobs().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("scaledobjects"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().ScaledObjects().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("scaledobjectsets"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().ScaledObjectSets().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("triggerauthentications"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().TriggerAuthentications().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("triggerauthenticationgrants"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().TriggerAuthenticationGrants().Informer()}, nil
 
 	}
 
@@ -26,12 +26,18 @@ import (
 type Interface interface {
 	// ClusterTriggerAuthentications returns a ClusterTriggerAuthenticationInformer.
 	ClusterTriggerAuthentications() ClusterTriggerAuthenticationInformer
+	// ScaledGroups returns a ScaledGroupInformer.
+	ScaledGroups() ScaledGroupInformer
 	// ScaledJobs returns a ScaledJobInformer.
 	ScaledJobs() ScaledJobInformer
 	// ScaledObjects returns a ScaledObjectInformer.
 	ScaledObjects() ScaledObjectInformer
+	// ScaledObjectSets returns a ScaledObjectSetInformer.
+	ScaledObjectSets() ScaledObjectSetInformer
 	// TriggerAuthentications returns a TriggerAuthenticationInformer.
 	TriggerAuthentications() TriggerAuthenticationInformer
+	// TriggerAuthenticationGrants returns a TriggerAuthenticationGrantInformer.
+	TriggerAuthenticationGrants() TriggerAuthenticationGrantInformer
 }
 
 type version struct {
@@ -50,6 +56,11 @@ func (v *version) ClusterTriggerAuthentications() ClusterTriggerAuthenticationIn
 	return &clusterTriggerAuthenticationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ScaledGroups returns a ScaledGroupInformer.
+func (v *version) ScaledGroups() ScaledGroupInformer {
+	return &scaledGroupInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ScaledJobs returns a ScaledJobInformer.
 func (v *version) ScaledJobs() ScaledJobInformer {
 	return &scaledJobInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -60,7 +71,17 @@ func (v *version) ScaledObjects() ScaledObjectInformer {
 	return &scaledObjectInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ScaledObjectSets returns a ScaledObjectSetInformer.
+func (v *version) ScaledObjectSets() ScaledObjectSetInformer {
+	return &scaledObjectSetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // TriggerAuthentications returns a TriggerAuthenticationInformer.
 func (v *version) TriggerAuthentications() TriggerAuthenticationInformer {
 	return &triggerAuthenticationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// TriggerAuthenticationGrants returns a TriggerAuthenticationGrantInformer.
+func (v *version) TriggerAuthenticationGrants() TriggerAuthenticationGrantInformer {
+	return &triggerAuthenticationGrantInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -22,6 +22,14 @@ package v1alpha1
 // ClusterTriggerAuthenticationLister.
 type ClusterTriggerAuthenticationListerExpansion interface{}
 
+// ScaledGroupListerExpansion allows custom methods to be added to
+// ScaledGroupLister.
+type ScaledGroupListerExpansion interface{}
+
+// ScaledGroupNamespaceListerExpansion allows custom methods to be added to
+// ScaledGroupNamespaceLister.
+type ScaledGroupNamespaceListerExpansion interface{}
+
 // ScaledJobListerExpansion allows custom methods to be added to
 // ScaledJobLister.
 type ScaledJobListerExpansion interface{}
@@ -38,6 +46,14 @@ type ScaledObjectListerExpansion interface{}
 // ScaledObjectNamespaceLister.
 type ScaledObjectNamespaceListerExpansion interface{}
 
+// ScaledObjectSetListerExpansion allows custom methods to be added to
+// ScaledObjectSetLister.
+type ScaledObjectSetListerExpansion interface{}
+
+// ScaledObjectSetNamespaceListerExpansion allows custom methods to be added to
+// ScaledObjectSetNamespaceLister.
+type ScaledObjectSetNamespaceListerExpansion interface{}
+
 // TriggerAuthenticationListerExpansion allows custom methods to be added to
 // TriggerAuthenticationLister.
 type TriggerAuthenticationListerExpansion interface{}
@@ -45,3 +61,11 @@ type TriggerAuthenticationListerExpansion interface{}
 // TriggerAuthenticationNamespaceListerExpansion allows custom methods to be added to
 // TriggerAuthenticationNamespaceLister.
 type TriggerAuthenticationNamespaceListerExpansion interface{}
+
+// TriggerAuthenticationGrantListerExpansion allows custom methods to be added to
+// TriggerAuthenticationGrantLister.
+type TriggerAuthenticationGrantListerExpansion interface{}
+
+// TriggerAuthenticationGrantNamespaceListerExpansion allows custom methods to be added to
+// TriggerAuthenticationGrantNamespaceLister.
+type TriggerAuthenticationGrantNamespaceListerExpansion interface{}
@@ -43,6 +43,9 @@ type MetricsCollector interface {
 	// RecordScalerLatency create a measurement of the latency to external metric
 	RecordScalerLatency(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool, value time.Duration)
 
+	// RecordScalerForecastError create a measurement of the error of a forecasting scaler
+	RecordScalerForecastError(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool, value float64)
+
 	// RecordScalableObjectLatency create a measurement of the latency executing scalable object loop
 	RecordScalableObjectLatency(namespace string, name string, isScaledObject bool, value time.Duration)
 
@@ -109,6 +112,13 @@ func RecordScalerLatency(namespace string, scaledObject string, scaler string, t
 	}
 }
 
+// RecordScalerForecastError create a measurement of the error of a forecasting scaler
+func RecordScalerForecastError(namespace string, scaledObject string, scaler string, triggerIndex int, metric string, isScaledObject bool, value float64) {
+	for _, element := range collectors {
+		element.RecordScalerForecastError(namespace, scaledObject, scaler, triggerIndex, metric, isScaledObject, value)
+	}
+}
+
 // RecordScalableObjectLatency create a measurement of the latency executing scalable object loop
 func RecordScalableObjectLatency(namespace string, name string, isScaledObject bool, value time.Duration) {
 	for _, element := range collectors {
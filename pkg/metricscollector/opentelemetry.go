@@ -45,8 +45,9 @@ var (
 	otCloudEventEmittedCounter  api.Int64Counter
 	otCloudEventQueueStatusVals []OtelMetricFloat64Val
 
-	otelScalerActiveVals []OtelMetricFloat64Val
-	otelScalerPauseVals  []OtelMetricFloat64Val
+	otelScalerActiveVals        []OtelMetricFloat64Val
+	otelScalerPauseVals         []OtelMetricFloat64Val
+	otelScalerForecastErrorVals []OtelMetricFloat64Val
 )
 
 type OtelMetrics struct {
@@ -180,6 +181,15 @@ func initMeters() {
 		otLog.Error(err, msg)
 	}
 
+	_, err = meter.Float64ObservableGauge(
+		"keda.scaler.forecast.error",
+		api.WithDescription("The absolute difference between the value forecasted by a scaler for the current time and the observed value"),
+		api.WithFloat64Callback(ScalerForecastErrorCallback),
+	)
+	if err != nil {
+		otLog.Error(err, msg)
+	}
+
 	_, err = meter.Float64ObservableGauge(
 		"keda.scaler.active",
 		api.WithDescription("Indicates whether a scaler is active (1), or not (0)"),
@@ -326,6 +336,22 @@ func (o *OtelMetrics) RecordScalableObjectLatency(namespace string, name string,
 	otelInternalLoopLatencyValDeprecated = append(otelInternalLoopLatencyValDeprecated, otelInternalLoopLatencyD)
 }
 
+func ScalerForecastErrorCallback(_ context.Context, obsrv api.Float64Observer) error {
+	for _, v := range otelScalerForecastErrorVals {
+		obsrv.Observe(v.val, v.measurementOption)
+	}
+	otelScalerForecastErrorVals = []OtelMetricFloat64Val{}
+	return nil
+}
+
+// RecordScalerForecastError create a measurement of the error of a forecasting scaler
+func (o *OtelMetrics) RecordScalerForecastError(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool, value float64) {
+	otelScalerForecastError := OtelMetricFloat64Val{}
+	otelScalerForecastError.val = value
+	otelScalerForecastError.measurementOption = getScalerMeasurementOption(namespace, scaledResource, scaler, triggerIndex, metric, isScaledObject)
+	otelScalerForecastErrorVals = append(otelScalerForecastErrorVals, otelScalerForecastError)
+}
+
 func ScalerActiveCallback(_ context.Context, obsrv api.Float64Observer) error {
 	for _, v := range otelScalerActiveVals {
 		obsrv.Observe(v.val, v.measurementOption)
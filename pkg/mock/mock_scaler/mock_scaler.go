@@ -163,3 +163,85 @@ func (mr *MockPushScalerMockRecorder) Run(ctx, active any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockPushScaler)(nil).Run), ctx, active)
 }
+
+// MockPodMetricsScaler is a mock of PodMetricsScaler interface.
+type MockPodMetricsScaler struct {
+	ctrl     *gomock.Controller
+	recorder *MockPodMetricsScalerMockRecorder
+}
+
+// MockPodMetricsScalerMockRecorder is the mock recorder for MockPodMetricsScaler.
+type MockPodMetricsScalerMockRecorder struct {
+	mock *MockPodMetricsScaler
+}
+
+// NewMockPodMetricsScaler creates a new mock instance.
+func NewMockPodMetricsScaler(ctrl *gomock.Controller) *MockPodMetricsScaler {
+	mock := &MockPodMetricsScaler{ctrl: ctrl}
+	mock.recorder = &MockPodMetricsScalerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPodMetricsScaler) EXPECT() *MockPodMetricsScalerMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockPodMetricsScaler) Close(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockPodMetricsScalerMockRecorder) Close(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPodMetricsScaler)(nil).Close), ctx)
+}
+
+// GetMetricSpecForScaling mocks base method.
+func (m *MockPodMetricsScaler) GetMetricSpecForScaling(ctx context.Context) []v2.MetricSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricSpecForScaling", ctx)
+	ret0, _ := ret[0].([]v2.MetricSpec)
+	return ret0
+}
+
+// GetMetricSpecForScaling indicates an expected call of GetMetricSpecForScaling.
+func (mr *MockPodMetricsScalerMockRecorder) GetMetricSpecForScaling(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricSpecForScaling", reflect.TypeOf((*MockPodMetricsScaler)(nil).GetMetricSpecForScaling), ctx)
+}
+
+// GetMetricsAndActivity mocks base method.
+func (m *MockPodMetricsScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricsAndActivity", ctx, metricName)
+	ret0, _ := ret[0].([]external_metrics.ExternalMetricValue)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMetricsAndActivity indicates an expected call of GetMetricsAndActivity.
+func (mr *MockPodMetricsScalerMockRecorder) GetMetricsAndActivity(ctx, metricName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricsAndActivity", reflect.TypeOf((*MockPodMetricsScaler)(nil).GetMetricsAndActivity), ctx, metricName)
+}
+
+// GetPodMetrics mocks base method.
+func (m *MockPodMetricsScaler) GetPodMetrics(ctx context.Context) (map[string]float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPodMetrics", ctx)
+	ret0, _ := ret[0].(map[string]float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPodMetrics indicates an expected call of GetPodMetrics.
+func (mr *MockPodMetricsScalerMockRecorder) GetPodMetrics(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodMetrics", reflect.TypeOf((*MockPodMetricsScaler)(nil).GetPodMetrics), ctx)
+}
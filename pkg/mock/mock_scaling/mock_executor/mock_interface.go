@@ -14,8 +14,9 @@ import (
 	reflect "reflect"
 
 	v1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
-	"github.com/kedacore/keda/v2/pkg/scaling/executor"
+	executor "github.com/kedacore/keda/v2/pkg/scaling/executor"
 	gomock "go.uber.org/mock/gomock"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // MockScaleExecutor is a mock of ScaleExecutor interface.
@@ -41,6 +42,22 @@ func (m *MockScaleExecutor) EXPECT() *MockScaleExecutorMockRecorder {
 	return m.recorder
 }
 
+// PopLastScale mocks base method.
+func (m *MockScaleExecutor) PopLastScale(scaledObject *v1alpha1.ScaledObject) (v1.Time, int32, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PopLastScale", scaledObject)
+	ret0, _ := ret[0].(v1.Time)
+	ret1, _ := ret[1].(int32)
+	ret2, _ := ret[2].(bool)
+	return ret0, ret1, ret2
+}
+
+// PopLastScale indicates an expected call of PopLastScale.
+func (mr *MockScaleExecutorMockRecorder) PopLastScale(scaledObject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PopLastScale", reflect.TypeOf((*MockScaleExecutor)(nil).PopLastScale), scaledObject)
+}
+
 // RequestJobScale mocks base method.
 func (m *MockScaleExecutor) RequestJobScale(ctx context.Context, scaledJob *v1alpha1.ScaledJob, isActive bool, scaleTo, maxScale int64) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,374 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// ******************************* DESCRIPTION ****************************** \\
+// otlpreceiver package receives OpenTelemetry metrics pushed over OTLP/gRPC and
+// OTLP/HTTP, and keeps the gauges and sums in an in-memory store for a short
+// retention. The store is queried by the otel scaler. The series are scoped by
+// the namespace of their resource and the exports are authenticated with the
+// bearer tokens of the otel triggers of that namespace.
+// ************************************************************************** \\
+
+package otlpreceiver
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	collectormetricsv1 "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	metricsv1 "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// HTTPPath is the path of the OTLP/HTTP metrics endpoint
+const HTTPPath = "/v1/metrics"
+
+// NamespaceAttribute is the resource attribute with the namespace of the exported series, it's required
+const NamespaceAttribute = "k8s.namespace.name"
+
+// errUnauthenticated is returned for an export whose bearer token isn't allowed for any namespace
+var errUnauthenticated = errors.New("the bearer token isn't the one of an otel trigger")
+
+const (
+	contentTypeProtobuf = "application/x-protobuf"
+	contentTypeJSON     = "application/json"
+	maxHTTPBodySize     = 16 * 1024 * 1024
+	expirationInterval  = 30 * time.Second
+)
+
+var log = logf.Log.WithName("otlp_receiver")
+
+var (
+	defaultStoreMutex sync.RWMutex
+	defaultStore      *Store
+)
+
+// SetDefaultStore sets the store the otel scalers query, it's set when the receiver is enabled
+func SetDefaultStore(store *Store) {
+	defaultStoreMutex.Lock()
+	defer defaultStoreMutex.Unlock()
+	defaultStore = store
+}
+
+// GetDefaultStore returns the store the otel scalers query, nil when the receiver isn't enabled
+func GetDefaultStore() *Store {
+	defaultStoreMutex.RLock()
+	defer defaultStoreMutex.RUnlock()
+	return defaultStore
+}
+
+// Receiver receives OTLP metrics over gRPC and HTTP and appends their gauges and sums to the store
+type Receiver struct {
+	collectormetricsv1.UnimplementedMetricsServiceServer
+	store       *Store
+	grpcAddress string
+	httpAddress string
+}
+
+// NewReceiver creates a Receiver serving OTLP/gRPC and OTLP/HTTP on the addresses, an empty address disables the protocol
+func NewReceiver(store *Store, grpcAddress, httpAddress string) *Receiver {
+	return &Receiver{
+		store:       store,
+		grpcAddress: grpcAddress,
+		httpAddress: httpAddress,
+	}
+}
+
+// Export stores the gauges and sums of the request authenticated with the bearer token of the gRPC metadata
+func (r *Receiver) Export(ctx context.Context, req *collectormetricsv1.ExportMetricsServiceRequest) (*collectormetricsv1.ExportMetricsServiceResponse, error) {
+	token := ""
+	if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) > 0 {
+		token = bearerToken(values[0])
+	}
+	response, err := r.export(token, req)
+	if errors.Is(err, errUnauthenticated) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return response, err
+}
+
+// export stores the gauges and sums of the request, the other metric types and the samples which can't
+// be stored are reported as rejected, like the resources of a namespace the token isn't allowed for
+func (r *Receiver) export(token string, req *collectormetricsv1.ExportMetricsServiceRequest) (*collectormetricsv1.ExportMetricsServiceResponse, error) {
+	if !r.store.IsTokenAllowed(token, "") {
+		return nil, errUnauthenticated
+	}
+
+	now := time.Now()
+	rejected := int64(0)
+	var unsupported, unauthorized []string
+	for _, resourceMetrics := range req.GetResourceMetrics() {
+		resourceLabels := attributesToLabels(nil, resourceMetrics.GetResource().GetAttributes())
+		namespace := resourceLabels[NamespaceAttribute]
+		if !r.store.IsTokenAllowed(token, namespace) {
+			for _, scopeMetrics := range resourceMetrics.GetScopeMetrics() {
+				for _, metric := range scopeMetrics.GetMetrics() {
+					rejected += countDataPoints(metric)
+				}
+			}
+			unauthorized = append(unauthorized, namespace)
+			continue
+		}
+		for _, scopeMetrics := range resourceMetrics.GetScopeMetrics() {
+			for _, metric := range scopeMetrics.GetMetrics() {
+				var dataPoints []*metricsv1.NumberDataPoint
+				delta := false
+				switch data := metric.GetData().(type) {
+				case *metricsv1.Metric_Gauge:
+					dataPoints = data.Gauge.GetDataPoints()
+				case *metricsv1.Metric_Sum:
+					dataPoints = data.Sum.GetDataPoints()
+					delta = data.Sum.GetAggregationTemporality() == metricsv1.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA
+				default:
+					rejected += countDataPoints(metric)
+					unsupported = append(unsupported, metric.GetName())
+					continue
+				}
+				for _, dataPoint := range dataPoints {
+					ref := SeriesRef{
+						Namespace:  namespace,
+						MetricName: metric.GetName(),
+						Labels:     attributesToLabels(resourceLabels, dataPoint.GetAttributes()),
+						Delta:      delta,
+					}
+					if !r.store.Append(ref, dataPointTime(dataPoint, now), dataPointValue(dataPoint), now) {
+						rejected++
+					}
+				}
+			}
+		}
+	}
+
+	response := &collectormetricsv1.ExportMetricsServiceResponse{}
+	if rejected > 0 {
+		message := "the store of the receiver is full or the data points are older than its retention"
+		switch {
+		case len(unauthorized) > 0:
+			message = fmt.Sprintf("the resources need a %s attribute of a namespace the bearer token is allowed for, rejected namespaces %q", NamespaceAttribute, unauthorized)
+		case len(unsupported) > 0:
+			message = fmt.Sprintf("only gauges and sums are supported, rejected metrics %v", unsupported)
+		}
+		response.PartialSuccess = &collectormetricsv1.ExportMetricsPartialSuccess{RejectedDataPoints: rejected, ErrorMessage: message}
+	}
+	return response, nil
+}
+
+// ServeHTTP handles an OTLP/HTTP export request encoded in protobuf or JSON
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// the token is checked again for the namespaces of the request, it's checked first to not read the body
+	token := bearerToken(req.Header.Get("Authorization"))
+	if !r.store.IsTokenAllowed(token, "") {
+		http.Error(w, errUnauthenticated.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	contentType := req.Header.Get("Content-Type")
+	var unmarshal func([]byte, proto.Message) error
+	var marshal func(proto.Message) ([]byte, error)
+	switch contentType {
+	case contentTypeProtobuf:
+		unmarshal, marshal = proto.Unmarshal, proto.Marshal
+	case contentTypeJSON:
+		unmarshal, marshal = protojson.Unmarshal, protojson.Marshal
+	default:
+		http.Error(w, fmt.Sprintf("unsupported content type %q", contentType), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	var body io.Reader = http.MaxBytesReader(w, req.Body, maxHTTPBodySize)
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(body)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid gzip body: %s", err), http.StatusBadRequest)
+			return
+		}
+		defer gzipReader.Close()
+		body = io.LimitReader(gzipReader, maxHTTPBodySize)
+	}
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error reading the body: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	exportRequest := &collectormetricsv1.ExportMetricsServiceRequest{}
+	if err := unmarshal(raw, exportRequest); err != nil {
+		http.Error(w, fmt.Sprintf("invalid export request: %s", err), http.StatusBadRequest)
+		return
+	}
+	exportResponse, err := r.export(token, exportRequest)
+	if errors.Is(err, errUnauthenticated) {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	response, err := marshal(exportResponse)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write(response)
+}
+
+// Start runs the OTLP servers and expires the old samples of the store until the context is done,
+// this implements the Runnable interface of controller-runtime Manager
+func (r *Receiver) Start(ctx context.Context) error {
+	errChan := make(chan error, 2)
+
+	if r.grpcAddress != "" {
+		grpcServer := grpc.NewServer()
+		collectormetricsv1.RegisterMetricsServiceServer(grpcServer, r)
+		lis, err := net.Listen("tcp", r.grpcAddress)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", r.grpcAddress, err)
+		}
+		go func() {
+			log.Info("Starting OTLP/gRPC receiver", "address", r.grpcAddress)
+			if err := grpcServer.Serve(lis); err != nil {
+				errChan <- fmt.Errorf("unable to serve OTLP/gRPC on address %s: %w", r.grpcAddress, err)
+			}
+		}()
+		defer grpcServer.GracefulStop()
+	}
+
+	if r.httpAddress != "" {
+		mux := http.NewServeMux()
+		mux.Handle(HTTPPath, r)
+		httpServer := &http.Server{
+			Addr:              r.httpAddress,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			log.Info("Starting OTLP/HTTP receiver", "address", r.httpAddress)
+			if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errChan <- fmt.Errorf("unable to serve OTLP/HTTP on address %s: %w", r.httpAddress, err)
+			}
+		}()
+		defer func() { _ = httpServer.Shutdown(context.Background()) }()
+	}
+
+	ticker := time.NewTicker(expirationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-errChan:
+			return err
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			r.store.Expire(now)
+		}
+	}
+}
+
+// NeedLeaderElection is needed to implement LeaderElectionRunnable interface
+// of controller-runtime. The receiver runs on the leader, whose scalers query the store.
+func (r *Receiver) NeedLeaderElection() bool {
+	return true
+}
+
+// attributesToLabels adds the attributes to a copy of the labels, the attributes override the labels
+func attributesToLabels(base map[string]string, attributes []*commonv1.KeyValue) map[string]string {
+	result := make(map[string]string, len(base)+len(attributes))
+	for name, value := range base {
+		result[name] = value
+	}
+	for _, attribute := range attributes {
+		result[attribute.GetKey()] = anyValueString(attribute.GetValue())
+	}
+	return result
+}
+
+// bearerToken returns the token of a bearer authorization header
+func bearerToken(authorization string) string {
+	if token, found := strings.CutPrefix(authorization, "Bearer "); found {
+		return token
+	}
+	return ""
+}
+
+func anyValueString(value *commonv1.AnyValue) string {
+	switch v := value.GetValue().(type) {
+	case *commonv1.AnyValue_StringValue:
+		return v.StringValue
+	case *commonv1.AnyValue_BoolValue:
+		return strconv.FormatBool(v.BoolValue)
+	case *commonv1.AnyValue_IntValue:
+		return strconv.FormatInt(v.IntValue, 10)
+	case *commonv1.AnyValue_DoubleValue:
+		return strconv.FormatFloat(v.DoubleValue, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+func dataPointTime(dataPoint *metricsv1.NumberDataPoint, now time.Time) time.Time {
+	if dataPoint.GetTimeUnixNano() == 0 {
+		return now
+	}
+	return time.Unix(0, int64(dataPoint.GetTimeUnixNano()))
+}
+
+func dataPointValue(dataPoint *metricsv1.NumberDataPoint) float64 {
+	if v, ok := dataPoint.GetValue().(*metricsv1.NumberDataPoint_AsInt); ok {
+		return float64(v.AsInt)
+	}
+	return dataPoint.GetAsDouble()
+}
+
+func countDataPoints(metric *metricsv1.Metric) int64 {
+	switch data := metric.GetData().(type) {
+	case *metricsv1.Metric_Gauge:
+		return int64(len(data.Gauge.GetDataPoints()))
+	case *metricsv1.Metric_Sum:
+		return int64(len(data.Sum.GetDataPoints()))
+	case *metricsv1.Metric_Histogram:
+		return int64(len(data.Histogram.GetDataPoints()))
+	case *metricsv1.Metric_ExponentialHistogram:
+		return int64(len(data.ExponentialHistogram.GetDataPoints()))
+	case *metricsv1.Metric_Summary:
+		return int64(len(data.Summary.GetDataPoints()))
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otlpreceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collectormetricsv1 "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	metricsv1 "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcev1 "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func stringAttribute(key, value string) *commonv1.KeyValue {
+	return &commonv1.KeyValue{Key: key, Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: value}}}
+}
+
+func newTestExportRequest(now time.Time, namespace string) *collectormetricsv1.ExportMetricsServiceRequest {
+	timestamp := uint64(now.UnixNano())
+	return &collectormetricsv1.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricsv1.ResourceMetrics{{
+			Resource: &resourcev1.Resource{Attributes: []*commonv1.KeyValue{stringAttribute("service.name", "worker"), stringAttribute(NamespaceAttribute, namespace)}},
+			ScopeMetrics: []*metricsv1.ScopeMetrics{{
+				Metrics: []*metricsv1.Metric{
+					{
+						Name: "queue.depth",
+						Data: &metricsv1.Metric_Gauge{Gauge: &metricsv1.Gauge{DataPoints: []*metricsv1.NumberDataPoint{
+							{Attributes: []*commonv1.KeyValue{stringAttribute("queue", "orders")}, TimeUnixNano: timestamp, Value: &metricsv1.NumberDataPoint_AsInt{AsInt: 12}},
+							{Attributes: []*commonv1.KeyValue{stringAttribute("queue", "payments")}, TimeUnixNano: timestamp, Value: &metricsv1.NumberDataPoint_AsDouble{AsDouble: 3.5}},
+						}}},
+					},
+					{
+						Name: "jobs.processed",
+						Data: &metricsv1.Metric_Sum{Sum: &metricsv1.Sum{
+							AggregationTemporality: metricsv1.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+							DataPoints:             []*metricsv1.NumberDataPoint{{TimeUnixNano: timestamp, Value: &metricsv1.NumberDataPoint_AsInt{AsInt: 40}}},
+						}},
+					},
+					{
+						Name: "request.duration",
+						Data: &metricsv1.Metric_Histogram{Histogram: &metricsv1.Histogram{DataPoints: []*metricsv1.HistogramDataPoint{{TimeUnixNano: timestamp}}}},
+					},
+				},
+			}},
+		}},
+	}
+}
+
+func TestReceiverExport(t *testing.T) {
+	now := time.Now()
+	store := NewStore(10*time.Minute, 100)
+	store.AllowToken("default", "token")
+	receiver := NewReceiver(store, "", "")
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token"))
+
+	response, err := receiver.Export(ctx, newTestExportRequest(now, "default"))
+	require.NoError(t, err)
+	require.NotNil(t, response.PartialSuccess, "the histogram must be rejected")
+	assert.Equal(t, int64(1), response.PartialSuccess.RejectedDataPoints)
+
+	selector, err := labels.Parse("service.name=worker,queue=orders")
+	require.NoError(t, err)
+	value, series, err := store.Query(Query{Namespace: "default", MetricName: "queue.depth", Selector: selector, Window: time.Minute}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, series)
+	assert.Equal(t, float64(12), value)
+
+	value, _, err = store.Query(Query{Namespace: "default", MetricName: "queue.depth", Window: time.Minute}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 15.5, value)
+
+	value, _, err = store.Query(Query{Namespace: "default", MetricName: "jobs.processed", Window: time.Minute}, now)
+	require.NoError(t, err)
+	assert.Equal(t, float64(40), value)
+
+	// the series of a namespace the token isn't allowed for are rejected
+	response, err = receiver.Export(ctx, newTestExportRequest(now, "other"))
+	require.NoError(t, err)
+	require.NotNil(t, response.PartialSuccess)
+	assert.Equal(t, int64(4), response.PartialSuccess.RejectedDataPoints)
+	assert.Contains(t, response.PartialSuccess.ErrorMessage, NamespaceAttribute)
+	_, series, err = store.Query(Query{Namespace: "other", MetricName: "queue.depth", Window: time.Minute}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, series)
+
+	// an export without a known token is refused
+	_, err = receiver.Export(context.Background(), newTestExportRequest(now, "default"))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = receiver.Export(metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer wrong")), newTestExportRequest(now, "default"))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestReceiverHTTP(t *testing.T) {
+	now := time.Now()
+	protobufBody, err := proto.Marshal(newTestExportRequest(now, "default"))
+	require.NoError(t, err)
+	jsonBody, err := protojson.Marshal(newTestExportRequest(now, "default"))
+	require.NoError(t, err)
+	var gzipBody bytes.Buffer
+	gzipWriter := gzip.NewWriter(&gzipBody)
+	_, err = gzipWriter.Write(protobufBody)
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+
+	tests := []struct {
+		name            string
+		method          string
+		contentType     string
+		contentEncoding string
+		token           string
+		body            []byte
+		status          int
+	}{
+		{"protobuf", http.MethodPost, contentTypeProtobuf, "", "token", protobufBody, http.StatusOK},
+		{"json", http.MethodPost, contentTypeJSON, "", "token", jsonBody, http.StatusOK},
+		{"gzip protobuf", http.MethodPost, contentTypeProtobuf, "gzip", "token", gzipBody.Bytes(), http.StatusOK},
+		{"invalid body", http.MethodPost, contentTypeProtobuf, "", "token", []byte("invalid"), http.StatusBadRequest},
+		{"unsupported content type", http.MethodPost, "text/plain", "", "token", protobufBody, http.StatusUnsupportedMediaType},
+		{"unsupported method", http.MethodGet, contentTypeProtobuf, "", "token", nil, http.StatusMethodNotAllowed},
+		{"unknown token", http.MethodPost, contentTypeProtobuf, "", "wrong", protobufBody, http.StatusUnauthorized},
+		{"no token", http.MethodPost, contentTypeProtobuf, "", "", protobufBody, http.StatusUnauthorized},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := NewStore(10*time.Minute, 100)
+			store.AllowToken("default", "token")
+			receiver := NewReceiver(store, "", "")
+
+			r := httptest.NewRequest(test.method, HTTPPath, bytes.NewReader(test.body))
+			r.Header.Set("Content-Type", test.contentType)
+			if test.token != "" {
+				r.Header.Set("Authorization", "Bearer "+test.token)
+			}
+			if test.contentEncoding != "" {
+				r.Header.Set("Content-Encoding", test.contentEncoding)
+			}
+			w := httptest.NewRecorder()
+			receiver.ServeHTTP(w, r)
+			require.Equal(t, test.status, w.Code, w.Body.String())
+			if test.status != http.StatusOK {
+				return
+			}
+
+			assert.Equal(t, test.contentType, w.Header().Get("Content-Type"))
+			value, _, err := store.Query(Query{Namespace: "default", MetricName: "queue.depth", Window: time.Minute}, now)
+			require.NoError(t, err)
+			assert.Equal(t, 15.5, value)
+		})
+	}
+}
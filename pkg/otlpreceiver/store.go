@@ -0,0 +1,308 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otlpreceiver
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Aggregations of the samples of a series over the query window
+const (
+	AggregationLast = "last"
+	AggregationAvg  = "avg"
+	AggregationMin  = "min"
+	AggregationMax  = "max"
+	AggregationSum  = "sum"
+	AggregationRate = "rate"
+)
+
+// Aggregations of the series matching a query
+const (
+	SeriesAggregationSum = "sum"
+	SeriesAggregationAvg = "avg"
+	SeriesAggregationMin = "min"
+	SeriesAggregationMax = "max"
+)
+
+// SeriesRef identifies a series of a namespace
+type SeriesRef struct {
+	Namespace  string
+	MetricName string
+	Labels     map[string]string
+	// Delta is set for the sums with a delta temporality, whose samples are increments
+	Delta bool
+}
+
+// Query selects the series of a metric of a namespace and aggregates their samples over a window
+type Query struct {
+	Namespace         string
+	MetricName        string
+	Selector          labels.Selector
+	Window            time.Duration
+	Aggregation       string
+	SeriesAggregation string
+}
+
+type sample struct {
+	timestamp time.Time
+	value     float64
+}
+
+type series struct {
+	namespace  string
+	metricName string
+	labels     labels.Set
+	delta      bool
+	samples    []sample
+}
+
+// Store keeps the samples received over OTLP for the retention, in memory, along with the bearer tokens
+// allowed to export the series of each namespace
+type Store struct {
+	mutex     sync.RWMutex
+	series    map[string]*series
+	retention time.Duration
+	maxSeries int
+
+	tokensMutex sync.RWMutex
+	// tokens holds the references to the namespaces allowed for the sha256 of a token
+	tokens map[[sha256.Size]byte]map[string]int
+}
+
+// NewStore creates a Store keeping the samples for the retention, the samples of new series are
+// dropped once the store holds maxSeries series
+func NewStore(retention time.Duration, maxSeries int) *Store {
+	return &Store{
+		series:    map[string]*series{},
+		retention: retention,
+		maxSeries: maxSeries,
+		tokens:    map[[sha256.Size]byte]map[string]int{},
+	}
+}
+
+// AllowToken allows the exports authenticated with the bearer token to append the series of the namespace,
+// until the returned function is called
+func (s *Store) AllowToken(namespace, token string) func() {
+	key := sha256.Sum256([]byte(token))
+
+	s.tokensMutex.Lock()
+	defer s.tokensMutex.Unlock()
+	if s.tokens[key] == nil {
+		s.tokens[key] = map[string]int{}
+	}
+	s.tokens[key][namespace]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.tokensMutex.Lock()
+			defer s.tokensMutex.Unlock()
+			s.tokens[key][namespace]--
+			if s.tokens[key][namespace] == 0 {
+				delete(s.tokens[key], namespace)
+			}
+			if len(s.tokens[key]) == 0 {
+				delete(s.tokens, key)
+			}
+		})
+	}
+}
+
+// IsTokenAllowed returns whether the bearer token is allowed for the namespace, or for any namespace
+// when the namespace is empty
+func (s *Store) IsTokenAllowed(token, namespace string) bool {
+	if token == "" {
+		return false
+	}
+	key := sha256.Sum256([]byte(token))
+
+	s.tokensMutex.RLock()
+	defer s.tokensMutex.RUnlock()
+	namespaces, ok := s.tokens[key]
+	if !ok || namespace == "" {
+		return ok
+	}
+	_, ok = namespaces[namespace]
+	return ok
+}
+
+// Append stores a sample, it returns false when the sample is dropped because the store is full
+// or the sample is older than the retention
+func (s *Store) Append(ref SeriesRef, timestamp time.Time, value float64, now time.Time) bool {
+	if timestamp.Before(now.Add(-s.retention)) {
+		return false
+	}
+	key := seriesKey(ref.Namespace, ref.MetricName, ref.Labels)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	ser, ok := s.series[key]
+	if !ok {
+		if len(s.series) >= s.maxSeries {
+			return false
+		}
+		ser = &series{namespace: ref.Namespace, metricName: ref.MetricName, labels: labels.Set(ref.Labels)}
+		s.series[key] = ser
+	}
+	ser.delta = ref.Delta
+
+	// the samples are kept ordered by timestamp, they usually arrive in order
+	i := len(ser.samples)
+	for i > 0 && ser.samples[i-1].timestamp.After(timestamp) {
+		i--
+	}
+	if i > 0 && ser.samples[i-1].timestamp.Equal(timestamp) {
+		ser.samples[i-1].value = value
+		return true
+	}
+	ser.samples = append(ser.samples, sample{})
+	copy(ser.samples[i+1:], ser.samples[i:])
+	ser.samples[i] = sample{timestamp: timestamp, value: value}
+	return true
+}
+
+// Expire removes the samples older than the retention and the series without samples
+func (s *Store) Expire(now time.Time) {
+	oldest := now.Add(-s.retention)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for key, ser := range s.series {
+		i := sort.Search(len(ser.samples), func(i int) bool { return !ser.samples[i].timestamp.Before(oldest) })
+		ser.samples = ser.samples[i:]
+		if len(ser.samples) == 0 {
+			delete(s.series, key)
+		}
+	}
+}
+
+// Query returns the aggregation of the series matching the query, and the number of matching series.
+// The value is 0 when no series has samples in the window.
+func (s *Store) Query(query Query, now time.Time) (float64, int, error) {
+	start := now.Add(-query.Window)
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	var values []float64
+	for _, ser := range s.series {
+		if ser.namespace != query.Namespace || ser.metricName != query.MetricName || (query.Selector != nil && !query.Selector.Matches(ser.labels)) {
+			continue
+		}
+		var windowSamples []sample
+		for _, smpl := range ser.samples {
+			if !smpl.timestamp.Before(start) && !smpl.timestamp.After(now) {
+				windowSamples = append(windowSamples, smpl)
+			}
+		}
+		if len(windowSamples) == 0 {
+			continue
+		}
+		value, err := aggregateSamples(query.Aggregation, windowSamples, ser.delta)
+		if err != nil {
+			return 0, 0, err
+		}
+		values = append(values, value)
+	}
+	if len(values) == 0 {
+		return 0, 0, nil
+	}
+	value, err := aggregateSeries(query.SeriesAggregation, values)
+	return value, len(values), err
+}
+
+// aggregateSamples aggregates the samples of a series, the samples of a delta series are summed for the rate
+// instead of being differenced
+func aggregateSamples(aggregation string, samples []sample, delta bool) (float64, error) {
+	switch aggregation {
+	case AggregationLast, "":
+		return samples[len(samples)-1].value, nil
+	case AggregationRate:
+		// the increase between the first and the last sample, the increment of the first delta sample
+		// is before the first sample. A reset of a cumulative counter restarts the increase from 0.
+		increase := float64(0)
+		for i := 1; i < len(samples); i++ {
+			switch diff := samples[i].value - samples[i-1].value; {
+			case delta:
+				increase += samples[i].value
+			case diff >= 0:
+				increase += diff
+			default:
+				increase += samples[i].value
+			}
+		}
+		elapsed := samples[len(samples)-1].timestamp.Sub(samples[0].timestamp).Seconds()
+		if elapsed <= 0 {
+			return 0, nil
+		}
+		return increase / elapsed, nil
+	default:
+		values := make([]float64, len(samples))
+		for i, smpl := range samples {
+			values[i] = smpl.value
+		}
+		return aggregateSeries(aggregation, values)
+	}
+}
+
+func aggregateSeries(aggregation string, values []float64) (float64, error) {
+	result := values[0]
+	switch aggregation {
+	case SeriesAggregationSum, SeriesAggregationAvg, "":
+		for _, value := range values[1:] {
+			result += value
+		}
+		if aggregation == SeriesAggregationAvg {
+			result /= float64(len(values))
+		}
+	case SeriesAggregationMin:
+		for _, value := range values[1:] {
+			result = math.Min(result, value)
+		}
+	case SeriesAggregationMax:
+		for _, value := range values[1:] {
+			result = math.Max(result, value)
+		}
+	default:
+		return 0, fmt.Errorf("unsupported aggregation %q", aggregation)
+	}
+	return result, nil
+}
+
+// seriesKey identifies a series by its namespace, metric name and sorted labels
+func seriesKey(namespace, metricName string, seriesLabels map[string]string) string {
+	names := make([]string, 0, len(seriesLabels))
+	for name := range seriesLabels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	b.WriteString(namespace)
+	b.WriteString("\xfd")
+	b.WriteString(metricName)
+	for _, name := range names {
+		fmt.Fprintf(&b, "\xff%s\xfe%s", name, seriesLabels[name])
+	}
+	return b.String()
+}
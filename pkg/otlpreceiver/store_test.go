@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otlpreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestStoreQuery(t *testing.T) {
+	now := time.Now()
+	store := NewStore(10*time.Minute, 100)
+	orders := SeriesRef{Namespace: "default", MetricName: "queue.depth", Labels: map[string]string{"queue": "orders", "service.name": "worker"}}
+	payments := SeriesRef{Namespace: "default", MetricName: "queue.depth", Labels: map[string]string{"queue": "payments", "service.name": "worker"}}
+	otherNamespace := SeriesRef{Namespace: "other", MetricName: "queue.depth", Labels: map[string]string{"queue": "orders", "service.name": "worker"}}
+	for i, value := range []float64{10, 30, 20} {
+		require.True(t, store.Append(orders, now.Add(time.Duration(i-3)*time.Minute), value, now))
+		require.True(t, store.Append(payments, now.Add(time.Duration(i-3)*time.Minute), value*10, now))
+		require.True(t, store.Append(otherNamespace, now.Add(time.Duration(i-3)*time.Minute), value*100, now))
+	}
+
+	worker, err := labels.Parse("service.name=worker")
+	require.NoError(t, err)
+	onlyOrders, err := labels.Parse("queue=orders")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		query  Query
+		value  float64
+		series int
+	}{
+		{"last of every series", Query{Namespace: "default", MetricName: "queue.depth", Selector: worker, Window: 5 * time.Minute, Aggregation: AggregationLast, SeriesAggregation: SeriesAggregationSum}, 220, 2},
+		{"max over the window", Query{Namespace: "default", MetricName: "queue.depth", Selector: onlyOrders, Window: 5 * time.Minute, Aggregation: AggregationMax, SeriesAggregation: SeriesAggregationSum}, 30, 1},
+		{"avg over the window", Query{Namespace: "default", MetricName: "queue.depth", Selector: onlyOrders, Window: 5 * time.Minute, Aggregation: AggregationAvg, SeriesAggregation: SeriesAggregationSum}, 20, 1},
+		{"window shorter than the samples", Query{Namespace: "default", MetricName: "queue.depth", Selector: onlyOrders, Window: 90 * time.Second, Aggregation: AggregationSum, SeriesAggregation: SeriesAggregationSum}, 20, 1},
+		{"max across the series", Query{Namespace: "default", MetricName: "queue.depth", Window: 5 * time.Minute, Aggregation: AggregationLast, SeriesAggregation: SeriesAggregationMax}, 200, 2},
+		{"unknown metric", Query{Namespace: "default", MetricName: "unknown", Window: 5 * time.Minute}, 0, 0},
+		{"unknown namespace", Query{Namespace: "unknown", MetricName: "queue.depth", Window: 5 * time.Minute}, 0, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, series, err := store.Query(test.query, now)
+			require.NoError(t, err)
+			assert.Equal(t, test.value, value)
+			assert.Equal(t, test.series, series)
+		})
+	}
+}
+
+func TestStoreRate(t *testing.T) {
+	now := time.Now()
+	store := NewStore(10*time.Minute, 100)
+	// the counter is reset between the second and the third sample
+	for i, value := range []float64{100, 160, 30} {
+		store.Append(SeriesRef{Namespace: "default", MetricName: "requests"}, now.Add(time.Duration(i-2)*time.Minute), value, now)
+	}
+
+	value, _, err := store.Query(Query{Namespace: "default", MetricName: "requests", Window: 5 * time.Minute, Aggregation: AggregationRate}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 0.75, value)
+
+	// the samples of a delta sum are the increments since the previous sample
+	for i, value := range []float64{100, 60, 30} {
+		store.Append(SeriesRef{Namespace: "default", MetricName: "requests.delta", Delta: true}, now.Add(time.Duration(i-2)*time.Minute), value, now)
+	}
+	value, _, err = store.Query(Query{Namespace: "default", MetricName: "requests.delta", Window: 5 * time.Minute, Aggregation: AggregationRate}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 0.75, value)
+}
+
+func TestStoreTokens(t *testing.T) {
+	store := NewStore(time.Minute, 1)
+	assert.False(t, store.IsTokenAllowed("token", ""))
+
+	revokeDefault := store.AllowToken("default", "token")
+	revokeAgain := store.AllowToken("default", "token")
+	revokeOther := store.AllowToken("other", "token")
+	assert.True(t, store.IsTokenAllowed("token", ""))
+	assert.True(t, store.IsTokenAllowed("token", "default"))
+	assert.False(t, store.IsTokenAllowed("other-token", "default"))
+	assert.False(t, store.IsTokenAllowed("", "default"))
+
+	revokeDefault()
+	revokeDefault()
+	assert.True(t, store.IsTokenAllowed("token", "default"), "the token is still allowed by another trigger")
+	revokeAgain()
+	assert.False(t, store.IsTokenAllowed("token", "default"))
+	revokeOther()
+	assert.False(t, store.IsTokenAllowed("token", ""))
+}
+
+func TestStoreLimits(t *testing.T) {
+	now := time.Now()
+	store := NewStore(time.Minute, 1)
+
+	assert.False(t, store.Append(SeriesRef{Namespace: "default", MetricName: "queue.depth"}, now.Add(-2*time.Minute), 1, now), "a sample older than the retention must be dropped")
+	assert.True(t, store.Append(SeriesRef{Namespace: "default", MetricName: "queue.depth"}, now, 1, now))
+	assert.False(t, store.Append(SeriesRef{Namespace: "default", MetricName: "queue.depth", Labels: map[string]string{"queue": "orders"}}, now, 1, now), "a new series above the limit must be dropped")
+
+	store.Expire(now.Add(2 * time.Minute))
+	assert.Empty(t, store.series)
+	assert.True(t, store.Append(SeriesRef{Namespace: "default", MetricName: "queue.depth", Labels: map[string]string{"queue": "orders"}}, now.Add(2*time.Minute), 1, now.Add(2*time.Minute)))
+}
+
+func TestStoreOutOfOrderSamples(t *testing.T) {
+	now := time.Now()
+	store := NewStore(10*time.Minute, 100)
+	store.Append(SeriesRef{Namespace: "default", MetricName: "queue.depth"}, now, 3, now)
+	store.Append(SeriesRef{Namespace: "default", MetricName: "queue.depth"}, now.Add(-time.Minute), 1, now)
+	store.Append(SeriesRef{Namespace: "default", MetricName: "queue.depth"}, now, 5, now)
+
+	value, _, err := store.Query(Query{Namespace: "default", MetricName: "queue.depth", Window: 5 * time.Minute, Aggregation: AggregationLast}, now)
+	require.NoError(t, err)
+	assert.Equal(t, float64(5), value)
+	value, _, err = store.Query(Query{Namespace: "default", MetricName: "queue.depth", Window: 5 * time.Minute, Aggregation: AggregationSum}, now)
+	require.NoError(t, err)
+	assert.Equal(t, float64(6), value)
+}
@@ -29,6 +29,7 @@ import (
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/metricsservice"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 // KedaProvider implements External Metrics Provider
@@ -38,6 +39,8 @@ type KedaProvider struct {
 	client client.Client
 
 	grpcClient metricsservice.GrpcClient
+
+	watchScope *kedautil.WatchScope
 }
 
 var (
@@ -47,10 +50,11 @@ var (
 )
 
 // NewProvider returns an instance of KedaProvider
-func NewProvider(ctx context.Context, adapterLogger logr.Logger, client client.Client, grpcClient metricsservice.GrpcClient) provider.ExternalMetricsProvider {
+func NewProvider(ctx context.Context, adapterLogger logr.Logger, client client.Client, grpcClient metricsservice.GrpcClient, watchScope *kedautil.WatchScope) provider.ExternalMetricsProvider {
 	provider := &KedaProvider{
 		client:     client,
 		grpcClient: grpcClient,
+		watchScope: watchScope,
 	}
 	logger = adapterLogger.WithName("provider")
 	logger.Info("starting")
@@ -83,6 +87,17 @@ func (p *KedaProvider) GetExternalMetric(ctx context.Context, namespace string,
 		return nil, err
 	}
 
+	inScope, err := p.watchScope.Contains(ctx, namespace)
+	if err != nil {
+		logger.Error(err, "error checking the watch scope of the namespace", "namespace", namespace)
+		return nil, err
+	}
+	if !inScope {
+		err := fmt.Errorf("namespace %s is out of the watch scope", namespace)
+		logger.Error(err, "refusing the request for external metrics", "namespace", namespace)
+		return &external_metrics.ExternalMetricValueList{}, err
+	}
+
 	// Get Metrics from Metrics Service gRPC Server
 	if !p.grpcClient.WaitForConnectionReady(ctx, logger) {
 		grpcClientConnected = false
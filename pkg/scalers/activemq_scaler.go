@@ -34,6 +34,8 @@ type activeMQMetadata struct {
 	ManagementEndpoint string `keda:"name=managementEndpoint, order=triggerMetadata, optional"`
 	DestinationName    string `keda:"name=destinationName,    order=triggerMetadata, optional"`
 	BrokerName         string `keda:"name=brokerName,         order=triggerMetadata, optional"`
+	// ManagementEndpoints are the management endpoints of every broker of a network of brokers, the queue sizes are summed
+	ManagementEndpoints []string `keda:"name=managementEndpoints, order=triggerMetadata, optional"`
 
 	// auth
 	Username string `keda:"name=username, order=authParams;resolvedEnv;triggerMetadata"`
@@ -48,6 +50,10 @@ type activeMQMetadata struct {
 
 func (a *activeMQMetadata) Validate() error {
 	if a.RestAPITemplate != "" {
+		// the template has the host of a single broker, it would be counted once per management endpoint
+		if len(a.ManagementEndpoints) > 0 {
+			return fmt.Errorf("restAPITemplate can't be used with managementEndpoints")
+		}
 		// parse restAPITemplate to provide managementEndpoint, brokerName, destinationName
 		u, err := url.ParseRequestURI(a.RestAPITemplate)
 		if err != nil {
@@ -72,7 +78,7 @@ func (a *activeMQMetadata) Validate() error {
 		a.BrokerName = v["brokerName"][0]
 	} else {
 		a.RestAPITemplate = defaultActiveMQRestAPITemplate
-		if a.ManagementEndpoint == "" {
+		if a.ManagementEndpoint == "" && len(a.ManagementEndpoints) == 0 {
 			return fmt.Errorf("no management endpoint given")
 		}
 		if a.DestinationName == "" {
@@ -82,6 +88,9 @@ func (a *activeMQMetadata) Validate() error {
 			return fmt.Errorf("no broker name given")
 		}
 	}
+	if a.ManagementEndpoint == "" {
+		a.ManagementEndpoint = a.ManagementEndpoints[0]
+	}
 	if a.CorsHeader == "" {
 		a.CorsHeader = fmt.Sprintf(defaultCorsHeader, a.ManagementEndpoint)
 	}
@@ -89,10 +98,12 @@ func (a *activeMQMetadata) Validate() error {
 	return nil
 }
 
+// activeMQMonitoring is the Jolokia response, the value is a number or, when the MBean name is a pattern
+// like brokerName=*, an object with the attributes of every matching MBean
 type activeMQMonitoring struct {
-	MsgCount  int   `json:"value"`
-	Status    int   `json:"status"`
-	Timestamp int64 `json:"timestamp"`
+	Value     json.RawMessage `json:"value"`
+	Status    int             `json:"status"`
+	Timestamp int64           `json:"timestamp"`
 }
 
 // NewActiveMQScaler creates a new activeMQ Scaler
@@ -125,10 +136,10 @@ func parseActiveMQMetadata(config *scalersconfig.ScalerConfig) (*activeMQMetadat
 	return meta, nil
 }
 
-func (s *activeMQScaler) getMonitoringEndpoint() (string, error) {
+func (s *activeMQScaler) getMonitoringEndpoint(managementEndpoint string) (string, error) {
 	var buf bytes.Buffer
 	endpoint := map[string]string{
-		"ManagementEndpoint": s.metadata.ManagementEndpoint,
+		"ManagementEndpoint": managementEndpoint,
 		"BrokerName":         s.metadata.BrokerName,
 		"DestinationName":    s.metadata.DestinationName,
 	}
@@ -144,12 +155,32 @@ func (s *activeMQScaler) getMonitoringEndpoint() (string, error) {
 	return monitoringEndpoint, nil
 }
 
+// getQueueMessageCount returns the queue size summed across the management endpoints
 func (s *activeMQScaler) getQueueMessageCount(ctx context.Context) (int64, error) {
-	var monitoringInfo *activeMQMonitoring
+	endpoints := s.metadata.ManagementEndpoints
+	if len(endpoints) == 0 {
+		endpoints = []string{s.metadata.ManagementEndpoint}
+	}
+
 	var queueMessageCount int64
+	for _, endpoint := range endpoints {
+		count, err := s.getBrokerQueueMessageCount(ctx, endpoint)
+		if err != nil {
+			return -1, err
+		}
+		queueMessageCount += count
+	}
+
+	s.logger.V(1).Info(fmt.Sprintf("ActiveMQ scaler: Providing metrics based on current queue size %d queue size limit %d", queueMessageCount, s.metadata.TargetQueueSize))
+
+	return queueMessageCount, nil
+}
+
+func (s *activeMQScaler) getBrokerQueueMessageCount(ctx context.Context, managementEndpoint string) (int64, error) {
+	var monitoringInfo *activeMQMonitoring
 
 	client := s.httpClient
-	url, err := s.getMonitoringEndpoint()
+	url, err := s.getMonitoringEndpoint(managementEndpoint)
 	if err != nil {
 		return -1, err
 	}
@@ -174,15 +205,11 @@ func (s *activeMQScaler) getQueueMessageCount(ctx context.Context) (int64, error
 	if err := json.NewDecoder(resp.Body).Decode(&monitoringInfo); err != nil {
 		return -1, err
 	}
-	if resp.StatusCode == 200 && monitoringInfo.Status == 200 {
-		queueMessageCount = int64(monitoringInfo.MsgCount)
-	} else {
+	if resp.StatusCode != 200 || monitoringInfo.Status != 200 {
 		return -1, fmt.Errorf("ActiveMQ management endpoint response error code : %d %d", resp.StatusCode, monitoringInfo.Status)
 	}
 
-	s.logger.V(1).Info(fmt.Sprintf("ActiveMQ scaler: Providing metrics based on current queue size %d queue size limit %d", queueMessageCount, s.metadata.TargetQueueSize))
-
-	return queueMessageCount, nil
+	return sumJolokiaValue(monitoringInfo.Value)
 }
 
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
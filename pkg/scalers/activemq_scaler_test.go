@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/go-logr/logr"
+
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
 
@@ -220,6 +223,18 @@ var testActiveMQMetadata = []parseActiveMQMetadataTestData{
 		},
 		isError: true,
 	},
+	{
+		name: "several management endpoints with a restAPITemplate, should fail",
+		metadata: map[string]string{
+			"managementEndpoints": "broker-0:8161,broker-1:8161",
+			"restAPITemplate":     "http://localhost:8161/api/jolokia/read/org.apache.activemq:type=Broker,brokerName=*,destinationType=Queue,destinationName=testQueue/QueueSize",
+		},
+		authParams: map[string]string{
+			"username": "testUsername",
+			"password": "pass123",
+		},
+		isError: true,
+	},
 }
 
 func TestActiveMQDefaultCorsHeader(t *testing.T) {
@@ -355,7 +370,7 @@ func TestActiveMQGetMonitoringEndpoint(t *testing.T) {
 			httpClient: http.DefaultClient,
 		}
 
-		endpoint, err := mockActiveMQScaler.getMonitoringEndpoint()
+		endpoint, err := mockActiveMQScaler.getMonitoringEndpoint(mockActiveMQScaler.metadata.ManagementEndpoint)
 		if err != nil {
 			t.Fatal("Could not get the endpoint:", err)
 		}
@@ -365,3 +380,39 @@ func TestActiveMQGetMonitoringEndpoint(t *testing.T) {
 		}
 	}
 }
+
+func TestActiveMQGetQueueMessageCountAcrossBrokers(t *testing.T) {
+	broker0 := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(writer, `{"value":3,"status":200,"timestamp":1}`)
+	}))
+	defer broker0.Close()
+	broker1 := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(writer, `{"value":{"org.apache.activemq:type=Broker,brokerName=broker-1,destinationType=Queue,destinationName=testQueue":{"QueueSize":4}},"status":200,"timestamp":1}`)
+	}))
+	defer broker1.Close()
+
+	metadata, err := parseActiveMQMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"managementEndpoints": broker0.Listener.Addr().String() + "," + broker1.Listener.Addr().String(),
+			"destinationName":     "testQueue",
+			"brokerName":          "*",
+		},
+		AuthParams: map[string]string{"username": "testUsername", "password": "pass123"},
+	})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	mockActiveMQScaler := activeMQScaler{
+		metadata:   metadata,
+		httpClient: http.DefaultClient,
+		logger:     logr.Discard(),
+	}
+
+	count, err := mockActiveMQScaler.getQueueMessageCount(context.Background())
+	if err != nil {
+		t.Fatal("Could not get the queue size:", err)
+	}
+	if count != 7 {
+		t.Errorf("Wrong queue size: %d, expected: 7", count)
+	}
+}
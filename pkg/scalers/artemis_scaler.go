@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
@@ -37,34 +38,55 @@ type artemisMetadata struct {
 	QueueLength           int64  `keda:"name=queueLength, order=triggerMetadata, optional, default=10"`
 	ActivationQueueLength int64  `keda:"name=activationQueueLength, order=triggerMetadata, optional, default=10"`
 	CorsHeader            string `keda:"name=corsHeader, order=triggerMetadata, optional"`
+	// ManagementEndpoints are the management endpoints of every broker of the cluster, the message counts are summed
+	ManagementEndpoints []string `keda:"name=managementEndpoints, order=triggerMetadata, optional"`
+	// DiscoverBrokers adds the brokers of the cluster topology of the management endpoints to the endpoints
+	DiscoverBrokers   bool   `keda:"name=discoverBrokers, order=triggerMetadata, optional"`
+	MetricLevel       string `keda:"name=metricLevel, order=triggerMetadata, enum=queue;address, default=queue"`
+	PerConsumerTarget bool   `keda:"name=perConsumerTarget, order=triggerMetadata, optional"`
 }
 
 //revive:enable:var-naming
 
+// artemisMonitoring is the Jolokia response, the value is a number or, when the MBean name is a pattern
+// like broker="*", an object with the attributes of every matching MBean
 type artemisMonitoring struct {
-	MsgCount  int   `json:"value"`
-	Status    int   `json:"status"`
-	Timestamp int64 `json:"timestamp"`
+	Value     json.RawMessage `json:"value"`
+	Status    int             `json:"status"`
+	Timestamp int64           `json:"timestamp"`
 }
 
 const (
-	artemisMetricType      = "External"
-	defaultRestAPITemplate = "http://<<managementEndpoint>>/console/jolokia/read/org.apache.activemq.artemis:broker=\"<<brokerName>>\",component=addresses,address=\"<<brokerAddress>>\",subcomponent=queues,routing-type=\"anycast\",queue=\"<<queueName>>\"/MessageCount"
-	defaultCorsHeader      = "http://%s"
+	artemisMetricType             = "External"
+	artemisQueueLevel             = "queue"
+	artemisAddressLevel           = "address"
+	defaultRestAPITemplate        = "http://<<managementEndpoint>>/console/jolokia/read/org.apache.activemq.artemis:broker=\"<<brokerName>>\",component=addresses,address=\"<<brokerAddress>>\",subcomponent=queues,routing-type=\"anycast\",queue=\"<<queueName>>\"/MessageCount"
+	defaultAddressRestAPITemplate = "http://<<managementEndpoint>>/console/jolokia/read/org.apache.activemq.artemis:broker=\"<<brokerName>>\",component=addresses,address=\"<<brokerAddress>>\"/MessageCount"
+	artemisTopologyTemplate       = "http://<<managementEndpoint>>/console/jolokia/read/org.apache.activemq.artemis:broker=*,component=cluster-connections,name=*/Nodes"
+	artemisMessageCountAttribute  = "/MessageCount"
+	artemisConsumerCountAttribute = "/ConsumerCount"
+	defaultCorsHeader             = "http://%s"
 )
 
 func (a *artemisMetadata) Validate() error {
 	if a.RestAPITemplate != "" {
+		// every broker is read through the template, a literal host would count the same broker once per endpoint
+		if (len(a.ManagementEndpoints) > 0 || a.DiscoverBrokers) && !strings.Contains(a.RestAPITemplate, "<<managementEndpoint>>") {
+			return errors.New("restApiTemplate must contain <<managementEndpoint>> with managementEndpoints or discoverBrokers")
+		}
 		var err error
 		if *a, err = getAPIParameters(*a); err != nil {
 			return fmt.Errorf("can't parse restApiTemplate : %s ", err)
 		}
 	} else {
 		a.RestAPITemplate = defaultRestAPITemplate
-		if a.ManagementEndpoint == "" {
+		if a.MetricLevel == artemisAddressLevel {
+			a.RestAPITemplate = defaultAddressRestAPITemplate
+		}
+		if a.ManagementEndpoint == "" && len(a.ManagementEndpoints) == 0 {
 			return errors.New("no management endpoint given")
 		}
-		if a.QueueName == "" {
+		if a.QueueName == "" && a.MetricLevel == artemisQueueLevel {
 			return errors.New("no queue name given")
 		}
 		if a.BrokerName == "" {
@@ -74,6 +96,17 @@ func (a *artemisMetadata) Validate() error {
 			return errors.New("no broker address given")
 		}
 	}
+	if a.PerConsumerTarget {
+		if a.MetricLevel == artemisAddressLevel {
+			return errors.New("perConsumerTarget is only supported for the queue metricLevel")
+		}
+		if !strings.HasSuffix(a.RestAPITemplate, artemisMessageCountAttribute) {
+			return fmt.Errorf("perConsumerTarget requires the restApiTemplate to read the %s attribute", strings.TrimPrefix(artemisMessageCountAttribute, "/"))
+		}
+	}
+	if a.ManagementEndpoint == "" {
+		a.ManagementEndpoint = a.ManagementEndpoints[0]
+	}
 	if a.CorsHeader == "" {
 		a.CorsHeader = fmt.Sprintf(defaultCorsHeader, a.ManagementEndpoint)
 	}
@@ -97,6 +130,14 @@ func NewArtemisQueueScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing artemis metadata: %w", err)
 	}
 
+	// the message count per consumer is already relative to the replicas consuming the queue
+	if artemisMetadata.PerConsumerTarget {
+		if config.MetricType != "" && metricType != v2.ValueMetricType {
+			return nil, fmt.Errorf("perConsumerTarget requires the %s metricType", v2.ValueMetricType)
+		}
+		metricType = v2.ValueMetricType
+	}
+
 	return &artemisScaler{
 		metricType: metricType,
 		metadata:   artemisMetadata,
@@ -122,7 +163,9 @@ func getAPIParameters(meta artemisMetadata) (artemisMetadata, error) {
 	if err != nil {
 		return meta, fmt.Errorf("unable to parse the artemis restAPITemplate: %w", err)
 	}
-	meta.ManagementEndpoint = u.Host
+	if u.Host != "<<managementEndpoint>>" {
+		meta.ManagementEndpoint = u.Host
+	}
 	splitURL := strings.Split(strings.Split(u.RawPath, ":")[1], "/")[0] // This returns : broker="<<brokerName>>",component=addresses,address="<<brokerAddress>>",subcomponent=queues,routing-type="anycast",queue="<<queueName>>"
 	replacer := strings.NewReplacer(",", "&", "\"\"", "")
 	v, err := url.ParseQuery(replacer.Replace(splitURL)) // This returns a map with key: string types and element type [] string. : map[address:["<<brokerAddress>>"] broker:["<<brokerName>>"] component:[addresses] queue:["<<queueName>>"] routing-type:["anycast"] subcomponent:[queues]]
@@ -130,26 +173,26 @@ func getAPIParameters(meta artemisMetadata) (artemisMetadata, error) {
 		return meta, fmt.Errorf("unable to parse the artemis restAPITemplate: %w", err)
 	}
 
-	if len(v["address"][0]) == 0 {
+	if len(v.Get("address")) == 0 {
 		return meta, errors.New("no brokerAddress given")
 	}
-	meta.BrokerAddress = v["address"][0]
+	meta.BrokerAddress = v.Get("address")
 
-	if len(v["queue"][0]) == 0 {
+	if len(v.Get("queue")) == 0 && meta.MetricLevel != artemisAddressLevel {
 		return meta, errors.New("no queueName is given")
 	}
-	meta.QueueName = v["queue"][0]
+	meta.QueueName = v.Get("queue")
 
-	if len(v["broker"][0]) == 0 {
+	if len(v.Get("broker")) == 0 {
 		return meta, fmt.Errorf("no brokerName given: %s", meta.RestAPITemplate)
 	}
-	meta.BrokerName = v["broker"][0]
+	meta.BrokerName = v.Get("broker")
 
 	return meta, nil
 }
 
-func (s *artemisScaler) getMonitoringEndpoint() string {
-	replacer := strings.NewReplacer("<<managementEndpoint>>", s.metadata.ManagementEndpoint,
+func (s *artemisScaler) getMonitoringEndpoint(managementEndpoint string) string {
+	replacer := strings.NewReplacer("<<managementEndpoint>>", managementEndpoint,
 		"<<queueName>>", s.metadata.QueueName,
 		"<<brokerName>>", s.metadata.BrokerName,
 		"<<brokerAddress>>", s.metadata.BrokerAddress)
@@ -159,45 +202,159 @@ func (s *artemisScaler) getMonitoringEndpoint() string {
 	return monitoringEndpoint
 }
 
-func (s *artemisScaler) getQueueMessageCount(ctx context.Context) (int64, error) {
-	var monitoringInfo *artemisMonitoring
-	var messageCount int64
+// getManagementEndpoints returns the management endpoints of the brokers to aggregate, the discovered brokers
+// are expected to expose their management endpoint on the same port as the configured ones
+func (s *artemisScaler) getManagementEndpoints(ctx context.Context) ([]string, error) {
+	endpoints := s.metadata.ManagementEndpoints
+	if len(endpoints) == 0 {
+		endpoints = []string{s.metadata.ManagementEndpoint}
+	}
+	if !s.metadata.DiscoverBrokers {
+		return endpoints, nil
+	}
 
-	client := s.httpClient
-	url := s.getMonitoringEndpoint()
+	_, port, err := net.SplitHostPort(endpoints[0])
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the management port of %s: %w", endpoints[0], err)
+	}
+
+	value, err := s.readJolokiaValue(ctx, strings.ReplaceAll(artemisTopologyTemplate, "<<managementEndpoint>>", endpoints[0]))
+	if err != nil {
+		return nil, fmt.Errorf("unable to discover the artemis cluster topology: %w", err)
+	}
+	// the value is an object with the Nodes attribute of every cluster connection,
+	// Nodes being the acceptor address of every other node of the cluster by node ID
+	var clusterConnections map[string]map[string]map[string]string
+	if err := json.Unmarshal(value, &clusterConnections); err != nil {
+		return nil, fmt.Errorf("unable to parse the artemis cluster topology: %w", err)
+	}
+
+	discovered := make(map[string]bool, len(endpoints))
+	for _, endpoint := range endpoints {
+		discovered[endpoint] = true
+	}
+	for _, attributes := range clusterConnections {
+		for _, address := range attributes["Nodes"] {
+			if i := strings.Index(address, "://"); i >= 0 {
+				address = address[i+len("://"):]
+			}
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				host = address
+			}
+			endpoint := net.JoinHostPort(host, port)
+			if !discovered[endpoint] {
+				discovered[endpoint] = true
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+	}
+	return endpoints, nil
+}
+
+func (s *artemisScaler) readJolokiaValue(ctx context.Context, url string) (json.RawMessage, error) {
+	var monitoringInfo *artemisMonitoring
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return -1, err
+		return nil, err
 	}
 	req.SetBasicAuth(s.metadata.Username, s.metadata.Password)
 	req.Header.Set("Origin", s.metadata.CorsHeader)
 
-	resp, err := client.Do(req)
+	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return -1, err
+		return nil, err
 	}
 
 	defer resp.Body.Close()
 
 	if err := json.NewDecoder(resp.Body).Decode(&monitoringInfo); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 200 || monitoringInfo.Status != 200 {
+		return nil, fmt.Errorf("artemis management endpoint response error code : %d %d", resp.StatusCode, monitoringInfo.Status)
+	}
+	return monitoringInfo.Value, nil
+}
+
+// sumJolokiaValue sums the numbers of a Jolokia value, reading the attributes of every MBean matching a pattern
+func sumJolokiaValue(value json.RawMessage) (int64, error) {
+	var count int64
+	if err := json.Unmarshal(value, &count); err == nil {
+		return count, nil
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(value, &values); err != nil {
+		return 0, fmt.Errorf("unexpected Jolokia value %s", string(value))
+	}
+	for _, v := range values {
+		c, err := sumJolokiaValue(v)
+		if err != nil {
+			return 0, err
+		}
+		count += c
+	}
+	return count, nil
+}
+
+func (s *artemisScaler) readJolokiaCount(ctx context.Context, url string) (int64, error) {
+	value, err := s.readJolokiaValue(ctx, url)
+	if err != nil {
 		return -1, err
 	}
-	if resp.StatusCode == 200 && monitoringInfo.Status == 200 {
-		messageCount = int64(monitoringInfo.MsgCount)
-	} else {
-		return -1, fmt.Errorf("artemis management endpoint response error code : %d %d", resp.StatusCode, monitoringInfo.Status)
+	return sumJolokiaValue(value)
+}
+
+// getQueueMessageCount returns the messages of the queue or the address summed across the brokers,
+// divided by the consumers of the queue with perConsumerTarget
+func (s *artemisScaler) getQueueMessageCount(ctx context.Context) (int64, float64, error) {
+	endpoints, err := s.getManagementEndpoints(ctx)
+	if err != nil {
+		return -1, -1, err
+	}
+
+	var messageCount, consumerCount int64
+	for _, endpoint := range endpoints {
+		url := s.getMonitoringEndpoint(endpoint)
+		count, err := s.readJolokiaCount(ctx, url)
+		if err != nil {
+			return -1, -1, err
+		}
+		messageCount += count
+
+		if s.metadata.PerConsumerTarget {
+			count, err := s.readJolokiaCount(ctx, strings.TrimSuffix(url, artemisMessageCountAttribute)+artemisConsumerCountAttribute)
+			if err != nil {
+				return -1, -1, err
+			}
+			consumerCount += count
+		}
 	}
 
 	s.logger.V(1).Info(fmt.Sprintf("Artemis scaler: Providing metrics based on current queue length %d queue length limit %d", messageCount, s.metadata.QueueLength))
 
-	return messageCount, nil
+	if !s.metadata.PerConsumerTarget {
+		return messageCount, float64(messageCount), nil
+	}
+	// messages without any consumer are counted as the backlog of a single consumer
+	if consumerCount == 0 {
+		consumerCount = 1
+	}
+	return messageCount, float64(messageCount) / float64(consumerCount), nil
+}
+
+func (s *artemisScaler) getDestinationName() string {
+	if s.metadata.MetricLevel == artemisAddressLevel {
+		return s.metadata.BrokerAddress
+	}
+	return s.metadata.QueueName
 }
 
 func (s *artemisScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.TriggerIndex, kedautil.NormalizeString(fmt.Sprintf("artemis-%s", s.metadata.QueueName))),
+			Name: GenerateMetricNameWithIndex(s.metadata.TriggerIndex, kedautil.NormalizeString(fmt.Sprintf("artemis-%s", s.getDestinationName()))),
 		},
 		Target: GetMetricTarget(s.metricType, s.metadata.QueueLength),
 	}
@@ -207,14 +364,14 @@ func (s *artemisScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec
 
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
 func (s *artemisScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	messages, err := s.getQueueMessageCount(ctx)
+	messages, value, err := s.getQueueMessageCount(ctx)
 
 	if err != nil {
 		s.logger.Error(err, "Unable to access the artemis management endpoint", "managementEndpoint", s.metadata.ManagementEndpoint)
 		return []external_metrics.ExternalMetricValue{}, false, err
 	}
 
-	metric := GenerateMetricInMili(metricName, float64(messages))
+	metric := GenerateMetricInMili(metricName, value)
 
 	return []external_metrics.ExternalMetricValue{metric}, messages > s.metadata.ActivationQueueLength, nil
 }
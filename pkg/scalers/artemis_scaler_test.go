@@ -2,9 +2,16 @@ package scalers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
 
@@ -60,11 +67,30 @@ var testArtemisMetadata = []parseArtemisMetadataTestData{
 	{map[string]string{"restApiTemplate": "http://localhost:8161/console/jolokia/read/org.apache.activemq.artemis:broker=\"broker-activemq\",component=addresses,address=\"test\",subcomponent=queues,routing-type=\"anycast\",queue=\"queue1\"/MessageCount", "username": "myUserName", "password": "myPassword"}, false},
 	// Missing brokername , should fail
 	{map[string]string{"restApiTemplate": "http://localhost:8161/console/jolokia/read/org.apache.activemq.artemis:broker=\"\",component=addresses,address=\"test\",subcomponent=queues,routing-type=\"anycast\",queue=\"queue1\"/MessageCount", "username": "myUserName", "password": "myPassword"}, true},
+	// several management endpoints
+	{map[string]string{"managementEndpoints": "broker-0:8161,broker-1:8161", "queueName": "queue1", "brokerName": "*", "brokerAddress": "test", "username": "myUserName", "password": "myPassword"}, false},
+	// address level without queue name
+	{map[string]string{"managementEndpoint": "localhost:8161", "metricLevel": "address", "brokerName": "broker-activemq", "brokerAddress": "test", "username": "myUserName", "password": "myPassword"}, false},
+	// address level with a restApiTemplate without queue
+	{map[string]string{"metricLevel": "address", "restApiTemplate": "http://localhost:8161/console/jolokia/read/org.apache.activemq.artemis:broker=\"broker-activemq\",component=addresses,address=\"test\"/MessageCount", "username": "myUserName", "password": "myPassword"}, false},
+	// invalid metric level
+	{map[string]string{"managementEndpoint": "localhost:8161", "metricLevel": "broker", "queueName": "queue1", "brokerName": "broker-activemq", "brokerAddress": "test", "username": "myUserName", "password": "myPassword"}, true},
+	// per consumer target
+	{map[string]string{"managementEndpoint": "localhost:8161", "perConsumerTarget": "true", "queueName": "queue1", "brokerName": "broker-activemq", "brokerAddress": "test", "username": "myUserName", "password": "myPassword"}, false},
+	// per consumer target at address level, should fail
+	{map[string]string{"managementEndpoint": "localhost:8161", "metricLevel": "address", "perConsumerTarget": "true", "brokerName": "broker-activemq", "brokerAddress": "test", "username": "myUserName", "password": "myPassword"}, true},
+	// several management endpoints read through a restApiTemplate
+	{map[string]string{"managementEndpoints": "broker-0:8161,broker-1:8161", "restApiTemplate": "http://<<managementEndpoint>>/console/jolokia/read/org.apache.activemq.artemis:broker=\"*\",component=addresses,address=\"test\",subcomponent=queues,routing-type=\"anycast\",queue=\"queue1\"/MessageCount", "username": "myUserName", "password": "myPassword"}, false},
+	// several management endpoints with a restApiTemplate reading a single host, should fail
+	{map[string]string{"managementEndpoints": "broker-0:8161,broker-1:8161", "restApiTemplate": "http://localhost:8161/console/jolokia/read/org.apache.activemq.artemis:broker=\"*\",component=addresses,address=\"test\",subcomponent=queues,routing-type=\"anycast\",queue=\"queue1\"/MessageCount", "username": "myUserName", "password": "myPassword"}, true},
+	// discovered brokers with a restApiTemplate reading a single host, should fail
+	{map[string]string{"discoverBrokers": "true", "restApiTemplate": "http://localhost:8161/console/jolokia/read/org.apache.activemq.artemis:broker=\"*\",component=addresses,address=\"test\",subcomponent=queues,routing-type=\"anycast\",queue=\"queue1\"/MessageCount", "username": "myUserName", "password": "myPassword"}, true},
 }
 
 var artemisMetricIdentifiers = []artemisMetricIdentifier{
 	{&testArtemisMetadata[7], 0, "s0-artemis-queue1"},
 	{&testArtemisMetadata[7], 1, "s1-artemis-queue1"},
+	{&testArtemisMetadata[11], 0, "s0-artemis-test"},
 }
 
 var testArtemisMetadataWithEmptyAuthParams = []parseArtemisMetadataTestData{
@@ -163,3 +189,86 @@ func TestArtemisGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func newArtemisTestServer(t *testing.T, nodes string, messageCount string, consumerCount string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		username, password, ok := request.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "admin", username)
+		assert.Equal(t, "admin", password)
+
+		var value string
+		switch {
+		case strings.HasSuffix(request.URL.Path, "/Nodes"):
+			value = nodes
+		case strings.HasSuffix(request.URL.Path, "/MessageCount"):
+			value = messageCount
+		case strings.HasSuffix(request.URL.Path, "/ConsumerCount"):
+			value = consumerCount
+		default:
+			t.Errorf("Unexpected path %s", request.URL.Path)
+		}
+		_, err := fmt.Fprintf(writer, `{"value":%s,"status":200,"timestamp":1}`, value)
+		require.NoError(t, err)
+	}))
+}
+
+func TestArtemisGetQueueMessageCount(t *testing.T) {
+	broker0 := newArtemisTestServer(t, "{}", `{"org.apache.activemq.artemis:broker=\"broker-0\",component=addresses,address=\"test\",subcomponent=queues,routing-type=\"anycast\",queue=\"queue1\"":{"MessageCount":5}}`, "1")
+	defer broker0.Close()
+	broker1 := newArtemisTestServer(t, "{}", "7", "3")
+	defer broker1.Close()
+	endpoints := broker0.Listener.Addr().String() + "," + broker1.Listener.Addr().String()
+
+	tests := []struct {
+		name          string
+		metadata      map[string]string
+		expectedCount int64
+		expectedValue float64
+	}{
+		{
+			name:          "messages are summed across the brokers",
+			metadata:      map[string]string{"managementEndpoints": endpoints, "queueName": "queue1", "brokerName": "*", "brokerAddress": "test"},
+			expectedCount: 12,
+			expectedValue: 12,
+		},
+		{
+			name:          "messages are divided by the consumers",
+			metadata:      map[string]string{"managementEndpoints": endpoints, "queueName": "queue1", "brokerName": "*", "brokerAddress": "test", "perConsumerTarget": "true"},
+			expectedCount: 12,
+			expectedValue: 3,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			meta, err := parseArtemisMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: tt.metadata, AuthParams: artemisAuthParams})
+			require.NoError(t, err)
+			scaler := artemisScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+			count, value, err := scaler.getQueueMessageCount(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCount, count)
+			assert.Equal(t, tt.expectedValue, value)
+		})
+	}
+}
+
+func TestArtemisDiscoverBrokers(t *testing.T) {
+	broker := newArtemisTestServer(t, `{"org.apache.activemq.artemis:broker=\"broker-0\",component=cluster-connections,name=\"my-cluster\"":{"Nodes":{"node-1":"tcp://localhost:61616"}}}`, "4", "1")
+	defer broker.Close()
+
+	meta, err := parseArtemisMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{"managementEndpoint": broker.Listener.Addr().String(), "discoverBrokers": "true", "metricLevel": "address", "brokerName": "*", "brokerAddress": "test"}, AuthParams: artemisAuthParams})
+	require.NoError(t, err)
+	scaler := artemisScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+	endpoints, err := scaler.getManagementEndpoints(context.Background())
+	require.NoError(t, err)
+	_, port, _ := strings.Cut(broker.Listener.Addr().String(), ":")
+	assert.Equal(t, []string{broker.Listener.Addr().String(), "localhost:" + port}, endpoints)
+
+	count, _, err := scaler.getQueueMessageCount(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), count)
+}
@@ -10,14 +10,26 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/metrics/pkg/apis/external_metrics"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
+// cpuMemoryScaler lets the HPA scale on the resource metrics of the pods, when the trigger is a metric
+// source of a scalingModifiers formula it reads the usage of the pods from the metrics.k8s.io API instead
 type cpuMemoryScaler struct {
 	metadata     *cpuMemoryMetadata
 	resourceName v1.ResourceName
+	kubeClient   client.Client
 	logger       logr.Logger
+
+	asMetricSource          bool
+	triggerIndex            int
+	scalableObjectName      string
+	scalableObjectNamespace string
+	scalableObjectType      string
 }
 
 type cpuMemoryMetadata struct {
@@ -28,7 +40,7 @@ type cpuMemoryMetadata struct {
 }
 
 // NewCPUMemoryScaler creates a new cpuMemoryScaler
-func NewCPUMemoryScaler(resourceName v1.ResourceName, config *scalersconfig.ScalerConfig) (Scaler, error) {
+func NewCPUMemoryScaler(resourceName v1.ResourceName, kubeClient client.Client, config *scalersconfig.ScalerConfig) (Scaler, error) {
 	logger := InitializeLogger(config, "cpu_memory_scaler")
 
 	meta, parseErr := parseResourceMetadata(config, logger)
@@ -37,9 +49,15 @@ func NewCPUMemoryScaler(resourceName v1.ResourceName, config *scalersconfig.Scal
 	}
 
 	return &cpuMemoryScaler{
-		metadata:     meta,
-		resourceName: resourceName,
-		logger:       logger,
+		metadata:                meta,
+		resourceName:            resourceName,
+		kubeClient:              kubeClient,
+		logger:                  logger,
+		asMetricSource:          config.AsMetricSource,
+		triggerIndex:            config.TriggerIndex,
+		scalableObjectName:      config.ScalableObjectName,
+		scalableObjectNamespace: config.ScalableObjectNamespace,
+		scalableObjectType:      config.ScalableObjectType,
 	}, nil
 }
 
@@ -94,6 +112,25 @@ func (s *cpuMemoryScaler) Close(context.Context) error {
 func (s *cpuMemoryScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	var metricSpec v2.MetricSpec
 
+	if s.asMetricSource {
+		metricName := string(s.resourceName)
+		if s.metadata.ContainerName != "" {
+			metricName = fmt.Sprintf("%s-%s", s.resourceName, s.metadata.ContainerName)
+		}
+		target := v2.MetricTarget{Type: v2.AverageValueMetricType, AverageValue: s.metadata.AverageValue}
+		if s.metadata.Type == v2.UtilizationMetricType {
+			target.AverageValue = resource.NewQuantity(int64(*s.metadata.AverageUtilization), resource.DecimalSI)
+		}
+		externalMetric := &v2.ExternalMetricSource{
+			Metric: v2.MetricIdentifier{
+				Name: GenerateMetricNameWithIndex(s.triggerIndex, kedautil.NormalizeString(metricName)),
+			},
+			Target: target,
+		}
+		metricSpec = v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+		return []v2.MetricSpec{metricSpec}
+	}
+
 	if s.metadata.ContainerName != "" {
 		containerCPUMemoryMetric := &v2.ContainerResourceMetricSource{
 			Name: s.resourceName,
@@ -120,7 +157,84 @@ func (s *cpuMemoryScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSp
 	return []v2.MetricSpec{metricSpec}
 }
 
-// GetMetricsAndActivity no need for cpu/memory scaler and always active for cpu/memory scaler
-func (s *cpuMemoryScaler) GetMetricsAndActivity(_ context.Context, _ string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	return nil, true, nil
+// GetMetricsAndActivity returns the usage of the pods when the trigger is a metric source, otherwise the
+// metric is read by the HPA. The cpu/memory scaler is always active.
+func (s *cpuMemoryScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	if !s.asMetricSource {
+		return nil, true, nil
+	}
+
+	val, err := s.getPodsUsage(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, true, fmt.Errorf("error getting the %s usage of the pods: %w", s.resourceName, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, val)
+
+	return []external_metrics.ExternalMetricValue{metric}, true, nil
+}
+
+// getPodsUsage returns the usage of the running pods of the scale target which have metrics, like the HPA
+// computes it: the utilization is the percentage of the sum of the usages over the sum of the requests, the
+// average value is the sum of the usages divided by the number of pods (in cores for cpu, in bytes for memory)
+func (s *cpuMemoryScaler) getPodsUsage(ctx context.Context) (float64, error) {
+	selector, err := GetScaleTargetPodSelector(ctx, s.kubeClient, s.scalableObjectType, s.scalableObjectName, s.scalableObjectNamespace)
+	if err != nil {
+		return 0, err
+	}
+
+	podList := &v1.PodList{}
+	if err := s.kubeClient.List(ctx, podList, client.InNamespace(s.scalableObjectNamespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return 0, err
+	}
+	podMetricsList := &metricsv1beta1.PodMetricsList{}
+	if err := s.kubeClient.List(ctx, podMetricsList, client.InNamespace(s.scalableObjectNamespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return 0, fmt.Errorf("error listing the pods metrics: %w", err)
+	}
+	podMetrics := make(map[string]*metricsv1beta1.PodMetrics, len(podMetricsList.Items))
+	for i := range podMetricsList.Items {
+		podMetrics[podMetricsList.Items[i].Name] = &podMetricsList.Items[i]
+	}
+
+	var usage, requests float64
+	pods := 0
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		metrics, ok := podMetrics[pod.Name]
+		if !ok || pod.Status.Phase != v1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, container := range metrics.Containers {
+			if s.metadata.ContainerName != "" && container.Name != s.metadata.ContainerName {
+				continue
+			}
+			if quantity, ok := container.Usage[s.resourceName]; ok {
+				usage += quantity.AsApproximateFloat64()
+			}
+		}
+		if s.metadata.Type == v2.UtilizationMetricType {
+			for _, container := range pod.Spec.Containers {
+				if s.metadata.ContainerName != "" && container.Name != s.metadata.ContainerName {
+					continue
+				}
+				request, ok := container.Resources.Requests[s.resourceName]
+				if !ok {
+					return 0, fmt.Errorf("missing request for %s in container %s of pod %s", s.resourceName, container.Name, pod.Name)
+				}
+				requests += request.AsApproximateFloat64()
+			}
+		}
+		pods++
+	}
+	if pods == 0 {
+		return 0, nil
+	}
+
+	if s.metadata.Type == v2.UtilizationMetricType {
+		if requests == 0 {
+			return 0, fmt.Errorf("the pods have no request for %s", s.resourceName)
+		}
+		return usage * 100 / requests, nil
+	}
+	return usage / float64(pods), nil
 }
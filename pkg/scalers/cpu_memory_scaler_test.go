@@ -6,9 +6,18 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	v2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
 
@@ -64,7 +73,7 @@ func TestGetMetricSpecForScaling(t *testing.T) {
 	config := &scalersconfig.ScalerConfig{
 		TriggerMetadata: validCPUMemoryMetadata,
 	}
-	scaler, _ := NewCPUMemoryScaler(v1.ResourceCPU, config)
+	scaler, _ := NewCPUMemoryScaler(v1.ResourceCPU, nil, config)
 	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
 
 	assert.Equal(t, metricSpec[0].Type, v2.ResourceMetricSourceType)
@@ -76,7 +85,7 @@ func TestGetMetricSpecForScaling(t *testing.T) {
 		TriggerMetadata: map[string]string{"value": "50"},
 		MetricType:      v2.UtilizationMetricType,
 	}
-	scaler, _ = NewCPUMemoryScaler(v1.ResourceCPU, config)
+	scaler, _ = NewCPUMemoryScaler(v1.ResourceCPU, nil, config)
 	metricSpec = scaler.GetMetricSpecForScaling(context.Background())
 
 	assert.Equal(t, metricSpec[0].Type, v2.ResourceMetricSourceType)
@@ -89,7 +98,7 @@ func TestGetContainerMetricSpecForScaling(t *testing.T) {
 	config := &scalersconfig.ScalerConfig{
 		TriggerMetadata: validContainerCPUMemoryMetadata,
 	}
-	scaler, _ := NewCPUMemoryScaler(v1.ResourceCPU, config)
+	scaler, _ := NewCPUMemoryScaler(v1.ResourceCPU, nil, config)
 	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
 
 	assert.Equal(t, metricSpec[0].Type, v2.ContainerResourceMetricSourceType)
@@ -102,7 +111,7 @@ func TestGetContainerMetricSpecForScaling(t *testing.T) {
 		TriggerMetadata: map[string]string{"value": "50", "containerName": "bar"},
 		MetricType:      v2.UtilizationMetricType,
 	}
-	scaler, _ = NewCPUMemoryScaler(v1.ResourceCPU, config)
+	scaler, _ = NewCPUMemoryScaler(v1.ResourceCPU, nil, config)
 	metricSpec = scaler.GetMetricSpecForScaling(context.Background())
 
 	assert.Equal(t, metricSpec[0].Type, v2.ContainerResourceMetricSourceType)
@@ -110,3 +119,114 @@ func TestGetContainerMetricSpecForScaling(t *testing.T) {
 	assert.Equal(t, metricSpec[0].ContainerResource.Target.Type, v2.UtilizationMetricType)
 	assert.Equal(t, metricSpec[0].ContainerResource.Container, "bar")
 }
+
+func TestGetMetricSpecForScalingAsMetricSource(t *testing.T) {
+	config := &scalersconfig.ScalerConfig{
+		TriggerMetadata: validCPUMemoryMetadata,
+		AsMetricSource:  true,
+		TriggerIndex:    1,
+	}
+	scaler, _ := NewCPUMemoryScaler(v1.ResourceCPU, nil, config)
+	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+
+	assert.Equal(t, v2.ExternalMetricSourceType, metricSpec[0].Type)
+	assert.Equal(t, "s1-cpu", metricSpec[0].External.Metric.Name)
+
+	config = &scalersconfig.ScalerConfig{
+		TriggerMetadata: validContainerCPUMemoryMetadata,
+		AsMetricSource:  true,
+	}
+	scaler, _ = NewCPUMemoryScaler(v1.ResourceMemory, nil, config)
+	metricSpec = scaler.GetMetricSpecForScaling(context.Background())
+
+	assert.Equal(t, v2.ExternalMetricSourceType, metricSpec[0].Type)
+	assert.Equal(t, "s0-memory-foo", metricSpec[0].External.Metric.Name)
+}
+
+func newCPUMemoryTestPod(name string, phase v1.PodPhase) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "worker"}},
+		Spec: v1.PodSpec{Containers: []v1.Container{
+			{Name: "app", Resources: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("200m")}}},
+			{Name: "sidecar", Resources: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("50m")}}},
+		}},
+		Status: v1.PodStatus{Phase: phase},
+	}
+}
+
+func newCPUMemoryTestPodMetrics(name, appCPU, appMemory, sidecarCPU string) *metricsv1beta1.PodMetrics {
+	return &metricsv1beta1.PodMetrics{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "worker"}},
+		Containers: []metricsv1beta1.ContainerMetrics{
+			{Name: "app", Usage: v1.ResourceList{v1.ResourceCPU: resource.MustParse(appCPU), v1.ResourceMemory: resource.MustParse(appMemory)}},
+			{Name: "sidecar", Usage: v1.ResourceList{v1.ResourceCPU: resource.MustParse(sidecarCPU)}},
+		},
+	}
+}
+
+func TestCPUMemoryGetMetricsAsMetricSource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, metricsv1beta1.AddToScheme(scheme))
+	require.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&kedav1alpha1.ScaledObject{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"},
+			Spec:       kedav1alpha1.ScaledObjectSpec{ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "worker"}},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "worker"}}},
+		},
+		newCPUMemoryTestPod("worker-1", v1.PodRunning),
+		newCPUMemoryTestPod("worker-2", v1.PodRunning),
+		// a pod without metrics yet is left out
+		newCPUMemoryTestPod("worker-3", v1.PodRunning),
+		newCPUMemoryTestPodMetrics("worker-1", "100m", "100Mi", "25m"),
+		newCPUMemoryTestPodMetrics("worker-2", "200m", "200Mi", "75m"),
+	).Build()
+
+	tests := []struct {
+		name         string
+		resourceName v1.ResourceName
+		metricType   v2.MetricTargetType
+		metadata     map[string]string
+		milliValue   int64
+	}{
+		{"cpu utilization of the pods", v1.ResourceCPU, v2.UtilizationMetricType, map[string]string{"value": "70"}, 80000},
+		{"cpu utilization of a container", v1.ResourceCPU, v2.UtilizationMetricType, map[string]string{"value": "70", "containerName": "app"}, 75000},
+		{"cpu average value", v1.ResourceCPU, v2.AverageValueMetricType, map[string]string{"value": "500m"}, 200},
+		{"memory average value", v1.ResourceMemory, v2.AverageValueMetricType, map[string]string{"value": "1Gi"}, 150 * 1024 * 1024 * 1000},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scaler, err := NewCPUMemoryScaler(test.resourceName, kubeClient, &scalersconfig.ScalerConfig{
+				TriggerMetadata:         test.metadata,
+				MetricType:              test.metricType,
+				AsMetricSource:          true,
+				ScalableObjectName:      "worker",
+				ScalableObjectNamespace: "default",
+				ScalableObjectType:      "ScaledObject",
+			})
+			require.NoError(t, err)
+
+			metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "s0-cpu")
+			require.NoError(t, err)
+			assert.Equal(t, test.milliValue, metrics[0].Value.MilliValue())
+			assert.True(t, active)
+		})
+	}
+
+	// the requests are needed for the utilization
+	scaler, err := NewCPUMemoryScaler(v1.ResourceMemory, kubeClient, &scalersconfig.ScalerConfig{
+		TriggerMetadata:         map[string]string{"value": "70"},
+		MetricType:              v2.UtilizationMetricType,
+		AsMetricSource:          true,
+		ScalableObjectName:      "worker",
+		ScalableObjectNamespace: "default",
+		ScalableObjectType:      "ScaledObject",
+	})
+	require.NoError(t, err)
+	_, _, err = scaler.GetMetricsAndActivity(context.Background(), "s0-memory")
+	assert.ErrorContains(t, err, "missing request")
+}
@@ -1,8 +1,13 @@
 package scalers
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
@@ -18,9 +23,10 @@ import (
 )
 
 type datadogScaler struct {
-	metadata  *datadogMetadata
-	apiClient *datadog.APIClient
-	logger    logr.Logger
+	metadata   *datadogMetadata
+	apiClient  *datadog.APIClient
+	httpClient *http.Client
+	logger     logr.Logger
 }
 
 type datadogMetadata struct {
@@ -38,11 +44,23 @@ type datadogMetadata struct {
 	lastAvailablePointOffset int
 	useFiller                bool
 	fillValue                float64
+
+	// formula combines the queries named a, b, c... in their order
+	formula       string
+	queries       []string
+	timeseriesURL string
+
+	useClusterAgentProxy bool
+	clusterAgentURL      string
+	clusterAgentToken    string
+	unsafeSsl            bool
 }
 
 const maxString = "max"
 const avgString = "average"
 
+const datadogQueryNames = "abcdefghijklmnopqrstuvwxyz"
+
 var filter *regexp.Regexp
 
 func init() {
@@ -58,14 +76,21 @@ func NewDatadogScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (
 		return nil, fmt.Errorf("error parsing Datadog metadata: %w", err)
 	}
 
-	apiClient, err := newDatadogConnection(ctx, meta, config)
-	if err != nil {
-		return nil, fmt.Errorf("error establishing Datadog connection: %w", err)
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.unsafeSsl)
+
+	// the Cluster Agent holds the Datadog credentials, so the API is only used without it
+	var apiClient *datadog.APIClient
+	if !meta.useClusterAgentProxy {
+		apiClient, err = newDatadogConnection(ctx, meta, config)
+		if err != nil {
+			return nil, fmt.Errorf("error establishing Datadog connection: %w", err)
+		}
 	}
 	return &datadogScaler{
-		metadata:  meta,
-		apiClient: apiClient,
-		logger:    logger,
+		metadata:   meta,
+		apiClient:  apiClient,
+		httpClient: httpClient,
+		logger:     logger,
 	}, nil
 }
 
@@ -126,17 +151,6 @@ func parseDatadogMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger
 		meta.lastAvailablePointOffset = 0 // Default use the last point
 	}
 
-	if val, ok := config.TriggerMetadata["query"]; ok {
-		_, err := parseDatadogQuery(val)
-
-		if err != nil {
-			return nil, fmt.Errorf("error in query: %w", err)
-		}
-		meta.query = val
-	} else {
-		return nil, fmt.Errorf("no query given")
-	}
-
 	if val, ok := config.TriggerMetadata["queryValue"]; ok {
 		queryValue, err := strconv.ParseFloat(val, 64)
 		if err != nil {
@@ -203,16 +217,64 @@ func parseDatadogMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger
 		meta.vType = metricType
 	}
 
+	if val, ok := config.TriggerMetadata["useClusterAgentProxy"]; ok && val != "" {
+		useClusterAgentProxy, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("useClusterAgentProxy parsing error %w", err)
+		}
+		meta.useClusterAgentProxy = useClusterAgentProxy
+	}
+
+	var err error
+	if meta.useClusterAgentProxy {
+		err = parseDatadogClusterAgentMetadata(config, &meta)
+	} else {
+		err = parseDatadogAPIMetadata(config, &meta)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &meta, nil
+}
+
+// parseDatadogAPIMetadata parses the query and the credentials used to query the Datadog API directly
+func parseDatadogAPIMetadata(config *scalersconfig.ScalerConfig, meta *datadogMetadata) error {
+	if val, ok := config.TriggerMetadata["query"]; ok {
+		_, err := parseDatadogQuery(val)
+
+		if err != nil {
+			return fmt.Errorf("error in query: %w", err)
+		}
+		meta.query = val
+	} else {
+		return fmt.Errorf("no query given")
+	}
+
+	if val, ok := config.TriggerMetadata["formula"]; ok && val != "" {
+		queries := splitDatadogQueries(meta.query)
+		if len(queries) > len(datadogQueryNames) {
+			return fmt.Errorf("a formula can't reference more than %d queries", len(datadogQueryNames))
+		}
+		for _, query := range queries {
+			if _, err := parseDatadogQuery(query); err != nil {
+				return fmt.Errorf("error in query %s: %w", query, err)
+			}
+		}
+		meta.formula = val
+		meta.queries = queries
+	}
+
 	if val, ok := config.AuthParams["apiKey"]; ok {
 		meta.apiKey = val
 	} else {
-		return nil, fmt.Errorf("no api key given")
+		return fmt.Errorf("no api key given")
 	}
 
 	if val, ok := config.AuthParams["appKey"]; ok {
 		meta.appKey = val
 	} else {
-		return nil, fmt.Errorf("no app key given")
+		return fmt.Errorf("no app key given")
 	}
 
 	siteVal := "datadoghq.com"
@@ -222,11 +284,89 @@ func parseDatadogMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger
 	}
 
 	meta.datadogSite = siteVal
+	meta.timeseriesURL = fmt.Sprintf("https://api.%s/api/v2/query/timeseries", siteVal)
 
 	metricName := meta.query[0:strings.Index(meta.query, "{")]
 	meta.metricName = GenerateMetricNameWithIndex(config.TriggerIndex, kedautil.NormalizeString(fmt.Sprintf("datadog-%s", metricName)))
 
-	return &meta, nil
+	return nil
+}
+
+// parseDatadogClusterAgentMetadata parses the DatadogMetric and the Cluster Agent service exposing it,
+// the query itself is defined in the DatadogMetric and isn't part of the trigger
+func parseDatadogClusterAgentMetadata(config *scalersconfig.ScalerConfig, meta *datadogMetadata) error {
+	if val, ok := config.TriggerMetadata["query"]; ok && val != "" {
+		return fmt.Errorf("query can't be used with useClusterAgentProxy, the query is defined in the DatadogMetric")
+	}
+
+	metricName, ok := config.TriggerMetadata["datadogMetricName"]
+	if !ok || metricName == "" {
+		return fmt.Errorf("no datadogMetricName given")
+	}
+
+	metricNamespace, ok := config.TriggerMetadata["datadogMetricNamespace"]
+	if !ok || metricNamespace == "" {
+		return fmt.Errorf("no datadogMetricNamespace given")
+	}
+
+	namespace, ok := config.AuthParams["datadogNamespace"]
+	if !ok || namespace == "" {
+		return fmt.Errorf("no datadogNamespace given")
+	}
+
+	service := "datadog-cluster-agent-metrics-api"
+	if val, ok := config.AuthParams["datadogMetricsService"]; ok && val != "" {
+		service = val
+	}
+
+	port := 8443
+	if val, ok := config.AuthParams["datadogMetricsServicePort"]; ok && val != "" {
+		servicePort, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("datadogMetricsServicePort parsing error %w", err)
+		}
+		port = servicePort
+	}
+
+	if val, ok := config.TriggerMetadata["unsafeSsl"]; ok && val != "" {
+		unsafeSsl, err := strconv.ParseBool(val)
+		if err != nil {
+			return fmt.Errorf("unsafeSsl parsing error %w", err)
+		}
+		meta.unsafeSsl = unsafeSsl
+	}
+
+	meta.clusterAgentToken = config.AuthParams["token"]
+
+	// the Cluster Agent exposes every DatadogMetric as an external metric named datadogmetric@<namespace>:<name>
+	externalMetricName := fmt.Sprintf("datadogmetric@%s:%s", metricNamespace, metricName)
+	meta.clusterAgentURL = fmt.Sprintf("https://%s.%s:%d/apis/external.metrics.k8s.io/v1beta1/namespaces/%s/%s",
+		service, namespace, port, metricNamespace, url.PathEscape(externalMetricName))
+
+	meta.metricName = GenerateMetricNameWithIndex(config.TriggerIndex, kedautil.NormalizeString(fmt.Sprintf("datadog-%s", metricName)))
+
+	return nil
+}
+
+// splitDatadogQueries splits a comma separated list of queries, ignoring the commas of the query scopes and functions
+func splitDatadogQueries(q string) []string {
+	var queries []string
+	depth := 0
+	start := 0
+	for i, c := range q {
+		switch c {
+		case '{', '(':
+			depth++
+		case '}', ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				queries = append(queries, strings.TrimSpace(q[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(queries, strings.TrimSpace(q[start:]))
 }
 
 // newDatadogConnection tests a connection to the Datadog API
@@ -267,6 +407,9 @@ func (s *datadogScaler) Close(context.Context) error {
 	if s.apiClient != nil {
 		s.apiClient.GetConfig().HTTPClient.CloseIdleConnections()
 	}
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
 	return nil
 }
 
@@ -338,13 +481,28 @@ func (s *datadogScaler) getQueryResult(ctx context.Context) (float64, error) {
 	}
 
 	// Collect all latest point values from any/all series
-	results := make([]float64, len(series))
+	values := make([][]*float64, len(series))
 	for i := 0; i < len(series); i++ {
 		points := series[i].GetPointlist()
-		index := len(points) - 1
+		values[i] = make([]*float64, len(points))
+		for j, point := range points {
+			if len(point) >= 2 {
+				values[i][j] = point[1]
+			}
+		}
+	}
+
+	return s.aggregateSeries(values)
+}
+
+// aggregateSeries picks the last available point of every series, and aggregates them with the queryAggregator
+func (s *datadogScaler) aggregateSeries(series [][]*float64) (float64, error) {
+	results := make([]float64, len(series))
+	for i, values := range series {
+		index := len(values) - 1
 		// Find out the last point != nil
 		for j := index; j >= 0; j-- {
-			if len(points[j]) >= 2 && points[j][1] != nil {
+			if values[j] != nil {
 				index = j
 				break
 			}
@@ -354,23 +512,171 @@ func (s *datadogScaler) getQueryResult(ctx context.Context) (float64, error) {
 		}
 		index -= s.metadata.lastAvailablePointOffset
 
-		if len(points) == 0 || len(points[index]) < 2 || points[index][1] == nil {
+		if len(values) == 0 || values[index] == nil {
 			if !s.metadata.useFiller {
 				return 0, fmt.Errorf("no Datadog metrics returned for the given time window")
 			}
 			return s.metadata.fillValue, nil
 		}
 		// Return the last point from the series
-		results[i] = *points[index][1]
+		results[i] = *values[index]
 	}
 
+	return s.aggregateResults(results), nil
+}
+
+func (s *datadogScaler) aggregateResults(results []float64) float64 {
 	switch s.metadata.queryAggegrator {
 	case avgString:
-		return AvgFloatFromSlice(results), nil
+		return AvgFloatFromSlice(results)
 	default:
 		// Aggregate Results - default Max value:
-		return MaxFloatFromSlice(results), nil
+		return MaxFloatFromSlice(results)
+	}
+}
+
+type datadogTimeseriesQuery struct {
+	DataSource string `json:"data_source"`
+	Query      string `json:"query"`
+	Name       string `json:"name"`
+}
+
+type datadogTimeseriesFormula struct {
+	Formula string `json:"formula"`
+}
+
+type datadogTimeseriesRequest struct {
+	Data struct {
+		Type       string `json:"type"`
+		Attributes struct {
+			From     int64                      `json:"from"`
+			To       int64                      `json:"to"`
+			Queries  []datadogTimeseriesQuery   `json:"queries"`
+			Formulas []datadogTimeseriesFormula `json:"formulas"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+type datadogTimeseriesResponse struct {
+	Data struct {
+		Attributes struct {
+			Values [][]*float64 `json:"values"`
+		} `json:"attributes"`
+	} `json:"data"`
+	Errors string `json:"errors"`
+}
+
+// getFormulaResult returns the result of the formula evaluated on the queries by the Datadog v2 timeseries API
+func (s *datadogScaler) getFormulaResult(ctx context.Context) (float64, error) {
+	timeWindowTo := time.Now().Unix() - int64(s.metadata.timeWindowOffset)
+	timeWindowFrom := timeWindowTo - int64(s.metadata.age)
+
+	request := datadogTimeseriesRequest{}
+	request.Data.Type = "timeseries_request"
+	request.Data.Attributes.From = timeWindowFrom * 1000
+	request.Data.Attributes.To = timeWindowTo * 1000
+	for i, query := range s.metadata.queries {
+		request.Data.Attributes.Queries = append(request.Data.Attributes.Queries, datadogTimeseriesQuery{
+			DataSource: "metrics",
+			Query:      query,
+			Name:       string(datadogQueryNames[i]),
+		})
+	}
+	request.Data.Attributes.Formulas = []datadogTimeseriesFormula{{Formula: s.metadata.formula}}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return -1, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.metadata.timeseriesURL, bytes.NewReader(body))
+	if err != nil {
+		return -1, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("DD-API-KEY", s.metadata.apiKey)
+	req.Header.Set("DD-APPLICATION-KEY", s.metadata.appKey)
+
+	r, err := s.httpClient.Do(req)
+	if err != nil {
+		return -1, fmt.Errorf("error when retrieving Datadog metrics: %w", err)
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode == http.StatusTooManyRequests {
+		rateLimit := r.Header.Get("X-Ratelimit-Limit")
+		rateLimitReset := r.Header.Get("X-Ratelimit-Reset")
+		rateLimitPeriod := r.Header.Get("X-Ratelimit-Period")
+
+		return -1, fmt.Errorf("your Datadog account reached the %s queries per %s seconds rate limit, next limit reset will happen in %s seconds", rateLimit, rateLimitPeriod, rateLimitReset)
+	}
+	if r.StatusCode != http.StatusOK {
+		return -1, fmt.Errorf("error when retrieving Datadog metrics: unexpected status code %d", r.StatusCode)
+	}
+
+	resp := datadogTimeseriesResponse{}
+	if err := json.NewDecoder(r.Body).Decode(&resp); err != nil {
+		return -1, fmt.Errorf("error decoding Datadog response: %w", err)
+	}
+	if resp.Errors != "" {
+		return -1, fmt.Errorf("error when retrieving Datadog metrics: %s", resp.Errors)
 	}
+
+	series := resp.Data.Attributes.Values
+	if len(series) == 0 {
+		if !s.metadata.useFiller {
+			return 0, fmt.Errorf("no Datadog metrics returned for the given time window")
+		}
+		return s.metadata.fillValue, nil
+	}
+
+	// Require queryAggregator be set explicitly for grouped formulas
+	if len(series) > 1 && s.metadata.queryAggegrator == "" {
+		return 0, fmt.Errorf("formula returned more than 1 series; modify the queries to return only 1 series or add a queryAggregator")
+	}
+
+	return s.aggregateSeries(series)
+}
+
+// getClusterAgentResult returns the value of the DatadogMetric served by the Datadog Cluster Agent,
+// which queries the Datadog API once for all the consumers of the metric
+func (s *datadogScaler) getClusterAgentResult(ctx context.Context) (float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadata.clusterAgentURL, nil)
+	if err != nil {
+		return -1, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if s.metadata.clusterAgentToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.metadata.clusterAgentToken)
+	}
+
+	r, err := s.httpClient.Do(req)
+	if err != nil {
+		return -1, fmt.Errorf("error when retrieving metrics from the Datadog Cluster Agent: %w", err)
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(r.Body)
+		return -1, fmt.Errorf("error when retrieving metrics from the Datadog Cluster Agent: unexpected status code %d: %s", r.StatusCode, string(body))
+	}
+
+	metrics := external_metrics.ExternalMetricValueList{}
+	if err := json.NewDecoder(r.Body).Decode(&metrics); err != nil {
+		return -1, fmt.Errorf("error decoding Datadog Cluster Agent response: %w", err)
+	}
+
+	if len(metrics.Items) == 0 {
+		if !s.metadata.useFiller {
+			return 0, fmt.Errorf("no metrics returned by the Datadog Cluster Agent")
+		}
+		return s.metadata.fillValue, nil
+	}
+
+	results := make([]float64, len(metrics.Items))
+	for i, item := range metrics.Items {
+		results[i] = item.Value.AsApproximateFloat64()
+	}
+	return s.aggregateResults(results), nil
 }
 
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
@@ -389,7 +695,16 @@ func (s *datadogScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec
 
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
 func (s *datadogScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	num, err := s.getQueryResult(ctx)
+	var num float64
+	var err error
+	switch {
+	case s.metadata.useClusterAgentProxy:
+		num, err = s.getClusterAgentResult(ctx)
+	case s.metadata.formula != "":
+		num, err = s.getFormulaResult(ctx)
+	default:
+		num, err = s.getQueryResult(ctx)
+	}
 	if err != nil {
 		s.logger.Error(err, "error getting metrics from Datadog")
 		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting metrics from Datadog: %w", err)
@@ -2,9 +2,14 @@ package scalers
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v2 "k8s.io/api/autoscaling/v2"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
@@ -133,6 +138,24 @@ var testDatadogMetadata = []datadogAuthMetadataTestData{
 	{"", map[string]string{"query": "sum:trace.redis.command.hits{env:none,service:redis}.as_count()", "queryValue": "7"}, map[string]string{"apiKey": "apiKey"}, true},
 	// invalid query missing {
 	{"", map[string]string{"query": "sum:trace.redis.command.hits.as_count()", "queryValue": "7"}, map[string]string{}, true},
+	// formula properly formed
+	{"", map[string]string{"query": "sum:http.errors{env:prod,service:api}.as_count(),sum:http.requests{env:prod,service:api}.as_count()", "formula": "a / b", "queryValue": "0.1"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, false},
+	// formula with a malformed query
+	{"", map[string]string{"query": "sum:http.errors{env:prod},sum:http.requests", "formula": "a / b", "queryValue": "0.1"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, true},
+	// cluster agent properly formed
+	{"", map[string]string{"useClusterAgentProxy": "true", "datadogMetricName": "nginx-hits", "datadogMetricNamespace": "default", "queryValue": "7"}, map[string]string{"datadogNamespace": "datadog", "token": "token"}, false},
+	// cluster agent with custom service and port
+	{"", map[string]string{"useClusterAgentProxy": "true", "datadogMetricName": "nginx-hits", "datadogMetricNamespace": "default", "queryValue": "7", "unsafeSsl": "true"}, map[string]string{"datadogNamespace": "datadog", "datadogMetricsService": "metrics", "datadogMetricsServicePort": "443"}, false},
+	// cluster agent missing datadogMetricName
+	{"", map[string]string{"useClusterAgentProxy": "true", "datadogMetricNamespace": "default", "queryValue": "7"}, map[string]string{"datadogNamespace": "datadog"}, true},
+	// cluster agent missing datadogNamespace
+	{"", map[string]string{"useClusterAgentProxy": "true", "datadogMetricName": "nginx-hits", "datadogMetricNamespace": "default", "queryValue": "7"}, map[string]string{}, true},
+	// cluster agent with a query
+	{"", map[string]string{"useClusterAgentProxy": "true", "query": "sum:trace.redis.command.hits{env:none,service:redis}.as_count()", "datadogMetricName": "nginx-hits", "datadogMetricNamespace": "default", "queryValue": "7"}, map[string]string{"datadogNamespace": "datadog"}, true},
+	// wrong useClusterAgentProxy
+	{"", map[string]string{"useClusterAgentProxy": "sometimes", "datadogMetricName": "nginx-hits", "datadogMetricNamespace": "default", "queryValue": "7"}, map[string]string{"datadogNamespace": "datadog"}, true},
+	// wrong datadogMetricsServicePort
+	{"", map[string]string{"useClusterAgentProxy": "true", "datadogMetricName": "nginx-hits", "datadogMetricNamespace": "default", "queryValue": "7"}, map[string]string{"datadogNamespace": "datadog", "datadogMetricsServicePort": "http"}, true},
 }
 
 func TestDatadogScalerAuthParams(t *testing.T) {
@@ -151,6 +174,7 @@ func TestDatadogScalerAuthParams(t *testing.T) {
 var datadogMetricIdentifiers = []datadogMetricIdentifier{
 	{&testDatadogMetadata[1], 0, "s0-datadog-sum-trace-redis-command-hits"},
 	{&testDatadogMetadata[1], 1, "s1-datadog-sum-trace-redis-command-hits"},
+	{&testDatadogMetadata[23], 0, "s0-datadog-nginx-hits"},
 }
 
 func TestDatadogGetMetricSpecForScaling(t *testing.T) {
@@ -171,3 +195,60 @@ func TestDatadogGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestSplitDatadogQueries(t *testing.T) {
+	queries := splitDatadogQueries("sum:http.errors{env:prod,service:api}.as_count(), avg:system.load.1{host:a,role:db}")
+	assert.Equal(t, []string{"sum:http.errors{env:prod,service:api}.as_count()", "avg:system.load.1{host:a,role:db}"}, queries)
+}
+
+func TestDatadogGetFormulaResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, http.MethodPost, request.Method)
+		assert.Equal(t, "apiKey", request.Header.Get("DD-API-KEY"))
+		assert.Equal(t, "appKey", request.Header.Get("DD-APPLICATION-KEY"))
+
+		body := datadogTimeseriesRequest{}
+		require.NoError(t, json.NewDecoder(request.Body).Decode(&body))
+		queries := body.Data.Attributes.Queries
+		require.Len(t, queries, 2)
+		assert.Equal(t, "a", queries[0].Name)
+		assert.Equal(t, "sum:http.errors{env:prod,service:api}.as_count()", queries[0].Query)
+		assert.Equal(t, "b", queries[1].Name)
+		assert.Equal(t, "a / b", body.Data.Attributes.Formulas[0].Formula)
+
+		_, err := writer.Write([]byte(`{"data":{"type":"timeseries_response","attributes":{"times":[1,2,3],"values":[[0.2,0.4,null]]}}}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	meta, err := parseDatadogMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testDatadogMetadata[21].metadata, AuthParams: testDatadogMetadata[21].authParams}, logr.Discard())
+	require.NoError(t, err)
+	meta.timeseriesURL = server.URL
+
+	scaler := datadogScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+	metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "s0-datadog-sum-http-errors")
+	require.NoError(t, err)
+	assert.Equal(t, int64(400), metrics[0].Value.MilliValue())
+	assert.True(t, active)
+}
+
+func TestDatadogGetClusterAgentResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "/apis/external.metrics.k8s.io/v1beta1/namespaces/default/datadogmetric@default:nginx-hits", request.URL.Path)
+		assert.Equal(t, "Bearer token", request.Header.Get("Authorization"))
+
+		_, err := writer.Write([]byte(`{"kind":"ExternalMetricValueList","items":[{"metricName":"datadogmetric@default:nginx-hits","value":"2500m"},{"metricName":"datadogmetric@default:nginx-hits","value":"3"}]}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	meta, err := parseDatadogMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testDatadogMetadata[23].metadata, AuthParams: testDatadogMetadata[23].authParams}, logr.Discard())
+	require.NoError(t, err)
+	meta.clusterAgentURL = server.URL + "/apis/external.metrics.k8s.io/v1beta1/namespaces/default/datadogmetric@default:nginx-hits"
+
+	scaler := datadogScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+	metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "s0-datadog-nginx-hits")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3000), metrics[0].Value.MilliValue())
+	assert.True(t, active)
+}
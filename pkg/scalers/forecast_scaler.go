@@ -0,0 +1,288 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/xhit/go-str2duration/v2"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/metricscollector"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	forecastDay  = 24 * time.Hour
+	forecastWeek = 7 * forecastDay
+)
+
+var errForecastNotEnoughSamples = errors.New("not enough samples to fit the forecast model")
+
+// forecastScaler forecasts the value of a Prometheus query with a model fitted inside the operator,
+// the history of the query is pulled with a range query and the fitted model is cached between polls
+type forecastScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *forecastMetadata
+	prometheus *prometheusScaler
+	logger     logr.Logger
+
+	scalableObjectName      string
+	scalableObjectNamespace string
+	isScaledObject          bool
+	triggerName             string
+
+	mutex    sync.Mutex
+	model    *forecastModel
+	fittedAt time.Time
+}
+
+type forecastMetadata struct {
+	triggerIndex int
+
+	PredictHorizon       string `keda:"name=predictHorizon,       order=triggerMetadata"`
+	HistoryTimeWindow    string `keda:"name=historyTimeWindow,    order=triggerMetadata, default=14d"`
+	QueryStep            string `keda:"name=queryStep,            order=triggerMetadata, default=5m"`
+	ModelRefreshInterval string `keda:"name=modelRefreshInterval, order=triggerMetadata, default=1h"`
+	DailySeasonality     bool   `keda:"name=dailySeasonality,     order=triggerMetadata, default=true"`
+	WeeklySeasonality    bool   `keda:"name=weeklySeasonality,    order=triggerMetadata, default=true"`
+
+	predictHorizon       time.Duration
+	historyTimeWindow    time.Duration
+	queryStep            time.Duration
+	modelRefreshInterval time.Duration
+}
+
+// NewForecastScaler creates a new forecastScaler
+func NewForecastScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseForecastMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing forecast metadata: %w", err)
+	}
+
+	prometheus, err := newPrometheusScaler(config)
+	if err != nil {
+		return nil, err
+	}
+
+	triggerName := config.TriggerName
+	if triggerName == "" {
+		triggerName = "forecastScaler"
+	}
+
+	return &forecastScaler{
+		metricType:              metricType,
+		metadata:                meta,
+		prometheus:              prometheus,
+		logger:                  InitializeLogger(config, "forecast_scaler"),
+		scalableObjectName:      config.ScalableObjectName,
+		scalableObjectNamespace: config.ScalableObjectNamespace,
+		isScaledObject:          config.ScalableObjectType == "ScaledObject",
+		triggerName:             triggerName,
+	}, nil
+}
+
+func parseForecastMetadata(config *scalersconfig.ScalerConfig) (*forecastMetadata, error) {
+	meta := &forecastMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	meta.triggerIndex = config.TriggerIndex
+
+	durations := []struct {
+		name   string
+		value  string
+		target *time.Duration
+	}{
+		{"predictHorizon", meta.PredictHorizon, &meta.predictHorizon},
+		{"historyTimeWindow", meta.HistoryTimeWindow, &meta.historyTimeWindow},
+		{"queryStep", meta.QueryStep, &meta.queryStep},
+		{"modelRefreshInterval", meta.ModelRefreshInterval, &meta.modelRefreshInterval},
+	}
+	for _, duration := range durations {
+		value, err := str2duration.ParseDuration(duration.value)
+		if err != nil {
+			return nil, fmt.Errorf("%s parsing error %w", duration.name, err)
+		}
+		if value < 0 {
+			return nil, fmt.Errorf("%s must not be negative", duration.name)
+		}
+		*duration.target = value
+	}
+
+	if meta.queryStep < time.Second {
+		return nil, fmt.Errorf("queryStep must be at least 1s")
+	}
+	if meta.historyTimeWindow < 2*meta.queryStep {
+		return nil, fmt.Errorf("historyTimeWindow must cover at least two queryStep")
+	}
+	return meta, nil
+}
+
+func (s *forecastScaler) Close(ctx context.Context) error {
+	return s.prometheus.Close(ctx)
+}
+
+func (s *forecastScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString("forecast")
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.prometheus.metadata.Threshold),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *forecastScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	now := time.Now().UTC()
+	model, err := s.getModel(ctx, now)
+	if err != nil {
+		s.logger.Error(err, "error fitting the forecast model")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	observed, err := s.prometheus.ExecutePromQuery(ctx)
+	if err != nil {
+		s.logger.Error(err, "error executing prometheus query")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	forecastError := math.Abs(model.predict(now) - observed)
+	metricscollector.RecordScalerForecastError(s.scalableObjectNamespace, s.scalableObjectName, s.triggerName, s.metadata.triggerIndex, metricName, s.isScaledObject, forecastError)
+
+	predicted := model.predict(now.Add(s.metadata.predictHorizon))
+	s.logger.V(1).Info("Forecasted value", "predicted", predicted, "observed", observed, "forecastError", forecastError)
+
+	metric := GenerateMetricInMili(metricName, predicted)
+
+	// the scaler stays active while the observed value is above the activation threshold,
+	// even if a decrease is forecasted
+	return []external_metrics.ExternalMetricValue{metric}, math.Max(predicted, observed) > s.prometheus.metadata.ActivationThreshold, nil
+}
+
+// getModel returns the cached model, or fits it again on the query history once it's older than the refresh interval
+func (s *forecastScaler) getModel(ctx context.Context, now time.Time) (*forecastModel, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.model != nil && now.Sub(s.fittedAt) < s.metadata.modelRefreshInterval {
+		return s.model, nil
+	}
+
+	samples, err := s.prometheus.ExecutePromRangeQuery(ctx, now.Add(-s.metadata.historyTimeWindow), now, s.metadata.queryStep)
+	if err != nil {
+		return nil, err
+	}
+
+	model, err := fitForecastModel(samples, s.metadata.queryStep, s.metadata.DailySeasonality, s.metadata.WeeklySeasonality)
+	if err != nil {
+		return nil, err
+	}
+	s.model = model
+	s.fittedAt = now
+	return model, nil
+}
+
+// forecastModel is a linear trend with optional daily and weekly seasonal profiles. The trend is fitted by least
+// squares, then each seasonal profile is the mean of the remaining residuals for every step of the season.
+type forecastModel struct {
+	origin    time.Time
+	intercept float64
+	// slope is the trend per second
+	slope  float64
+	step   time.Duration
+	daily  []float64
+	weekly []float64
+}
+
+func fitForecastModel(samples []promSample, step time.Duration, daily bool, weekly bool) (*forecastModel, error) {
+	if len(samples) < 2 {
+		return nil, errForecastNotEnoughSamples
+	}
+
+	model := &forecastModel{origin: samples[0].Timestamp, step: step}
+
+	n := float64(len(samples))
+	var sumX, sumY, sumXX, sumXY float64
+	for _, sample := range samples {
+		x := sample.Timestamp.Sub(model.origin).Seconds()
+		sumX += x
+		sumY += sample.Value
+		sumXX += x * x
+		sumXY += x * sample.Value
+	}
+	if variance := n*sumXX - sumX*sumX; variance != 0 {
+		model.slope = (n*sumXY - sumX*sumY) / variance
+	}
+	model.intercept = (sumY - model.slope*sumX) / n
+
+	residuals := make([]float64, len(samples))
+	for i, sample := range samples {
+		residuals[i] = sample.Value - model.trend(sample.Timestamp)
+	}
+
+	// a seasonal profile is only fitted when the history covers the season twice,
+	// otherwise it would reproduce the noise of a single season
+	span := samples[len(samples)-1].Timestamp.Sub(samples[0].Timestamp)
+	if daily && span >= 2*forecastDay {
+		model.daily = fitSeasonalProfile(samples, residuals, forecastDay, step)
+		for i, sample := range samples {
+			residuals[i] -= seasonalValue(model.daily, sample.Timestamp, forecastDay, step)
+		}
+	}
+	if weekly && span >= 2*forecastWeek {
+		model.weekly = fitSeasonalProfile(samples, residuals, forecastWeek, step)
+	}
+	return model, nil
+}
+
+func fitSeasonalProfile(samples []promSample, residuals []float64, season time.Duration, step time.Duration) []float64 {
+	slots := int((season + step - 1) / step)
+	sums := make([]float64, slots)
+	counts := make([]int, slots)
+	for i, sample := range samples {
+		slot := seasonalSlot(sample.Timestamp, season, step)
+		sums[slot] += residuals[i]
+		counts[slot]++
+	}
+	for slot := range sums {
+		if counts[slot] > 0 {
+			sums[slot] /= float64(counts[slot])
+		}
+	}
+	return sums
+}
+
+func seasonalSlot(t time.Time, season time.Duration, step time.Duration) int {
+	return int(time.Duration(t.UnixNano()%int64(season)) / step)
+}
+
+func seasonalValue(profile []float64, t time.Time, season time.Duration, step time.Duration) float64 {
+	if profile == nil {
+		return 0
+	}
+	return profile[seasonalSlot(t, season, step)]
+}
+
+func (m *forecastModel) trend(t time.Time) float64 {
+	return m.intercept + m.slope*t.Sub(m.origin).Seconds()
+}
+
+func (m *forecastModel) predict(t time.Time) float64 {
+	return m.trend(t) + seasonalValue(m.daily, t, forecastDay, m.step) + seasonalValue(m.weekly, t, forecastWeek, m.step)
+}
@@ -0,0 +1,182 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseForecastMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+}
+
+type forecastMetricIdentifier struct {
+	metadataTestData *parseForecastMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testForecastMetadata = []parseForecastMetadataTestData{
+	{map[string]string{}, true},
+	// all properly formed
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "predictHorizon": "10m"}, false},
+	// all properly formed, with history and model options
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "predictHorizon": "1h", "historyTimeWindow": "7d", "queryStep": "1m", "modelRefreshInterval": "30m", "weeklySeasonality": "false"}, false},
+	// missing predictHorizon
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up"}, true},
+	// missing prometheus query
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "predictHorizon": "10m"}, true},
+	// invalid predictHorizon
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "predictHorizon": "soon"}, true},
+	// negative predictHorizon
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "predictHorizon": "-10m"}, true},
+	// queryStep shorter than a second
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "predictHorizon": "10m", "queryStep": "10ms"}, true},
+	// historyTimeWindow shorter than two queryStep
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "predictHorizon": "10m", "historyTimeWindow": "5m"}, true},
+	// invalid dailySeasonality
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "predictHorizon": "10m", "dailySeasonality": "sometimes"}, true},
+}
+
+var forecastMetricIdentifiers = []forecastMetricIdentifier{
+	{&testForecastMetadata[1], 0, "s0-forecast"},
+	{&testForecastMetadata[1], 1, "s1-forecast"},
+}
+
+func TestForecastParseMetadata(t *testing.T) {
+	for _, testData := range testForecastMetadata {
+		_, err := NewForecastScaler(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for %v", testData.metadata)
+		}
+	}
+}
+
+func TestForecastGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range forecastMetricIdentifiers {
+		scaler, err := NewForecastScaler(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+
+		metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestFitForecastModelLinearTrend(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	samples := make([]promSample, 0, 60)
+	for i := 0; i < 60; i++ {
+		samples = append(samples, promSample{Timestamp: start.Add(time.Duration(i) * time.Minute), Value: 10 + 2*float64(i)})
+	}
+
+	model, err := fitForecastModel(samples, time.Minute, true, true)
+	require.NoError(t, err)
+	assert.Nil(t, model.daily)
+	assert.Nil(t, model.weekly)
+	assert.InDelta(t, 10+2*89, model.predict(start.Add(89*time.Minute)), 1e-6)
+}
+
+func TestFitForecastModelDailySeasonality(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	step := time.Hour
+	samples := make([]promSample, 0, 72)
+	for i := 0; i < 72; i++ {
+		timestamp := start.Add(time.Duration(i) * step)
+		// load peaks from 09:00 to 17:00 every day
+		value := 10.0
+		if hour := timestamp.Hour(); hour >= 9 && hour < 17 {
+			value = 100
+		}
+		samples = append(samples, promSample{Timestamp: timestamp, Value: value})
+	}
+
+	model, err := fitForecastModel(samples, step, true, true)
+	require.NoError(t, err)
+	assert.NotNil(t, model.daily)
+	assert.Nil(t, model.weekly)
+	assert.InDelta(t, 100, model.predict(start.Add(3*forecastDay+10*time.Hour)), 5)
+	assert.InDelta(t, 10, model.predict(start.Add(3*forecastDay+2*time.Hour)), 5)
+
+	model, err = fitForecastModel(samples, step, false, false)
+	require.NoError(t, err)
+	assert.Nil(t, model.daily)
+	assert.InDelta(t, 40, model.predict(start.Add(3*forecastDay+10*time.Hour)), 5)
+}
+
+func TestFitForecastModelNotEnoughSamples(t *testing.T) {
+	_, err := fitForecastModel([]promSample{{Timestamp: time.Now(), Value: 1}}, time.Minute, true, true)
+	assert.ErrorIs(t, err, errForecastNotEnoughSamples)
+}
+
+func TestForecastGetMetricsAndActivity(t *testing.T) {
+	now := time.Now().UTC()
+	rangeQueries := 0
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "up", request.URL.Query().Get("query"))
+		var body string
+		switch request.URL.Path {
+		case "/api/v1/query_range":
+			rangeQueries++
+			assert.Equal(t, "60", request.URL.Query().Get("step"))
+			// the value grows by 1 every minute over the last hour
+			values := make([]string, 0, 61)
+			for i := 60; i >= 0; i-- {
+				timestamp := now.Add(-time.Duration(i) * time.Minute)
+				values = append(values, fmt.Sprintf(`[%d,"%d"]`, timestamp.Unix(), 100-i))
+			}
+			body = fmt.Sprintf(`{"data":{"result":[{"values":[%s]}]}}`, strings.Join(values, ","))
+		case "/api/v1/query":
+			body = fmt.Sprintf(`{"data":{"result":[{"value":[%d,"100"]}]}}`, now.Unix())
+		default:
+			t.Errorf("Unexpected path %s", request.URL.Path)
+		}
+		if _, err := writer.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	meta, err := parseForecastMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{"predictHorizon": "30m", "historyTimeWindow": "1h", "queryStep": "1m"}})
+	require.NoError(t, err)
+	scaler := forecastScaler{
+		metadata: meta,
+		prometheus: &prometheusScaler{
+			metadata:   &prometheusMetadata{ServerAddress: server.URL, Query: "up", ActivationThreshold: 200},
+			httpClient: http.DefaultClient,
+			logger:     logr.Discard(),
+		},
+		logger: logr.Discard(),
+	}
+
+	metrics, active, err := scaler.GetMetricsAndActivity(context.TODO(), "s0-forecast")
+	require.NoError(t, err)
+	predicted, _ := strconv.ParseFloat(metrics[0].Value.AsDec().String(), 64)
+	assert.Less(t, math.Abs(predicted-130), 1.0)
+	assert.False(t, active)
+
+	// the fitted model is reused until the refresh interval elapses
+	_, _, err = scaler.GetMetricsAndActivity(context.TODO(), "s0-forecast")
+	require.NoError(t, err)
+	assert.Equal(t, 1, rangeQueries)
+}
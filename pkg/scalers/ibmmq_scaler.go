@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -24,6 +25,13 @@ import (
 const (
 	defaultTargetQueueDepth = 20
 	defaultTLSDisabled      = false
+
+	ibmmqInputHandlesMetricSuffix = "input-handles"
+	ibmmqMessageAgeMetricSuffix   = "oldest-message-age"
+
+	// ibmmqQueueParametersReuse is how long the parameters of the queues are reused, so the metrics requested
+	// one after another in a poll are read from a single query of the queues
+	ibmmqQueueParametersReuse = 5 * time.Second
 )
 
 // IBMMQScaler assigns struct data pointer to metadata variable
@@ -31,7 +39,12 @@ type IBMMQScaler struct {
 	metricType         v2.MetricTargetType
 	metadata           *IBMMQMetadata
 	defaultHTTPTimeout time.Duration
+	httpClient         *http.Client
 	logger             logr.Logger
+
+	queueParametersLock sync.Mutex
+	queueParameters     []*Parameters
+	queueParametersTime time.Time
 }
 
 // IBMMQMetadata Metadata used by KEDA to query IBM MQ queue depth and scale
@@ -39,11 +52,19 @@ type IBMMQMetadata struct {
 	host                 string
 	queueManager         string
 	queueName            string
+	queueNames           []string
+	operation            string
 	username             string
 	password             string
 	queueDepth           int64
 	activationQueueDepth int64
+	inputHandleCount     int64
+	oldestMessageAge     int64
 	tlsDisabled          bool
+	ca                   string
+	cert                 string
+	key                  string
+	keyPassword          string
 	triggerIndex         int
 }
 
@@ -58,9 +79,37 @@ type Response struct {
 	Message    []string    `json:"message"`
 }
 
-// Parameters Contains the current depth of the IBM MQ Queue
+// Parameters Contains the current depth, the count of input handles and the age of the oldest message of the IBM MQ Queue
 type Parameters struct {
-	Curdepth int `json:"curdepth"`
+	Curdepth int              `json:"curdepth"`
+	Ipprocs  int              `json:"ipprocs"`
+	Msgage   ibmmqOptionalInt `json:"msgage"`
+}
+
+// ibmmqOptionalInt is an integer parameter which MQ returns as an empty string when it isn't available,
+// e.g. MSGAGE when the queue monitoring is disabled
+type ibmmqOptionalInt int64
+
+func (i *ibmmqOptionalInt) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case float64:
+		*i = ibmmqOptionalInt(v)
+	case string:
+		if v == "" {
+			*i = 0
+			return nil
+		}
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return err
+		}
+		*i = ibmmqOptionalInt(parsed)
+	}
+	return nil
 }
 
 // NewIBMMQScaler creates a new IBM MQ scaler
@@ -75,16 +124,29 @@ func NewIBMMQScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing IBM MQ metadata: %w", err)
 	}
 
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.tlsDisabled)
+	if meta.cert != "" || meta.ca != "" {
+		tlsConfig, err := kedautil.NewTLSConfigWithPassword(meta.cert, meta.key, meta.keyPassword, meta.ca, meta.tlsDisabled)
+		if err != nil {
+			return nil, fmt.Errorf("error creating IBM MQ TLS config: %w", err)
+		}
+		httpClient.Transport = kedautil.CreateHTTPTransportWithTLSConfig(tlsConfig)
+	}
+
 	return &IBMMQScaler{
 		metricType:         metricType,
 		metadata:           meta,
 		defaultHTTPTimeout: config.GlobalHTTPTimeout,
+		httpClient:         httpClient,
 		logger:             InitializeLogger(config, "ibm_mq_scaler"),
 	}, nil
 }
 
 // Close closes and returns nil
 func (s *IBMMQScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
 	return nil
 }
 
@@ -108,12 +170,29 @@ func parseIBMMQMetadata(config *scalersconfig.ScalerConfig) (*IBMMQMetadata, err
 		return nil, fmt.Errorf("no queue manager given")
 	}
 
+	// queueName is a comma separated list of queue names, each one can be a generic name ending with '*'
 	if val, ok := config.TriggerMetadata["queueName"]; ok {
 		meta.queueName = val
-	} else {
+		for _, queueName := range strings.Split(val, ",") {
+			if queueName = strings.TrimSpace(queueName); queueName != "" {
+				meta.queueNames = append(meta.queueNames, queueName)
+			}
+		}
+	}
+	if len(meta.queueNames) == 0 {
 		return nil, fmt.Errorf("no queue name given")
 	}
 
+	meta.operation = sumOperation
+	if val, ok := config.TriggerMetadata["operation"]; ok && val != "" {
+		meta.operation = val
+	}
+	switch meta.operation {
+	case avgOperation, maxOperation, sumOperation:
+	default:
+		return nil, fmt.Errorf("operation must be one of avg, max, or sum")
+	}
+
 	if val, ok := config.TriggerMetadata["queueDepth"]; ok && val != "" {
 		queueDepth, err := strconv.ParseInt(val, 10, 64)
 		if err != nil {
@@ -134,6 +213,22 @@ func parseIBMMQMetadata(config *scalersconfig.ScalerConfig) (*IBMMQMetadata, err
 		meta.activationQueueDepth = activationQueueDepth
 	}
 
+	if val, ok := config.TriggerMetadata["inputHandleCount"]; ok && val != "" {
+		inputHandleCount, err := strconv.ParseInt(val, 10, 64)
+		if err != nil || inputHandleCount <= 0 {
+			return nil, fmt.Errorf("invalid inputHandleCount - must be a positive integer")
+		}
+		meta.inputHandleCount = inputHandleCount
+	}
+
+	if val, ok := config.TriggerMetadata["oldestMessageAge"]; ok && val != "" {
+		oldestMessageAge, err := strconv.ParseInt(val, 10, 64)
+		if err != nil || oldestMessageAge <= 0 {
+			return nil, fmt.Errorf("invalid oldestMessageAge - must be a positive integer")
+		}
+		meta.oldestMessageAge = oldestMessageAge
+	}
+
 	if val, ok := config.TriggerMetadata["tls"]; ok {
 		tlsDisabled, err := strconv.ParseBool(val)
 		if err != nil {
@@ -162,79 +257,202 @@ func parseIBMMQMetadata(config *scalersconfig.ScalerConfig) (*IBMMQMetadata, err
 	default:
 		return nil, fmt.Errorf("no password given")
 	}
+
+	meta.ca = config.AuthParams["ca"]
+	meta.cert = config.AuthParams["cert"]
+	meta.key = config.AuthParams["key"]
+	meta.keyPassword = config.AuthParams["keyPassword"]
+	if (meta.cert == "") != (meta.key == "") {
+		return nil, fmt.Errorf("both cert and key must be provided for mTLS")
+	}
+
 	meta.triggerIndex = config.TriggerIndex
 	return &meta, nil
 }
 
-// getQueueDepthViaHTTP returns the depth of the MQ Queue from the Admin endpoint
-func (s *IBMMQScaler) getQueueDepthViaHTTP(ctx context.Context) (int64, error) {
-	queue := s.metadata.queueName
-	url := s.metadata.host
+// getQueueParametersViaHTTP returns the parameters of every queue matching the queue names from the Admin endpoint.
+// The status of the queues is displayed when the input handle count or the oldest message age are needed, as the
+// message age is only part of the queue status.
+func (s *IBMMQScaler) getQueueParametersViaHTTP(ctx context.Context) ([]*Parameters, error) {
+	qualifier := "qlocal"
+	responseParameters := []string{"CURDEPTH"}
+	if s.metadata.inputHandleCount > 0 || s.metadata.oldestMessageAge > 0 {
+		qualifier = "qstatus"
+		responseParameters = []string{"CURDEPTH", "IPPROCS", "MSGAGE"}
+	}
 
-	var requestJSON = []byte(`{"type": "runCommandJSON", "command": "display", "qualifier": "qlocal", "name": "` + queue + `", "responseParameters" : ["CURDEPTH"]}`)
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(requestJSON))
+	var parameters []*Parameters
+	for _, queue := range s.metadata.queueNames {
+		command := map[string]any{
+			"type":               "runCommandJSON",
+			"command":            "display",
+			"qualifier":          qualifier,
+			"name":               queue,
+			"responseParameters": responseParameters,
+		}
+		response, err := s.runCommandViaHTTP(ctx, command)
+		if err != nil {
+			return nil, err
+		}
+		for _, commandResponse := range response.CommandResponse {
+			if commandResponse.Parameters == nil {
+				var reason string
+				message := strings.Join(commandResponse.Message, " ")
+				if message != "" {
+					reason = fmt.Sprintf(", reason: %s", message)
+				}
+				return nil, fmt.Errorf("failed to get the current queue depth parameter%s", reason)
+			}
+			parameters = append(parameters, commandResponse.Parameters)
+		}
+	}
+	return parameters, nil
+}
+
+func (s *IBMMQScaler) runCommandViaHTTP(ctx context.Context, command map[string]any) (*CommandResponse, error) {
+	requestJSON, err := json.Marshal(command)
 	if err != nil {
-		return 0, fmt.Errorf("failed to request queue depth: %w", err)
+		return nil, fmt.Errorf("failed to create the MQ command: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", s.metadata.host, bytes.NewBuffer(requestJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to request queue depth: %w", err)
 	}
 	req.Header.Set("ibm-mq-rest-csrf-token", "value")
 	req.Header.Set("Content-Type", "application/json")
 	req.SetBasicAuth(s.metadata.username, s.metadata.password)
 
-	client := kedautil.CreateHTTPClient(s.defaultHTTPTimeout, s.metadata.tlsDisabled)
-
-	resp, err := client.Do(req)
+	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return 0, fmt.Errorf("failed to contact MQ via REST: %w", err)
+		return nil, fmt.Errorf("failed to contact MQ via REST: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return 0, fmt.Errorf("failed to ready body of request: %w", err)
+		return nil, fmt.Errorf("failed to ready body of request: %w", err)
 	}
 
 	var response CommandResponse
 	err = json.Unmarshal(body, &response)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse JSON: %w", err)
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	if response.CommandResponse == nil || len(response.CommandResponse) == 0 {
-		return 0, fmt.Errorf("failed to parse response from REST call")
+	if len(response.CommandResponse) == 0 {
+		return nil, fmt.Errorf("failed to parse response from REST call")
 	}
+	return &response, nil
+}
 
-	if response.CommandResponse[0].Parameters == nil {
-		var reason string
-		message := strings.Join(response.CommandResponse[0].Message, " ")
-		if message != "" {
-			reason = fmt.Sprintf(", reason: %s", message)
-		}
-		return 0, fmt.Errorf("failed to get the current queue depth parameter%s", reason)
+// getQueueDepthViaHTTP returns the depth of the MQ Queues aggregated with the operation
+func (s *IBMMQScaler) getQueueDepthViaHTTP(ctx context.Context) (int64, error) {
+	parameters, err := s.getQueueParametersViaHTTP(ctx)
+	if err != nil {
+		return 0, err
+	}
+	depths := make([]int64, 0, len(parameters))
+	for _, p := range parameters {
+		depths = append(depths, int64(p.Curdepth))
+	}
+	return performOperation(depths, s.metadata.operation), nil
+}
+
+// getPolledQueueParameters returns the parameters of the queues, reused across the metrics of a poll
+// when the scaler has several metrics
+func (s *IBMMQScaler) getPolledQueueParameters(ctx context.Context) ([]*Parameters, error) {
+	if s.metadata.inputHandleCount <= 0 && s.metadata.oldestMessageAge <= 0 {
+		return s.getQueueParametersViaHTTP(ctx)
 	}
 
-	return int64(response.CommandResponse[0].Parameters.Curdepth), nil
+	s.queueParametersLock.Lock()
+	defer s.queueParametersLock.Unlock()
+	if s.queueParameters != nil && time.Since(s.queueParametersTime) < ibmmqQueueParametersReuse {
+		return s.queueParameters, nil
+	}
+	parameters, err := s.getQueueParametersViaHTTP(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.queueParameters, s.queueParametersTime = parameters, time.Now()
+	return parameters, nil
+}
+
+// getMetricName returns the name of a metric, a single queue is kept in the name, the metrics of
+// several queues are only named after the trigger index so the name doesn't grow with the queues
+func (s *IBMMQScaler) getMetricName(suffix string) string {
+	name := "ibmmq"
+	if len(s.metadata.queueNames) == 1 {
+		name = fmt.Sprintf("ibmmq-%s", s.metadata.queueNames[0])
+	}
+	if suffix != "" {
+		name = fmt.Sprintf("%s-%s", name, suffix)
+	}
+	return GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(strings.ReplaceAll(name, "*", "-")))
 }
 
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
 func (s *IBMMQScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("ibmmq-%s", s.metadata.queueName))),
+			Name: s.getMetricName(""),
 		},
 		Target: GetMetricTarget(s.metricType, s.metadata.queueDepth),
 	}
-	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
-	return []v2.MetricSpec{metricSpec}
+	metricSpecs := []v2.MetricSpec{{External: externalMetric, Type: externalMetricType}}
+
+	if s.metadata.inputHandleCount > 0 {
+		externalMetric := &v2.ExternalMetricSource{
+			Metric: v2.MetricIdentifier{
+				Name: s.getMetricName(ibmmqInputHandlesMetricSuffix),
+			},
+			Target: GetMetricTarget(s.metricType, s.metadata.inputHandleCount),
+		}
+		metricSpecs = append(metricSpecs, v2.MetricSpec{External: externalMetric, Type: externalMetricType})
+	}
+	if s.metadata.oldestMessageAge > 0 {
+		externalMetric := &v2.ExternalMetricSource{
+			Metric: v2.MetricIdentifier{
+				Name: s.getMetricName(ibmmqMessageAgeMetricSuffix),
+			},
+			Target: GetMetricTarget(s.metricType, s.metadata.oldestMessageAge),
+		}
+		metricSpecs = append(metricSpecs, v2.MetricSpec{External: externalMetric, Type: externalMetricType})
+	}
+	return metricSpecs
 }
 
-// GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
+// GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric.
+// The input handle count is aggregated with the operation, the oldest message age is the age of the oldest message
+// across the queues. Only the queue depth defines the activity of the scaler.
 func (s *IBMMQScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	queueDepth, err := s.getQueueDepthViaHTTP(ctx)
+	parameters, err := s.getPolledQueueParameters(ctx)
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting IBM MQ queue depth: %w", err)
 	}
 
-	metric := GenerateMetricInMili(metricName, float64(queueDepth))
+	depths := make([]int64, 0, len(parameters))
+	inputHandles := make([]int64, 0, len(parameters))
+	oldestMessageAge := int64(0)
+	for _, p := range parameters {
+		depths = append(depths, int64(p.Curdepth))
+		inputHandles = append(inputHandles, int64(p.Ipprocs))
+		oldestMessageAge = max(oldestMessageAge, int64(p.Msgage))
+	}
+	queueDepth := performOperation(depths, s.metadata.operation)
+	isActive := queueDepth > s.metadata.activationQueueDepth
+
+	var value int64
+	switch metricName {
+	case s.getMetricName(ibmmqInputHandlesMetricSuffix):
+		value = performOperation(inputHandles, s.metadata.operation)
+	case s.getMetricName(ibmmqMessageAgeMetricSuffix):
+		value = oldestMessageAge
+	default:
+		value = queueDepth
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(value))
 
-	return []external_metrics.ExternalMetricValue{metric}, queueDepth > s.metadata.activationQueueDepth, nil
+	return []external_metrics.ExternalMetricValue{metric}, isActive, nil
 }
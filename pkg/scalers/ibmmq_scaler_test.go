@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	v2 "k8s.io/api/autoscaling/v2"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
@@ -43,6 +44,7 @@ type IBMMQMetricIdentifier struct {
 var IBMMQMetricIdentifiers = []IBMMQMetricIdentifier{
 	{&testIBMMQMetadata[1], 0, "s0-ibmmq-testQueue"},
 	{&testIBMMQMetadata[1], 1, "s1-ibmmq-testQueue"},
+	{&testIBMMQMetadata[11], 0, "s0-ibmmq"},
 }
 
 // Test cases for TestIBMMQParseMetadata test
@@ -69,6 +71,20 @@ var testIBMMQMetadata = []parseIBMMQMetadataTestData{
 	{map[string]string{"host": testValidMQQueueURL, "queueManager": "testQueueManager", "queueName": "testQueue", "queueDepth": "10"}, true, map[string]string{"password": "Pass123"}},
 	// No password provided
 	{map[string]string{"host": testValidMQQueueURL, "queueManager": "testQueueManager", "queueName": "testQueue", "queueDepth": "10"}, true, map[string]string{"username": "testUsername"}},
+	// List of queues and generic queue name with an operation
+	{map[string]string{"host": testValidMQQueueURL, "queueManager": "testQueueManager", "queueName": "testQueue1, APP.*", "operation": "max"}, false, map[string]string{"username": "testUsername", "password": "Pass123"}},
+	// Empty list of queues
+	{map[string]string{"host": testValidMQQueueURL, "queueManager": "testQueueManager", "queueName": " , "}, true, map[string]string{"username": "testUsername", "password": "Pass123"}},
+	// Invalid operation
+	{map[string]string{"host": testValidMQQueueURL, "queueManager": "testQueueManager", "queueName": "testQueue", "operation": "median"}, true, map[string]string{"username": "testUsername", "password": "Pass123"}},
+	// Input handle count and oldest message age metrics
+	{map[string]string{"host": testValidMQQueueURL, "queueManager": "testQueueManager", "queueName": "testQueue", "inputHandleCount": "2", "oldestMessageAge": "60"}, false, map[string]string{"username": "testUsername", "password": "Pass123"}},
+	// Invalid input handle count
+	{map[string]string{"host": testValidMQQueueURL, "queueManager": "testQueueManager", "queueName": "testQueue", "inputHandleCount": "0"}, true, map[string]string{"username": "testUsername", "password": "Pass123"}},
+	// Invalid oldest message age
+	{map[string]string{"host": testValidMQQueueURL, "queueManager": "testQueueManager", "queueName": "testQueue", "oldestMessageAge": "AA"}, true, map[string]string{"username": "testUsername", "password": "Pass123"}},
+	// mTLS client certificate without key
+	{map[string]string{"host": testValidMQQueueURL, "queueManager": "testQueueManager", "queueName": "testQueue"}, true, map[string]string{"username": "testUsername", "password": "Pass123", "cert": "ceert"}},
 }
 
 // Test MQ Connection metadata is parsed correctly
@@ -214,8 +230,11 @@ func TestIBMMQScalerGetQueueDepthViaHTTP(t *testing.T) {
 
 			scaler := IBMMQScaler{
 				metadata: &IBMMQMetadata{
-					host: server.URL,
+					host:       server.URL,
+					queueNames: []string{"DEV.QUEUE.1"},
+					operation:  sumOperation,
 				},
+				httpClient: http.DefaultClient,
 			}
 
 			value, err := scaler.getQueueDepthViaHTTP(context.Background())
@@ -229,3 +248,100 @@ func TestIBMMQScalerGetQueueDepthViaHTTP(t *testing.T) {
 		})
 	}
 }
+
+func TestIBMMQGetMetricSpecForScalingWithQueueStatusMetrics(t *testing.T) {
+	metadata, err := parseIBMMQMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testIBMMQMetadata[14].metadata, AuthParams: testIBMMQMetadata[14].authParams})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	scaler := IBMMQScaler{metadata: metadata, metricType: v2.AverageValueMetricType}
+
+	metricSpecs := scaler.GetMetricSpecForScaling(context.Background())
+	assert.Len(t, metricSpecs, 3)
+	assert.Equal(t, "s0-ibmmq-testQueue", metricSpecs[0].External.Metric.Name)
+	assert.Equal(t, "s0-ibmmq-testQueue-input-handles", metricSpecs[1].External.Metric.Name)
+	assert.Equal(t, int64(2), metricSpecs[1].External.Target.AverageValue.Value())
+	assert.Equal(t, "s0-ibmmq-testQueue-oldest-message-age", metricSpecs[2].External.Metric.Name)
+	assert.Equal(t, int64(60), metricSpecs[2].External.Target.AverageValue.Value())
+}
+
+func TestIBMMQScalerGetMetricsAndActivityWithMultipleQueues(t *testing.T) {
+	queueStatus := map[string]string{
+		"APP.*": `[
+			{"parameters": {"queue": "APP.1", "curdepth": 4, "ipprocs": 1, "msgage": 30}},
+			{"parameters": {"queue": "APP.2", "curdepth": 8, "ipprocs": 3, "msgage": ""}}
+		]`,
+		"OTHER": `[{"parameters": {"queue": "OTHER", "curdepth": 0, "ipprocs": 2, "msgage": 120}}]`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var command map[string]any
+		if err := json.NewDecoder(request.Body).Decode(&command); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "qstatus", command["qualifier"])
+		writer.Header().Set("Content-Type", "application/json")
+		if _, err := fmt.Fprintf(writer, `{"commandResponse": %s}`, queueStatus[command["name"].(string)]); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		operation string
+		metric    string
+		expected  int64
+	}{
+		{sumOperation, "", 12},
+		{maxOperation, "", 8},
+		{avgOperation, "", 4},
+		{sumOperation, ibmmqInputHandlesMetricSuffix, 6},
+		{maxOperation, ibmmqInputHandlesMetricSuffix, 3},
+		{sumOperation, ibmmqMessageAgeMetricSuffix, 120},
+	}
+
+	for _, testData := range tests {
+		t.Run(fmt.Sprintf("%s %s", testData.operation, testData.metric), func(t *testing.T) {
+			scaler := IBMMQScaler{
+				metadata: &IBMMQMetadata{
+					host:             server.URL,
+					queueNames:       []string{"APP.*", "OTHER"},
+					operation:        testData.operation,
+					inputHandleCount: 1,
+					oldestMessageAge: 60,
+				},
+				httpClient: http.DefaultClient,
+			}
+
+			metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), scaler.getMetricName(testData.metric))
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expected, metrics[0].Value.Value())
+			assert.True(t, active)
+		})
+	}
+}
+
+func TestIBMMQScalerQueriesQueuesOncePerPoll(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+		writer.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(writer, `{"commandResponse": [{"parameters": {"curdepth": 4, "ipprocs": 1, "msgage": 30}}]}`)
+	}))
+	defer server.Close()
+
+	scaler := IBMMQScaler{
+		metadata: &IBMMQMetadata{
+			host:             server.URL,
+			queueNames:       []string{"APP.1", "APP.2"},
+			operation:        sumOperation,
+			inputHandleCount: 1,
+			oldestMessageAge: 60,
+		},
+		httpClient: http.DefaultClient,
+	}
+	for _, metricSpec := range scaler.GetMetricSpecForScaling(context.Background()) {
+		_, _, err := scaler.GetMetricsAndActivity(context.Background(), metricSpec.External.Metric.Name)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, requests)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/segmentio/kafka-go/sasl"
+)
+
+type oauthBearerMechanism struct {
+	tokenProvider TokenProvider
+}
+
+// OAuthBearerMechanism returns the kafka-go SASL/OAUTHBEARER mechanism authenticating with the tokens of the provider
+func OAuthBearerMechanism(tokenProvider TokenProvider) sasl.Mechanism {
+	return &oauthBearerMechanism{tokenProvider: tokenProvider}
+}
+
+func (m *oauthBearerMechanism) Name() string {
+	return "OAUTHBEARER"
+}
+
+// Start sends the initial client response of RFC 7628 with the token and its extensions
+func (m *oauthBearerMechanism) Start(context.Context) (sasl.StateMachine, []byte, error) {
+	token, err := m.tokenProvider.Token()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting the OAuth token: %w", err)
+	}
+
+	keys := make([]string, 0, len(token.Extensions))
+	for key := range token.Extensions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var ir strings.Builder
+	ir.WriteString("n,,\x01auth=Bearer ")
+	ir.WriteString(token.Token)
+	for _, key := range keys {
+		fmt.Fprintf(&ir, "\x01%s=%s", key, token.Extensions[key])
+	}
+	ir.WriteString("\x01\x01")
+	return m, []byte(ir.String()), nil
+}
+
+// Next completes the authentication, the broker answers a rejected token with an error challenge
+func (m *oauthBearerMechanism) Next(_ context.Context, challenge []byte) (bool, []byte, error) {
+	if len(challenge) > 0 {
+		return false, nil, fmt.Errorf("SASL/OAUTHBEARER authentication failed: %s", challenge)
+	}
+	return true, nil, nil
+}
@@ -14,8 +14,9 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// This scaler is based on sarama library.
-// It lacks support for AWS MSK. For AWS MSK please see: apache-kafka scaler.
+// This scaler serves both the kafka and the apache-kafka triggers. The cluster is queried through
+// a client backend, based on the sarama library (the default of the kafka trigger, see kafka_scaler_sarama.go)
+// or on the kafka-go library (the default of the apache-kafka trigger, see kafka_scaler_kafka_go.go).
 
 package scalers
 
@@ -24,9 +25,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
-	"sync"
 
 	"github.com/IBM/sarama"
 	"github.com/go-logr/logr"
@@ -34,7 +33,6 @@ import (
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
 	awsutils "github.com/kedacore/keda/v2/pkg/scalers/aws"
-	"github.com/kedacore/keda/v2/pkg/scalers/kafka"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
@@ -42,64 +40,160 @@ import (
 type kafkaScaler struct {
 	metricType      v2.MetricTargetType
 	metadata        kafkaMetadata
-	client          sarama.Client
-	admin           sarama.ClusterAdmin
+	client          kafkaClient
 	logger          logr.Logger
 	previousOffsets map[string]map[int32]int64
 }
 
+// kafkaClient is the client backend the kafka scaler reads the partitions and the offsets from
+type kafkaClient interface {
+	// getTopicPartitions returns the partitions of the topics, of all the topics the consumer group
+	// has committed offsets for when no topic is given
+	getTopicPartitions(ctx context.Context, topics []string, group string) (map[string][]int32, error)
+	// getConsumerOffsets returns the offsets committed by the consumer group, invalidOffset when no offset is committed
+	getConsumerOffsets(ctx context.Context, group string, topicPartitions map[string][]int32) (map[string]map[int32]int64, error)
+	// getProducerOffsets returns the latest offsets of the partitions
+	getProducerOffsets(ctx context.Context, topicPartitions map[string][]int32) (map[string]map[int32]int64, error)
+	close() error
+}
+
 const (
 	stringEnable  = "enable"
 	stringDisable = "disable"
 )
 
 type kafkaMetadata struct {
-	bootstrapServers       []string
-	group                  string
-	topic                  string
-	partitionLimitation    []int32
-	lagThreshold           int64
-	activationLagThreshold int64
-	offsetResetPolicy      offsetResetPolicy
-	allowIdleConsumers     bool
-	excludePersistentLag   bool
-	version                sarama.KafkaVersion
+	BootstrapServers       []string          `keda:"name=bootstrapServers,       order=resolvedEnv;triggerMetadata"`
+	Group                  string            `keda:"name=consumerGroup,          order=resolvedEnv;triggerMetadata"`
+	Topic                  []string          `keda:"name=topic,                  order=resolvedEnv;triggerMetadata, optional"`
+	PartitionLimitation    []int32           `keda:"name=partitionLimitation,    order=triggerMetadata, optional, range"`
+	LagThreshold           int64             `keda:"name=lagThreshold,           order=triggerMetadata, default=10"`
+	ActivationLagThreshold int64             `keda:"name=activationLagThreshold, order=triggerMetadata, default=0"`
+	OffsetResetPolicy      offsetResetPolicy `keda:"name=offsetResetPolicy,      order=triggerMetadata, enum=earliest;latest, default=latest"`
+	AllowIdleConsumers     bool              `keda:"name=allowIdleConsumers,     order=triggerMetadata, optional"`
+	ExcludePersistentLag   bool              `keda:"name=excludePersistentLag,   order=triggerMetadata, optional"`
 
 	// If an invalid offset is found, whether to scale to 1 (false - the default) so consumption can
 	// occur or scale to 0 (true). See discussion in https://github.com/kedacore/keda/issues/2612
-	scaleToZeroOnInvalidOffset bool
-	limitToPartitionsWithLag   bool
+	ScaleToZeroOnInvalidOffset bool `keda:"name=scaleToZeroOnInvalidOffset, order=triggerMetadata, optional"`
+	LimitToPartitionsWithLag   bool `keda:"name=limitToPartitionsWithLag,   order=triggerMetadata, optional"`
+
+	// Client backend, the default depends on the trigger type. The version is only used by sarama,
+	// kafka-go negotiates the versions of the requests with the brokers.
+	Client  kafkaClientType `keda:"name=client,  order=triggerMetadata, enum=sarama;kafka-go, optional"`
+	Version string          `keda:"name=version, order=triggerMetadata, default=1.0.0"`
 
 	// SASL
-	saslType kafkaSaslType
-	username string
-	password string
+	SASLType kafkaSaslType `keda:"name=sasl,     order=triggerMetadata;authParams, enum=none;plaintext;scram_sha256;scram_sha512;oauthbearer;gssapi;aws_msk_iam, default=none"`
+	Username string        `keda:"name=username, order=authParams, optional"`
+	Password string        `keda:"name=password, order=authParams, optional"`
 
 	// GSSAPI
-	keytabPath          string
-	realm               string
-	kerberosConfigPath  string
-	kerberosServiceName string
+	Keytab              string `keda:"name=keytab,              order=authParams, optional"`
+	Realm               string `keda:"name=realm,               order=authParams, optional"`
+	KerberosConfig      string `keda:"name=kerberosConfig,      order=authParams, optional"`
+	KerberosServiceName string `keda:"name=kerberosServiceName, order=authParams, optional"`
 
 	// OAUTHBEARER
-	tokenProvider         kafkaSaslOAuthTokenProvider
-	scopes                []string
-	oauthTokenEndpointURI string
-	oauthExtensions       map[string]string
+	TokenProvider         kafkaSaslOAuthTokenProvider `keda:"name=saslTokenProvider,     order=triggerMetadata;authParams, enum=bearer;aws_msk_iam, default=bearer"`
+	Scopes                []string                    `keda:"name=scopes,                order=authParams, optional"`
+	OAuthTokenEndpointURI string                      `keda:"name=oauthTokenEndpointUri, order=authParams, optional"`
+	OAuthExtensions       map[string]string           `keda:"name=oauthExtensions,       order=authParams, optional"`
 
 	// MSK
-	awsRegion        string
-	awsAuthorization awsutils.AuthorizationMetadata
+	AWSRegion        string `keda:"name=awsRegion,   order=triggerMetadata, optional"`
+	AWSEndpoint      string `keda:"name=awsEndpoint, order=triggerMetadata, optional"`
+	AWSAuthorization awsutils.AuthorizationMetadata
 
 	// TLS
-	enableTLS   bool
-	cert        string
-	key         string
-	keyPassword string
-	ca          string
-	unsafeSsl   bool
-
-	triggerIndex int
+	TLS         string `keda:"name=tls,         order=triggerMetadata;authParams, enum=enable;disable, default=disable"`
+	Cert        string `keda:"name=cert,        order=authParams, optional"`
+	Key         string `keda:"name=key,         order=authParams, optional"`
+	KeyPassword string `keda:"name=keyPassword, order=authParams, optional"`
+	CA          string `keda:"name=ca,          order=authParams, optional"`
+	UnsafeSsl   bool   `keda:"name=unsafeSsl,   order=triggerMetadata, optional"`
+
+	version            sarama.KafkaVersion
+	keytabPath         string
+	kerberosConfigPath string
+	triggerIndex       int
+}
+
+func (k *kafkaMetadata) enableTLS() bool {
+	return k.TLS == stringEnable
+}
+
+func (k *kafkaMetadata) Validate() error {
+	if k.LagThreshold <= 0 {
+		return fmt.Errorf("lagThreshold must be a positive number")
+	}
+	if k.ActivationLagThreshold < 0 {
+		return fmt.Errorf("activationLagThreshold must be a positive number")
+	}
+	if k.AllowIdleConsumers && k.LimitToPartitionsWithLag {
+		return fmt.Errorf("allowIdleConsumers and limitToPartitionsWithLag cannot be set simultaneously")
+	}
+	if len(k.Topic) == 0 && k.LimitToPartitionsWithLag {
+		return fmt.Errorf("topic must be specified when using limitToPartitionsWithLag")
+	}
+	if len(k.Topic) == 0 && len(k.PartitionLimitation) > 0 {
+		// no specific topics set, ignoring partitionLimitation setting
+		k.PartitionLimitation = nil
+	}
+	if k.enableTLS() && ((k.Cert == "") != (k.Key == "")) {
+		return fmt.Errorf("can't set only one of cert or key when using TLS")
+	}
+
+	switch k.SASLType {
+	case KafkaSASLTypePlaintext, KafkaSASLTypeSCRAMSHA256, KafkaSASLTypeSCRAMSHA512:
+		if k.Username == "" || k.Password == "" {
+			return fmt.Errorf("username and password must be set when using SASL/%s", k.SASLType)
+		}
+	case KafkaSASLTypeOAuthbearer:
+		switch k.TokenProvider {
+		case KafkaSASLOAuthTokenProviderBearer:
+			if k.Username == "" || k.Password == "" {
+				return fmt.Errorf("username and password must be set when using SASL/OAUTHBEARER")
+			}
+			if k.OAuthTokenEndpointURI == "" {
+				return fmt.Errorf("oauthTokenEndpointUri must be set when using SASL/OAUTHBEARER")
+			}
+		case KafkaSASLOAuthTokenProviderAWSMSKIAM:
+			return k.validateMSK()
+		}
+	case KafkaSASLTypeGSSAPI:
+		if k.Username == "" {
+			return fmt.Errorf("username must be set when using SASL/GSSAPI")
+		}
+		if (k.Password == "") == (k.Keytab == "") {
+			return fmt.Errorf("exactly one of password or keytab must be set when using SASL/GSSAPI")
+		}
+		if k.Realm == "" {
+			return fmt.Errorf("realm must be set when using SASL/GSSAPI")
+		}
+		if k.KerberosConfig == "" {
+			return fmt.Errorf("kerberosConfig must be set when using SASL/GSSAPI")
+		}
+	case KafkaSASLTypeMskIam:
+		return k.validateMSK()
+	}
+	return nil
+}
+
+func (k *kafkaMetadata) validateMSK() error {
+	if k.AWSRegion == "" {
+		return fmt.Errorf("awsRegion must be set when using AWS MSK IAM")
+	}
+	if !k.enableTLS() {
+		return fmt.Errorf("TLS must be enabled when using AWS MSK IAM")
+	}
+	return nil
+}
+
+// useMSK returns whether the brokers are authenticated with AWS MSK IAM, as a SASL type or as an OAUTHBEARER token provider
+func (k *kafkaMetadata) useMSK() bool {
+	return k.SASLType == KafkaSASLTypeMskIam ||
+		(k.SASLType == KafkaSASLTypeOAuthbearer && k.TokenProvider == KafkaSASLOAuthTokenProviderAWSMSKIAM)
 }
 
 type offsetResetPolicy string
@@ -109,6 +203,14 @@ const (
 	earliest offsetResetPolicy = "earliest"
 )
 
+type kafkaClientType string
+
+// supported client backends
+const (
+	kafkaClientSarama  kafkaClientType = "sarama"
+	kafkaClientKafkaGo kafkaClientType = "kafka-go"
+)
+
 type kafkaSaslType string
 
 // supported SASL types
@@ -119,6 +221,7 @@ const (
 	KafkaSASLTypeSCRAMSHA512 kafkaSaslType = "scram_sha512"
 	KafkaSASLTypeOAuthbearer kafkaSaslType = "oauthbearer"
 	KafkaSASLTypeGSSAPI      kafkaSaslType = "gssapi"
+	KafkaSASLTypeMskIam      kafkaSaslType = "aws_msk_iam"
 )
 
 type kafkaSaslOAuthTokenProvider string
@@ -130,301 +233,113 @@ const (
 )
 
 const (
-	lagThresholdMetricName             = "lagThreshold"
-	activationLagThresholdMetricName   = "activationLagThreshold"
-	kafkaMetricType                    = "External"
-	defaultKafkaLagThreshold           = 10
-	defaultKafkaActivationLagThreshold = 0
-	defaultOffsetResetPolicy           = latest
-	invalidOffset                      = -1
+	lagThresholdMetricName   = "lagThreshold"
+	kafkaMetricType          = "External"
+	defaultKafkaLagThreshold = 10
+	invalidOffset            = -1
 )
 
-// NewKafkaScaler creates a new kafkaScaler
+// NewKafkaScaler creates a new kafkaScaler, which uses the sarama client backend unless set otherwise
 func NewKafkaScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	return newKafkaScaler(ctx, config, kafkaClientSarama, InitializeLogger(config, "kafka_scaler"))
+}
+
+// NewApacheKafkaScaler creates a new kafkaScaler, which uses the kafka-go client backend unless set otherwise
+func NewApacheKafkaScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	return newKafkaScaler(ctx, config, kafkaClientKafkaGo, InitializeLogger(config, "apache_kafka_scaler"))
+}
+
+func newKafkaScaler(ctx context.Context, config *scalersconfig.ScalerConfig, defaultClient kafkaClientType, logger logr.Logger) (Scaler, error) {
 	metricType, err := GetMetricTargetType(config)
 	if err != nil {
 		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
 	}
 
-	logger := InitializeLogger(config, "kafka_scaler")
-
 	kafkaMetadata, err := parseKafkaMetadata(config, logger)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing kafka metadata: %w", err)
 	}
+	if kafkaMetadata.Client == "" {
+		kafkaMetadata.Client = defaultClient
+	}
 
-	client, admin, err := getKafkaClients(ctx, kafkaMetadata)
+	var client kafkaClient
+	switch kafkaMetadata.Client {
+	case kafkaClientKafkaGo:
+		client, err = newKafkaGoClient(ctx, kafkaMetadata, logger)
+	default:
+		client, err = newSaramaKafkaClient(ctx, kafkaMetadata, logger)
+	}
 	if err != nil {
+		_ = kafkaMetadata.removeKerberosFiles()
 		return nil, err
 	}
 
-	previousOffsets := make(map[string]map[int32]int64)
-
 	return &kafkaScaler{
 		client:          client,
-		admin:           admin,
 		metricType:      metricType,
 		metadata:        kafkaMetadata,
 		logger:          logger,
-		previousOffsets: previousOffsets,
+		previousOffsets: make(map[string]map[int32]int64),
 	}, nil
 }
 
-func parseKafkaAuthParams(config *scalersconfig.ScalerConfig, meta *kafkaMetadata) error {
-	meta.enableTLS = false
-	enableTLS := false
-	if val, ok := config.TriggerMetadata["tls"]; ok {
-		switch val {
-		case stringEnable:
-			enableTLS = true
-		case stringDisable:
-			enableTLS = false
-		default:
-			return fmt.Errorf("error incorrect TLS value given, got %s", val)
-		}
-	}
-
-	if val, ok := config.AuthParams["tls"]; ok {
-		val = strings.TrimSpace(val)
-		if enableTLS {
-			return errors.New("unable to set `tls` in both ScaledObject and TriggerAuthentication together")
-		}
-		switch val {
-		case stringEnable:
-			enableTLS = true
-		case stringDisable:
-			enableTLS = false
-		default:
-			return fmt.Errorf("error incorrect TLS value given, got %s", val)
-		}
-	}
-
-	if enableTLS {
-		if err := parseTLS(config, meta); err != nil {
-			return err
-		}
-	}
-
-	meta.saslType = KafkaSASLTypeNone
-	var saslAuthType string
-	switch {
-	case config.TriggerMetadata["sasl"] != "":
-		saslAuthType = config.TriggerMetadata["sasl"]
-	default:
-		saslAuthType = ""
-	}
-	if val, ok := config.AuthParams["sasl"]; ok {
-		if saslAuthType != "" {
-			return errors.New("unable to set `sasl` in both ScaledObject and TriggerAuthentication together")
-		}
-		saslAuthType = val
-	}
-
-	if saslAuthType != "" {
-		saslAuthType = strings.TrimSpace(saslAuthType)
-		mode := kafkaSaslType(saslAuthType)
-
-		switch {
-		case mode == KafkaSASLTypePlaintext || mode == KafkaSASLTypeSCRAMSHA256 || mode == KafkaSASLTypeSCRAMSHA512:
-			err := parseSaslParams(config, meta, mode)
-			if err != nil {
-				return err
-			}
-		case mode == KafkaSASLTypeOAuthbearer:
-			err := parseSaslOAuthParams(config, meta, mode)
-			if err != nil {
-				return err
-			}
-		case mode == KafkaSASLTypeGSSAPI:
-			err := parseKerberosParams(config, meta, mode)
-			if err != nil {
-				return err
-			}
-		default:
-			return fmt.Errorf("err SASL mode %s given", mode)
+// validateKafkaAuthParamsSource rejects the sasl and tls settings given both in the trigger metadata and
+// in the TriggerAuthentication, the typed config would silently prefer the trigger metadata otherwise
+func validateKafkaAuthParamsSource(config *scalersconfig.ScalerConfig) error {
+	for _, param := range []string{"sasl", "tls", "saslTokenProvider"} {
+		if _, ok := config.AuthParams[param]; ok && config.TriggerMetadata[param] != "" {
+			return fmt.Errorf("unable to set `%s` in both ScaledObject and TriggerAuthentication together", param)
 		}
 	}
-
 	return nil
 }
 
-func parseSaslOAuthParams(config *scalersconfig.ScalerConfig, meta *kafkaMetadata, mode kafkaSaslType) error {
-	var tokenProviderTypeValue string
-	if val, ok := config.TriggerMetadata["saslTokenProvider"]; ok {
-		tokenProviderTypeValue = val
-	}
-
-	if val, ok := config.AuthParams["saslTokenProvider"]; ok {
-		if tokenProviderTypeValue != "" {
-			return errors.New("unable to set `saslTokenProvider` in both ScaledObject and TriggerAuthentication together")
-		}
-		tokenProviderTypeValue = val
-	}
-
-	tokenProviderType := KafkaSASLOAuthTokenProviderBearer
-	if tokenProviderTypeValue != "" {
-		tokenProviderType = kafkaSaslOAuthTokenProvider(strings.TrimSpace(tokenProviderTypeValue))
-	}
-
-	var tokenProviderErr error
-	switch tokenProviderType {
-	case KafkaSASLOAuthTokenProviderBearer:
-		tokenProviderErr = parseSaslOAuthBearerParams(config, meta)
-	case KafkaSASLOAuthTokenProviderAWSMSKIAM:
-		tokenProviderErr = parseSaslOAuthAWSMSKIAMParams(config, meta)
-	default:
-		return fmt.Errorf("err SASL OAuth token provider %s given", tokenProviderType)
-	}
-
-	if tokenProviderErr != nil {
-		return fmt.Errorf("error parsing OAuth token provider configuration: %w", tokenProviderErr)
-	}
-
-	meta.saslType = mode
-	meta.tokenProvider = tokenProviderType
-
-	return nil
-}
-
-func parseSaslOAuthBearerParams(config *scalersconfig.ScalerConfig, meta *kafkaMetadata) error {
-	if config.AuthParams["username"] == "" {
-		return errors.New("no username given")
-	}
-	meta.username = strings.TrimSpace(config.AuthParams["username"])
-
-	if config.AuthParams["password"] == "" {
-		return errors.New("no password given")
-	}
-	meta.password = strings.TrimSpace(config.AuthParams["password"])
-
-	meta.scopes = strings.Split(config.AuthParams["scopes"], ",")
-
-	if config.AuthParams["oauthTokenEndpointUri"] == "" {
-		return errors.New("no oauth token endpoint uri given")
-	}
-	meta.oauthTokenEndpointURI = strings.TrimSpace(config.AuthParams["oauthTokenEndpointUri"])
-
-	meta.oauthExtensions = make(map[string]string)
-	oauthExtensionsRaw := config.AuthParams["oauthExtensions"]
-	if oauthExtensionsRaw != "" {
-		for _, extension := range strings.Split(oauthExtensionsRaw, ",") {
-			splittedExtension := strings.Split(extension, "=")
-			if len(splittedExtension) != 2 {
-				return errors.New("invalid OAuthBearer extension, must be of format key=value")
-			}
-			meta.oauthExtensions[splittedExtension[0]] = splittedExtension[1]
-		}
+func parseKafkaMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger) (kafkaMetadata, error) {
+	meta := kafkaMetadata{triggerIndex: config.TriggerIndex}
+	if err := validateKafkaAuthParamsSource(config); err != nil {
+		return meta, err
 	}
-
-	return nil
-}
-
-func parseSaslOAuthAWSMSKIAMParams(config *scalersconfig.ScalerConfig, meta *kafkaMetadata) error {
-	if !meta.enableTLS {
-		return errors.New("TLS is required for AWS MSK authentication")
+	if err := config.TypedConfig(&meta); err != nil {
+		return meta, fmt.Errorf("error parsing kafka metadata: %w", err)
 	}
 
-	if config.TriggerMetadata["awsRegion"] == "" {
-		return errors.New("no awsRegion given")
+	if len(meta.Topic) == 0 {
+		logger.V(1).Info(fmt.Sprintf("consumer group %q has no topic specified, "+
+			"will use all topics subscribed by the consumer group for scaling", meta.Group))
 	}
 
-	meta.awsRegion = config.TriggerMetadata["awsRegion"]
-
-	auth, err := awsutils.GetAwsAuthorization(config.TriggerUniqueKey, config.PodIdentity, config.TriggerMetadata, config.AuthParams, config.ResolvedEnv)
+	version, err := sarama.ParseKafkaVersion(meta.Version)
 	if err != nil {
-		return fmt.Errorf("error getting AWS authorization: %w", err)
+		return meta, fmt.Errorf("error parsing kafka version: %w", err)
 	}
+	meta.version = version
 
-	meta.awsAuthorization = auth
-	return nil
-}
-
-func parseTLS(config *scalersconfig.ScalerConfig, meta *kafkaMetadata) error {
-	certGiven := config.AuthParams["cert"] != ""
-	keyGiven := config.AuthParams["key"] != ""
-	if certGiven && !keyGiven {
-		return errors.New("key must be provided with cert")
-	}
-	if keyGiven && !certGiven {
-		return errors.New("cert must be provided with key")
-	}
-	meta.ca = config.AuthParams["ca"]
-	meta.cert = config.AuthParams["cert"]
-	meta.key = config.AuthParams["key"]
-	meta.unsafeSsl = defaultUnsafeSsl
-
-	if val, ok := config.TriggerMetadata["unsafeSsl"]; ok {
-		unsafeSsl, err := strconv.ParseBool(val)
+	if meta.useMSK() {
+		auth, err := awsutils.GetAwsAuthorization(config.TriggerUniqueKey, config.PodIdentity, config.TriggerMetadata, config.AuthParams, config.ResolvedEnv)
 		if err != nil {
-			return fmt.Errorf("error parsing unsafeSsl: %w", err)
+			return meta, fmt.Errorf("error getting AWS authorization: %w", err)
 		}
-		meta.unsafeSsl = unsafeSsl
-	}
-
-	if value, found := config.AuthParams["keyPassword"]; found {
-		meta.keyPassword = value
-	} else {
-		meta.keyPassword = ""
-	}
-	meta.enableTLS = true
-	return nil
-}
-
-func parseKerberosParams(config *scalersconfig.ScalerConfig, meta *kafkaMetadata, mode kafkaSaslType) error {
-	if config.AuthParams["username"] == "" {
-		return errors.New("no username given")
+		meta.AWSAuthorization = auth
 	}
-	meta.username = strings.TrimSpace(config.AuthParams["username"])
 
-	if (config.AuthParams["password"] == "" && config.AuthParams["keytab"] == "") ||
-		(config.AuthParams["password"] != "" && config.AuthParams["keytab"] != "") {
-		return errors.New("exactly one of 'password' or 'keytab' must be provided for GSSAPI authentication")
-	}
-	if config.AuthParams["password"] != "" {
-		meta.password = strings.TrimSpace(config.AuthParams["password"])
-	} else {
-		path, err := saveToFile(config.AuthParams["keytab"])
+	if meta.SASLType == KafkaSASLTypeGSSAPI {
+		if meta.Keytab != "" {
+			path, err := saveToFile(meta.Keytab)
+			if err != nil {
+				return meta, fmt.Errorf("error saving keytab to file: %w", err)
+			}
+			meta.keytabPath = path
+		}
+		path, err := saveToFile(meta.KerberosConfig)
 		if err != nil {
-			return fmt.Errorf("error saving keytab to file: %w", err)
+			_ = meta.removeKerberosFiles()
+			return meta, fmt.Errorf("error saving kerberosConfig to file: %w", err)
 		}
-		meta.keytabPath = path
+		meta.kerberosConfigPath = path
 	}
 
-	if config.AuthParams["realm"] == "" {
-		return errors.New("no realm given")
-	}
-	meta.realm = strings.TrimSpace(config.AuthParams["realm"])
-
-	if config.AuthParams["kerberosConfig"] == "" {
-		return errors.New("no Kerberos configuration file (kerberosConfig) given")
-	}
-	path, err := saveToFile(config.AuthParams["kerberosConfig"])
-	if err != nil {
-		return fmt.Errorf("error saving kerberosConfig to file: %w", err)
-	}
-	meta.kerberosConfigPath = path
-
-	if config.AuthParams["kerberosServiceName"] != "" {
-		meta.kerberosServiceName = strings.TrimSpace(config.AuthParams["kerberosServiceName"])
-	}
-
-	meta.saslType = mode
-	return nil
-}
-
-func parseSaslParams(config *scalersconfig.ScalerConfig, meta *kafkaMetadata, mode kafkaSaslType) error {
-	if config.AuthParams["username"] == "" {
-		return errors.New("no username given")
-	}
-	meta.username = strings.TrimSpace(config.AuthParams["username"])
-
-	if config.AuthParams["password"] == "" {
-		return errors.New("no password given")
-	}
-	meta.password = strings.TrimSpace(config.AuthParams["password"])
-	meta.saslType = mode
-
-	return nil
+	return meta, nil
 }
 
 func saveToFile(content string) (string, error) {
@@ -454,304 +369,49 @@ func saveToFile(content string) (string, error) {
 	return tempFilename, nil
 }
 
-func parseKafkaMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger) (kafkaMetadata, error) {
-	meta := kafkaMetadata{}
-	switch {
-	case config.TriggerMetadata["bootstrapServersFromEnv"] != "":
-		meta.bootstrapServers = strings.Split(config.ResolvedEnv[config.TriggerMetadata["bootstrapServersFromEnv"]], ",")
-	case config.TriggerMetadata["bootstrapServers"] != "":
-		meta.bootstrapServers = strings.Split(config.TriggerMetadata["bootstrapServers"], ",")
-	default:
-		return meta, errors.New("no bootstrapServers given")
-	}
-
-	switch {
-	case config.TriggerMetadata["consumerGroupFromEnv"] != "":
-		meta.group = config.ResolvedEnv[config.TriggerMetadata["consumerGroupFromEnv"]]
-	case config.TriggerMetadata["consumerGroup"] != "":
-		meta.group = config.TriggerMetadata["consumerGroup"]
-	default:
-		return meta, errors.New("no consumer group given")
-	}
-
-	switch {
-	case config.TriggerMetadata["topicFromEnv"] != "":
-		meta.topic = config.ResolvedEnv[config.TriggerMetadata["topicFromEnv"]]
-	case config.TriggerMetadata["topic"] != "":
-		meta.topic = config.TriggerMetadata["topic"]
-	default:
-		meta.topic = ""
-		logger.V(1).Info(fmt.Sprintf("consumer group %q has no topic specified, "+
-			"will use all topics subscribed by the consumer group for scaling", meta.group))
-	}
-
-	meta.partitionLimitation = nil
-	partitionLimitationMetadata := strings.TrimSpace(config.TriggerMetadata["partitionLimitation"])
-	if partitionLimitationMetadata != "" {
-		if meta.topic == "" {
-			logger.V(1).Info("no specific topic set, ignoring partitionLimitation setting")
-		} else {
-			pattern := config.TriggerMetadata["partitionLimitation"]
-			parsed, err := kedautil.ParseInt32List(pattern)
-			if err != nil {
-				return meta, fmt.Errorf("error parsing in partitionLimitation '%s': %w", pattern, err)
-			}
-			meta.partitionLimitation = parsed
-			logger.V(0).Info(fmt.Sprintf("partition limit active '%s'", pattern))
-		}
-	}
-
-	meta.offsetResetPolicy = defaultOffsetResetPolicy
-
-	if config.TriggerMetadata["offsetResetPolicy"] != "" {
-		policy := offsetResetPolicy(config.TriggerMetadata["offsetResetPolicy"])
-		if policy != earliest && policy != latest {
-			return meta, fmt.Errorf("err offsetResetPolicy policy %q given", policy)
-		}
-		meta.offsetResetPolicy = policy
-	}
-
-	meta.lagThreshold = defaultKafkaLagThreshold
-
-	if val, ok := config.TriggerMetadata[lagThresholdMetricName]; ok {
-		t, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return meta, fmt.Errorf("error parsing %q: %w", lagThresholdMetricName, err)
-		}
-		if t <= 0 {
-			return meta, fmt.Errorf("%q must be positive number", lagThresholdMetricName)
-		}
-		meta.lagThreshold = t
-	}
-
-	meta.activationLagThreshold = defaultKafkaActivationLagThreshold
-
-	if val, ok := config.TriggerMetadata[activationLagThresholdMetricName]; ok {
-		t, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return meta, fmt.Errorf("error parsing %q: %w", activationLagThresholdMetricName, err)
-		}
-		if t < 0 {
-			return meta, fmt.Errorf("%q must be positive number", activationLagThresholdMetricName)
-		}
-		meta.activationLagThreshold = t
-	}
-
-	if err := parseKafkaAuthParams(config, &meta); err != nil {
-		return meta, err
-	}
-
-	meta.allowIdleConsumers = false
-	if val, ok := config.TriggerMetadata["allowIdleConsumers"]; ok {
-		t, err := strconv.ParseBool(val)
-		if err != nil {
-			return meta, fmt.Errorf("error parsing allowIdleConsumers: %w", err)
-		}
-		meta.allowIdleConsumers = t
-	}
-
-	meta.excludePersistentLag = false
-	if val, ok := config.TriggerMetadata["excludePersistentLag"]; ok {
-		t, err := strconv.ParseBool(val)
-		if err != nil {
-			return meta, fmt.Errorf("error parsing excludePersistentLag: %w", err)
-		}
-		meta.excludePersistentLag = t
-	}
-
-	meta.scaleToZeroOnInvalidOffset = false
-	if val, ok := config.TriggerMetadata["scaleToZeroOnInvalidOffset"]; ok {
-		t, err := strconv.ParseBool(val)
-		if err != nil {
-			return meta, fmt.Errorf("error parsing scaleToZeroOnInvalidOffset: %w", err)
-		}
-		meta.scaleToZeroOnInvalidOffset = t
-	}
-
-	meta.limitToPartitionsWithLag = false
-	if val, ok := config.TriggerMetadata["limitToPartitionsWithLag"]; ok {
-		t, err := strconv.ParseBool(val)
-		if err != nil {
-			return meta, fmt.Errorf("error parsing limitToPartitionsWithLag: %w", err)
-		}
-		meta.limitToPartitionsWithLag = t
-
-		if meta.allowIdleConsumers && meta.limitToPartitionsWithLag {
-			return meta, fmt.Errorf("allowIdleConsumers and limitToPartitionsWithLag cannot be set simultaneously")
-		}
-		if len(meta.topic) == 0 && meta.limitToPartitionsWithLag {
-			return meta, fmt.Errorf("topic must be specified when using limitToPartitionsWithLag")
-		}
-	}
-
-	meta.version = sarama.V1_0_0_0
-	if val, ok := config.TriggerMetadata["version"]; ok {
-		val = strings.TrimSpace(val)
-		version, err := sarama.ParseKafkaVersion(val)
-		if err != nil {
-			return meta, fmt.Errorf("error parsing kafka version: %w", err)
-		}
-		meta.version = version
-	}
-	meta.triggerIndex = config.TriggerIndex
-	return meta, nil
-}
-
-func getKafkaClients(ctx context.Context, metadata kafkaMetadata) (sarama.Client, sarama.ClusterAdmin, error) {
-	config, err := getKafkaClientConfig(ctx, metadata)
-	if err != nil {
-		return nil, nil, fmt.Errorf("error getting kafka client config: %w", err)
-	}
-
-	client, err := sarama.NewClient(metadata.bootstrapServers, config)
-	if err != nil {
-		return nil, nil, fmt.Errorf("error creating kafka client: %w", err)
-	}
-
-	admin, err := sarama.NewClusterAdminFromClient(client)
-	if err != nil {
-		if !client.Closed() {
-			client.Close()
-		}
-		return nil, nil, fmt.Errorf("error creating kafka admin: %w", err)
-	}
-
-	return client, admin, nil
-}
-
-func getKafkaClientConfig(ctx context.Context, metadata kafkaMetadata) (*sarama.Config, error) {
-	config := sarama.NewConfig()
-	config.Version = metadata.version
-
-	if metadata.saslType != KafkaSASLTypeNone && metadata.saslType != KafkaSASLTypeGSSAPI {
-		config.Net.SASL.Enable = true
-		config.Net.SASL.User = metadata.username
-		config.Net.SASL.Password = metadata.password
-	}
-
-	if metadata.enableTLS {
-		config.Net.TLS.Enable = true
-		tlsConfig, err := kedautil.NewTLSConfigWithPassword(metadata.cert, metadata.key, metadata.keyPassword, metadata.ca, metadata.unsafeSsl)
-		if err != nil {
-			return nil, err
-		}
-		config.Net.TLS.Config = tlsConfig
-	}
-
-	if metadata.saslType == KafkaSASLTypePlaintext {
-		config.Net.SASL.Mechanism = sarama.SASLTypePlaintext
-	}
-
-	if metadata.saslType == KafkaSASLTypeSCRAMSHA256 {
-		config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return &kafka.XDGSCRAMClient{HashGeneratorFcn: kafka.SHA256} }
-		config.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA256
-	}
-
-	if metadata.saslType == KafkaSASLTypeSCRAMSHA512 {
-		config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return &kafka.XDGSCRAMClient{HashGeneratorFcn: kafka.SHA512} }
-		config.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
-	}
-
-	if metadata.saslType == KafkaSASLTypeOAuthbearer {
-		config.Net.SASL.Mechanism = sarama.SASLTypeOAuth
-		switch metadata.tokenProvider {
-		case KafkaSASLOAuthTokenProviderBearer:
-			config.Net.SASL.TokenProvider = kafka.OAuthBearerTokenProvider(metadata.username, metadata.password, metadata.oauthTokenEndpointURI, metadata.scopes, metadata.oauthExtensions)
-		case KafkaSASLOAuthTokenProviderAWSMSKIAM:
-			awsAuth, err := awsutils.GetAwsConfig(ctx, metadata.awsRegion, metadata.awsAuthorization)
-			if err != nil {
-				return nil, fmt.Errorf("error getting AWS config: %w", err)
-			}
-
-			config.Net.SASL.TokenProvider = kafka.OAuthMSKTokenProvider(awsAuth)
-		default:
-			return nil, fmt.Errorf("err SASL OAuth token provider %s given but not supported", metadata.tokenProvider)
-		}
-	}
-
-	if metadata.saslType == KafkaSASLTypeGSSAPI {
-		config.Net.SASL.Enable = true
-		config.Net.SASL.Mechanism = sarama.SASLTypeGSSAPI
-		if metadata.kerberosServiceName != "" {
-			config.Net.SASL.GSSAPI.ServiceName = metadata.kerberosServiceName
-		} else {
-			config.Net.SASL.GSSAPI.ServiceName = "kafka"
+// removeKerberosFiles cleans up the temporary files of the GSSAPI authentication
+func (k *kafkaMetadata) removeKerberosFiles() error {
+	var errs []error
+	for _, path := range []string{k.kerberosConfigPath, k.keytabPath} {
+		if strings.TrimSpace(path) == "" {
+			continue
 		}
-		config.Net.SASL.GSSAPI.Username = metadata.username
-		config.Net.SASL.GSSAPI.Realm = metadata.realm
-		config.Net.SASL.GSSAPI.KerberosConfigPath = metadata.kerberosConfigPath
-		if metadata.keytabPath != "" {
-			config.Net.SASL.GSSAPI.AuthType = sarama.KRB5_KEYTAB_AUTH
-			config.Net.SASL.GSSAPI.KeyTabPath = metadata.keytabPath
-		} else {
-			config.Net.SASL.GSSAPI.AuthType = sarama.KRB5_USER_AUTH
-			config.Net.SASL.GSSAPI.Password = metadata.password
+		if err := os.Remove(path); err != nil {
+			errs = append(errs, err)
 		}
 	}
-	return config, nil
+	return errors.Join(errs...)
 }
 
-func (s *kafkaScaler) getTopicPartitions() (map[string][]int32, error) {
-	var topicsToDescribe = make([]string, 0)
-
-	// when no topic is specified, query to cg group to fetch all subscribed topics
-	if s.metadata.topic == "" {
-		listCGOffsetResponse, err := s.admin.ListConsumerGroupOffsets(s.metadata.group, nil)
-		if err != nil {
-			return nil, fmt.Errorf("error listing cg offset: %w", err)
-		}
-
-		if listCGOffsetResponse.Err > 0 {
-			errMsg := fmt.Errorf("error listing cg offset: %w", listCGOffsetResponse.Err)
-			s.logger.Error(errMsg, "")
-		}
-
-		for topicName := range listCGOffsetResponse.Blocks {
-			topicsToDescribe = append(topicsToDescribe, topicName)
-		}
-	} else {
-		topicsToDescribe = []string{s.metadata.topic}
-	}
-
-	topicsMetadata, err := s.admin.DescribeTopics(topicsToDescribe)
+func (s *kafkaScaler) getTopicPartitions(ctx context.Context) (map[string][]int32, error) {
+	topicsMetadata, err := s.client.getTopicPartitions(ctx, s.metadata.Topic, s.metadata.Group)
 	if err != nil {
-		return nil, fmt.Errorf("error describing topics: %w", err)
-	}
-	s.logger.V(1).Info(
-		fmt.Sprintf("with topic name %s the list of topic metadata is %v", topicsToDescribe, topicsMetadata),
-	)
-
-	if s.metadata.topic != "" && len(topicsMetadata) != 1 {
-		return nil, fmt.Errorf("expected only 1 topic metadata, got %d", len(topicsMetadata))
+		return nil, err
 	}
+	s.logger.V(1).Info(fmt.Sprintf("with topic names %v the list of topic partitions is %v", s.metadata.Topic, topicsMetadata))
 
 	topicPartitions := make(map[string][]int32, len(topicsMetadata))
-	for _, topicMetadata := range topicsMetadata {
-		if topicMetadata.Err > 0 {
-			errMsg := fmt.Errorf("error describing topics: %w", topicMetadata.Err)
-			s.logger.Error(errMsg, "")
-		}
-		partitionMetadata := topicMetadata.Partitions
+	for topic, partitionIDs := range topicsMetadata {
 		var partitions []int32
-		for _, p := range partitionMetadata {
-			if s.isActivePartition(p.ID) {
-				partitions = append(partitions, p.ID)
+		for _, partitionID := range partitionIDs {
+			if s.isActivePartition(partitionID) {
+				partitions = append(partitions, partitionID)
 			}
 		}
 		if len(partitions) == 0 {
-			return nil, fmt.Errorf("expected at least one active partition within the topic '%s'", topicMetadata.Name)
+			return nil, fmt.Errorf("expected at least one active partition within the topic '%s'", topic)
 		}
 
-		topicPartitions[topicMetadata.Name] = partitions
+		topicPartitions[topic] = partitions
 	}
 	return topicPartitions, nil
 }
 
 func (s *kafkaScaler) isActivePartition(pID int32) bool {
-	if s.metadata.partitionLimitation == nil {
+	if s.metadata.PartitionLimitation == nil {
 		return true
 	}
-	for _, _pID := range s.metadata.partitionLimitation {
+	for _, _pID := range s.metadata.PartitionLimitation {
 		if pID == _pID {
 			return true
 		}
@@ -759,60 +419,43 @@ func (s *kafkaScaler) isActivePartition(pID int32) bool {
 	return false
 }
 
-func (s *kafkaScaler) getConsumerOffsets(topicPartitions map[string][]int32) (*sarama.OffsetFetchResponse, error) {
-	offsets, err := s.admin.ListConsumerGroupOffsets(s.metadata.group, topicPartitions)
-	if err != nil {
-		return nil, fmt.Errorf("error listing consumer group offsets: %w", err)
-	}
-	if offsets.Err > 0 {
-		errMsg := fmt.Errorf("error listing consumer group offsets: %w", offsets.Err)
-		s.logger.Error(errMsg, "")
-	}
-	return offsets, nil
-}
-
 // getLagForPartition returns (lag, lagWithPersistent, error)
 // When excludePersistentLag is set to `false` (default), lag will always be equal to lagWithPersistent
 // When excludePersistentLag is set to `true`, if partition is deemed to have persistent lag, lag will be set to 0 and lagWithPersistent will be latestOffset - consumerOffset
 // These return values will allow proper scaling from 0 -> 1 replicas by the IsActive func.
-func (s *kafkaScaler) getLagForPartition(topic string, partitionID int32, offsets *sarama.OffsetFetchResponse, topicPartitionOffsets map[string]map[int32]int64) (int64, int64, error) {
-	block := offsets.GetBlock(topic, partitionID)
-	if block == nil {
-		errMsg := fmt.Errorf("error finding offset block for topic %s and partition %d from offset block: %v", topic, partitionID, offsets.Blocks)
+func (s *kafkaScaler) getLagForPartition(topic string, partitionID int32, consumerOffsets map[string]map[int32]int64, producerOffsets map[string]map[int32]int64) (int64, int64, error) {
+	consumerOffset, found := consumerOffsets[topic][partitionID]
+	if !found {
+		errMsg := fmt.Errorf("error finding consumer offset for topic %s and partition %d from offsets: %v", topic, partitionID, consumerOffsets)
 		s.logger.Error(errMsg, "")
 		return 0, 0, errMsg
 	}
-	if block.Err > 0 {
-		errMsg := fmt.Errorf("error finding offset block for topic %s and partition %d: %w", topic, partitionID, offsets.Err)
-		s.logger.Error(errMsg, "")
-	}
 
-	consumerOffset := block.Offset
-	if consumerOffset == invalidOffset && s.metadata.offsetResetPolicy == latest {
+	if consumerOffset == invalidOffset && s.metadata.OffsetResetPolicy == latest {
 		retVal := int64(1)
-		if s.metadata.scaleToZeroOnInvalidOffset {
+		if s.metadata.ScaleToZeroOnInvalidOffset {
 			retVal = 0
 		}
 		msg := fmt.Sprintf(
 			"invalid offset found for topic %s in group %s and partition %d, probably no offset is committed yet. Returning with lag of %d",
-			topic, s.metadata.group, partitionID, retVal)
+			topic, s.metadata.Group, partitionID, retVal)
 		s.logger.V(1).Info(msg)
 		return retVal, retVal, nil
 	}
 
-	if _, found := topicPartitionOffsets[topic]; !found {
+	if _, found := producerOffsets[topic]; !found {
 		return 0, 0, fmt.Errorf("error finding partition offset for topic %s", topic)
 	}
-	latestOffset := topicPartitionOffsets[topic][partitionID]
-	if consumerOffset == invalidOffset && s.metadata.offsetResetPolicy == earliest {
-		if s.metadata.scaleToZeroOnInvalidOffset {
+	latestOffset := producerOffsets[topic][partitionID]
+	if consumerOffset == invalidOffset && s.metadata.OffsetResetPolicy == earliest {
+		if s.metadata.ScaleToZeroOnInvalidOffset {
 			return 0, 0, nil
 		}
 		return latestOffset, latestOffset, nil
 	}
 
 	// This code block tries to prevent KEDA Kafka trigger from scaling the scale target based on erroneous events
-	if s.metadata.excludePersistentLag {
+	if s.metadata.ExcludePersistentLag {
 		switch previousOffset, found := s.previousOffsets[topic][partitionID]; {
 		case !found:
 			// No record of previous offset, so store current consumer offset
@@ -832,69 +475,58 @@ func (s *kafkaScaler) getLagForPartition(topic string, partitionID int32, offset
 		}
 	}
 
+	s.logger.V(4).Info(fmt.Sprintf("Consumer offset for topic %s in group %s and partition %d is %d", topic, s.metadata.Group, partitionID, consumerOffset))
+	s.logger.V(4).Info(fmt.Sprintf("Producer offset for topic %s in group %s and partition %d is %d", topic, s.metadata.Group, partitionID, latestOffset))
+
 	return latestOffset - consumerOffset, latestOffset - consumerOffset, nil
 }
 
-// Close closes the kafka admin and client
+// Close closes the kafka client and removes the temporary files
 func (s *kafkaScaler) Close(context.Context) error {
-	// clean up any temporary files
-	if strings.TrimSpace(s.metadata.kerberosConfigPath) != "" {
-		if err := os.Remove(s.metadata.kerberosConfigPath); err != nil {
-			return err
-		}
-	}
-	if strings.TrimSpace(s.metadata.keytabPath) != "" {
-		if err := os.Remove(s.metadata.keytabPath); err != nil {
-			return err
-		}
+	if err := s.metadata.removeKerberosFiles(); err != nil {
+		return err
 	}
-	// underlying client will also be closed on admin's Close() call
-	if s.admin == nil {
+	if s.client == nil {
 		return nil
 	}
-
-	return s.admin.Close()
+	return s.client.close()
 }
 
 func (s *kafkaScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	var metricName string
-	if s.metadata.topic != "" {
-		metricName = fmt.Sprintf("kafka-%s", s.metadata.topic)
+	if len(s.metadata.Topic) > 0 {
+		metricName = fmt.Sprintf("kafka-%s", strings.Join(s.metadata.Topic, ","))
 	} else {
-		metricName = fmt.Sprintf("kafka-%s-topics", s.metadata.group)
+		metricName = fmt.Sprintf("kafka-%s-topics", s.metadata.Group)
 	}
 
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(metricName)),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.lagThreshold),
+		Target: GetMetricTarget(s.metricType, s.metadata.LagThreshold),
 	}
 	metricSpec := v2.MetricSpec{External: externalMetric, Type: kafkaMetricType}
 	return []v2.MetricSpec{metricSpec}
 }
 
-type consumerOffsetResult struct {
-	consumerOffsets *sarama.OffsetFetchResponse
-	err             error
-}
-
-type producerOffsetResult struct {
-	producerOffsets map[string]map[int32]int64
-	err             error
+type kafkaOffsetResult struct {
+	offsets map[string]map[int32]int64
+	err     error
 }
 
-func (s *kafkaScaler) getConsumerAndProducerOffsets(topicPartitions map[string][]int32) (*sarama.OffsetFetchResponse, map[string]map[int32]int64, error) {
-	consumerChan := make(chan consumerOffsetResult, 1)
+// getConsumerAndProducerOffsets returns (consumerOffsets, producerOffsets, error)
+func (s *kafkaScaler) getConsumerAndProducerOffsets(ctx context.Context, topicPartitions map[string][]int32) (map[string]map[int32]int64, map[string]map[int32]int64, error) {
+	consumerChan := make(chan kafkaOffsetResult, 1)
 	go func() {
-		consumerOffsets, err := s.getConsumerOffsets(topicPartitions)
-		consumerChan <- consumerOffsetResult{consumerOffsets, err}
+		consumerOffsets, err := s.client.getConsumerOffsets(ctx, s.metadata.Group, topicPartitions)
+		consumerChan <- kafkaOffsetResult{consumerOffsets, err}
 	}()
 
-	producerChan := make(chan producerOffsetResult, 1)
+	producerChan := make(chan kafkaOffsetResult, 1)
 	go func() {
-		producerOffsets, err := s.getProducerOffsets(topicPartitions)
-		producerChan <- producerOffsetResult{producerOffsets, err}
+		producerOffsets, err := s.client.getProducerOffsets(ctx, topicPartitions)
+		producerChan <- kafkaOffsetResult{producerOffsets, err}
 	}()
 
 	consumerRes := <-consumerChan
@@ -907,33 +539,34 @@ func (s *kafkaScaler) getConsumerAndProducerOffsets(topicPartitions map[string][
 		return nil, nil, producerRes.err
 	}
 
-	return consumerRes.consumerOffsets, producerRes.producerOffsets, nil
+	return consumerRes.offsets, producerRes.offsets, nil
 }
 
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
-func (s *kafkaScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	totalLag, totalLagWithPersistent, err := s.getTotalLag()
+func (s *kafkaScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	totalLag, totalLagWithPersistent, err := s.getTotalLag(ctx)
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, false, err
 	}
 	metric := GenerateMetricInMili(metricName, float64(totalLag))
 
-	return []external_metrics.ExternalMetricValue{metric}, totalLagWithPersistent > s.metadata.activationLagThreshold, nil
+	return []external_metrics.ExternalMetricValue{metric}, totalLagWithPersistent > s.metadata.ActivationLagThreshold, nil
 }
 
 // getTotalLag returns totalLag, totalLagWithPersistent, error
 // totalLag and totalLagWithPersistent are the summations of lag and lagWithPersistent returned by getLagForPartition function respectively.
 // totalLag maybe less than totalLagWithPersistent when excludePersistentLag is set to `true` due to some partitions deemed as having persistent lag
-func (s *kafkaScaler) getTotalLag() (int64, int64, error) {
-	topicPartitions, err := s.getTopicPartitions()
+func (s *kafkaScaler) getTotalLag(ctx context.Context) (int64, int64, error) {
+	topicPartitions, err := s.getTopicPartitions(ctx)
 	if err != nil {
 		return 0, 0, err
 	}
 
-	consumerOffsets, producerOffsets, err := s.getConsumerAndProducerOffsets(topicPartitions)
+	consumerOffsets, producerOffsets, err := s.getConsumerAndProducerOffsets(ctx, topicPartitions)
 	if err != nil {
 		return 0, 0, err
 	}
+	s.logger.V(4).Info(fmt.Sprintf("Kafka scaler: Consumer offsets %v, producer offsets %v", consumerOffsets, producerOffsets))
 
 	totalLag := int64(0)
 	totalLagWithPersistent := int64(0)
@@ -955,84 +588,18 @@ func (s *kafkaScaler) getTotalLag() (int64, int64, error) {
 		}
 		totalTopicPartitions += (int64)(len(partitionsOffsets))
 	}
-	s.logger.V(1).Info(fmt.Sprintf("Kafka scaler: Providing metrics based on totalLag %v, topicPartitions %v, threshold %v", totalLag, len(topicPartitions), s.metadata.lagThreshold))
+	s.logger.V(1).Info(fmt.Sprintf("Kafka scaler: Providing metrics based on totalLag %v, topicPartitions %v, threshold %v", totalLag, len(topicPartitions), s.metadata.LagThreshold))
 
-	if !s.metadata.allowIdleConsumers || s.metadata.limitToPartitionsWithLag {
+	if !s.metadata.AllowIdleConsumers || s.metadata.LimitToPartitionsWithLag {
 		// don't scale out beyond the number of topicPartitions or partitionsWithLag depending on settings
 		upperBound := totalTopicPartitions
-		if s.metadata.limitToPartitionsWithLag {
+		if s.metadata.LimitToPartitionsWithLag {
 			upperBound = partitionsWithLag
 		}
 
-		if (totalLag / s.metadata.lagThreshold) > upperBound {
-			totalLag = upperBound * s.metadata.lagThreshold
+		if (totalLag / s.metadata.LagThreshold) > upperBound {
+			totalLag = upperBound * s.metadata.LagThreshold
 		}
 	}
 	return totalLag, totalLagWithPersistent, nil
 }
-
-type brokerOffsetResult struct {
-	offsetResp *sarama.OffsetResponse
-	err        error
-}
-
-func (s *kafkaScaler) getProducerOffsets(topicPartitions map[string][]int32) (map[string]map[int32]int64, error) {
-	version := int16(0)
-	if s.client.Config().Version.IsAtLeast(sarama.V0_10_1_0) {
-		version = 1
-	}
-
-	// Step 1: build one OffsetRequest instance per broker.
-	requests := make(map[*sarama.Broker]*sarama.OffsetRequest)
-
-	for topic, partitions := range topicPartitions {
-		for _, partitionID := range partitions {
-			broker, err := s.client.Leader(topic, partitionID)
-			if err != nil {
-				return nil, err
-			}
-			request, ok := requests[broker]
-			if !ok {
-				request = &sarama.OffsetRequest{Version: version}
-				requests[broker] = request
-			}
-			request.AddBlock(topic, partitionID, sarama.OffsetNewest, 1)
-		}
-	}
-
-	// Step 2: send requests, one per broker, and collect topicPartitionsOffsets
-	resultCh := make(chan brokerOffsetResult, len(requests))
-	var wg sync.WaitGroup
-	wg.Add(len(requests))
-	for broker, request := range requests {
-		go func(brCopy *sarama.Broker, reqCopy *sarama.OffsetRequest) {
-			defer wg.Done()
-			response, err := brCopy.GetAvailableOffsets(reqCopy)
-			resultCh <- brokerOffsetResult{response, err}
-		}(broker, request)
-	}
-
-	wg.Wait()
-	close(resultCh)
-
-	topicPartitionsOffsets := make(map[string]map[int32]int64)
-	for brokerOffsetRes := range resultCh {
-		if brokerOffsetRes.err != nil {
-			return nil, brokerOffsetRes.err
-		}
-
-		for topic, blocks := range brokerOffsetRes.offsetResp.Blocks {
-			if _, found := topicPartitionsOffsets[topic]; !found {
-				topicPartitionsOffsets[topic] = make(map[int32]int64)
-			}
-			for partitionID, block := range blocks {
-				if block.Err != sarama.ErrNoError {
-					return nil, block.Err
-				}
-				topicPartitionsOffsets[topic][partitionID] = block.Offset
-			}
-		}
-	}
-
-	return topicPartitionsOffsets, nil
-}
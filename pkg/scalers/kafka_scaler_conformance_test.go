@@ -0,0 +1,117 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+// The conformance suite runs the same lag scenarios against every client backend and both trigger types,
+// so the sarama and the kafka-go backends are kept in line with each other.
+
+const conformanceGroup = "my-group"
+
+// partition -> {producer offset, consumer offset}
+var conformanceOffsets = map[string]map[int32][2]int64{
+	"topic-a": {0: {10, 5}, 1: {20, 20}},
+	"topic-b": {0: {30, invalidOffset}, 1: {40, 25}},
+}
+
+// newConformanceBroker returns a mock broker serving the conformance offsets, it only advertises the
+// api versions both client backends can speak to it
+func newConformanceBroker(t *testing.T) *sarama.MockBroker {
+	broker := sarama.NewMockBroker(t, 1)
+	t.Cleanup(broker.Close)
+
+	metadataResponse := sarama.NewMockMetadataResponse(t).
+		SetBroker(broker.Addr(), broker.BrokerID()).
+		SetController(broker.BrokerID())
+	offsetResponse := sarama.NewMockOffsetResponse(t)
+	offsetFetchResponse := sarama.NewMockOffsetFetchResponse(t)
+	for topic, partitions := range conformanceOffsets {
+		for partition, offsets := range partitions {
+			metadataResponse.SetLeader(topic, partition, broker.BrokerID())
+			offsetResponse.SetOffset(topic, partition, sarama.OffsetNewest, offsets[0])
+			offsetFetchResponse.SetOffset(conformanceGroup, topic, partition, offsets[1], "", sarama.ErrNoError)
+		}
+	}
+
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t).SetApiKeys([]sarama.ApiVersionsResponseKey{
+			{ApiKey: 3, MinVersion: 0, MaxVersion: 1},  // Metadata
+			{ApiKey: 2, MinVersion: 0, MaxVersion: 1},  // ListOffsets
+			{ApiKey: 9, MinVersion: 0, MaxVersion: 2},  // OffsetFetch
+			{ApiKey: 10, MinVersion: 0, MaxVersion: 0}, // FindCoordinator
+			{ApiKey: 18, MinVersion: 0, MaxVersion: 0}, // ApiVersions
+		}),
+		"MetadataRequest": metadataResponse,
+		"OffsetRequest":   offsetResponse,
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, conformanceGroup, broker),
+		"OffsetFetchRequest": offsetFetchResponse,
+	})
+	return broker
+}
+
+func TestKafkaClientConformance(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		metadata map[string]string
+		lag      int64
+		active   bool
+	}{
+		{"single topic", map[string]string{"topic": "topic-a"}, 5, true},
+		{"partition limitation", map[string]string{"topic": "topic-a", "partitionLimitation": "1"}, 0, false},
+		{"topic list with invalid offset and latest policy", map[string]string{"topic": "topic-a,topic-b"}, 21, true},
+		{"topic list with invalid offset and latest policy scaling to zero", map[string]string{"topic": "topic-a,topic-b", "scaleToZeroOnInvalidOffset": "true"}, 20, true},
+		{"topic list with invalid offset and earliest policy", map[string]string{"topic": "topic-a,topic-b", "offsetResetPolicy": "earliest", "allowIdleConsumers": "true"}, 50, true},
+		{"lag capped by the partition count", map[string]string{"topic": "topic-a,topic-b", "offsetResetPolicy": "earliest"}, 40, true},
+		{"lag capped by the partitions with lag", map[string]string{"topic": "topic-a,topic-b", "lagThreshold": "1", "limitToPartitionsWithLag": "true"}, 3, true},
+		{"topics of the consumer group", map[string]string{}, 21, true},
+		{"below the activation threshold", map[string]string{"topic": "topic-a", "activationLagThreshold": "5"}, 5, false},
+	}
+	backends := []struct {
+		name       string
+		newScaler  func(context.Context, *scalersconfig.ScalerConfig) (Scaler, error)
+		clientType string
+	}{
+		{"kafka", NewKafkaScaler, ""},
+		{"kafka with the kafka-go client", NewKafkaScaler, string(kafkaClientKafkaGo)},
+		{"apache-kafka", NewApacheKafkaScaler, ""},
+		{"apache-kafka with the sarama client", NewApacheKafkaScaler, string(kafkaClientSarama)},
+	}
+
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			broker := newConformanceBroker(t)
+			for _, scenario := range scenarios {
+				t.Run(scenario.name, func(t *testing.T) {
+					metadata := map[string]string{
+						"bootstrapServers": broker.Addr(),
+						"consumerGroup":    conformanceGroup,
+					}
+					if backend.clientType != "" {
+						metadata["client"] = backend.clientType
+					}
+					for key, value := range scenario.metadata {
+						metadata[key] = value
+					}
+
+					scaler, err := backend.newScaler(context.Background(), &scalersconfig.ScalerConfig{TriggerMetadata: metadata})
+					require.NoError(t, err)
+					defer scaler.Close(context.Background())
+
+					metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "s0-kafka")
+					require.NoError(t, err)
+					assert.Equal(t, scenario.lag, metrics[0].Value.Value())
+					assert.Equal(t, scenario.active, active)
+				})
+			}
+		})
+	}
+}
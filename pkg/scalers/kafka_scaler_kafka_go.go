@@ -0,0 +1,223 @@
+/*
+Copyright 2023 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalers
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/aws_msk_iam_v2"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+
+	awsutils "github.com/kedacore/keda/v2/pkg/scalers/aws"
+	"github.com/kedacore/keda/v2/pkg/scalers/kafka"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// kafkaGoClient is the kafka client backend based on the kafka-go library
+type kafkaGoClient struct {
+	client *kafkago.Client
+	logger logr.Logger
+}
+
+func newKafkaGoClient(ctx context.Context, metadata kafkaMetadata, logger logr.Logger) (*kafkaGoClient, error) {
+	var saslMechanism sasl.Mechanism
+	var tlsConfig *tls.Config
+	var err error
+
+	logger.V(4).Info(fmt.Sprintf("Kafka SASL type %s", metadata.SASLType))
+	if metadata.enableTLS() {
+		tlsConfig, err = kedautil.NewTLSConfigWithPassword(metadata.Cert, metadata.Key, metadata.KeyPassword, metadata.CA, metadata.UnsafeSsl)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch metadata.SASLType {
+	case KafkaSASLTypeNone:
+		saslMechanism = nil
+	case KafkaSASLTypePlaintext:
+		saslMechanism = plain.Mechanism{
+			Username: metadata.Username,
+			Password: metadata.Password,
+		}
+	case KafkaSASLTypeSCRAMSHA256:
+		saslMechanism, err = scram.Mechanism(scram.SHA256, metadata.Username, metadata.Password)
+		if err != nil {
+			return nil, err
+		}
+	case KafkaSASLTypeSCRAMSHA512:
+		saslMechanism, err = scram.Mechanism(scram.SHA512, metadata.Username, metadata.Password)
+		if err != nil {
+			return nil, err
+		}
+	case KafkaSASLTypeOAuthbearer:
+		tokenProvider, err := getKafkaTokenProvider(ctx, metadata)
+		if err != nil {
+			return nil, err
+		}
+		saslMechanism = kafka.OAuthBearerMechanism(tokenProvider)
+	case KafkaSASLTypeMskIam:
+		cfg, err := awsutils.GetAwsConfig(ctx, metadata.AWSRegion, metadata.AWSAuthorization)
+		if err != nil {
+			return nil, err
+		}
+
+		saslMechanism = aws_msk_iam_v2.NewMechanism(*cfg)
+	case KafkaSASLTypeGSSAPI:
+		return nil, errors.New("SASL/GSSAPI isn't supported by the kafka-go client, use the sarama client instead")
+	default:
+		return nil, fmt.Errorf("err sasl type %q given", metadata.SASLType)
+	}
+
+	transport := &kafkago.Transport{
+		TLS:  tlsConfig,
+		SASL: saslMechanism,
+	}
+	return &kafkaGoClient{
+		client: &kafkago.Client{
+			Addr:      kafkago.TCP(metadata.BootstrapServers...),
+			Transport: transport,
+		},
+		logger: logger,
+	}, nil
+}
+
+func (c *kafkaGoClient) getTopicPartitions(ctx context.Context, topics []string, group string) (map[string][]int32, error) {
+	topicsToDescribe := topics
+
+	// when no topic is specified, fetch the offsets of all the topics the consumer group has committed offsets for
+	if len(topics) == 0 {
+		response, err := c.client.OffsetFetch(ctx, &kafkago.OffsetFetchRequest{GroupID: group})
+		if err != nil {
+			return nil, fmt.Errorf("error listing cg offset: %w", err)
+		}
+		if response.Error != nil {
+			errMsg := fmt.Errorf("error listing cg offset: %w", response.Error)
+			c.logger.Error(errMsg, "")
+		}
+		for topicName := range response.Topics {
+			topicsToDescribe = append(topicsToDescribe, topicName)
+		}
+		if len(topicsToDescribe) == 0 {
+			return map[string][]int32{}, nil
+		}
+	}
+
+	metadata, err := c.client.Metadata(ctx, &kafkago.MetadataRequest{Topics: topicsToDescribe})
+	if err != nil {
+		return nil, fmt.Errorf("error describing topics: %w", err)
+	}
+
+	if len(topics) > 0 && len(metadata.Topics) != len(topics) {
+		return nil, fmt.Errorf("expected %d topic metadata, got %d", len(topics), len(metadata.Topics))
+	}
+
+	topicPartitions := make(map[string][]int32, len(metadata.Topics))
+	for _, topic := range metadata.Topics {
+		if topic.Error != nil {
+			errMsg := fmt.Errorf("error describing topics: %w", topic.Error)
+			c.logger.Error(errMsg, "")
+		}
+		partitions := make([]int32, 0, len(topic.Partitions))
+		for _, partition := range topic.Partitions {
+			partitions = append(partitions, int32(partition.ID))
+		}
+		topicPartitions[topic.Name] = partitions
+	}
+	return topicPartitions, nil
+}
+
+func (c *kafkaGoClient) getConsumerOffsets(ctx context.Context, group string, topicPartitions map[string][]int32) (map[string]map[int32]int64, error) {
+	response, err := c.client.OffsetFetch(
+		ctx,
+		&kafkago.OffsetFetchRequest{
+			GroupID: group,
+			Topics:  toKafkaGoTopicPartitions(topicPartitions),
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error listing consumer group offsets: %w", err)
+	}
+	if response.Error != nil {
+		errMsg := fmt.Errorf("error listing consumer group offsets: %w", response.Error)
+		c.logger.Error(errMsg, "")
+	}
+
+	consumerOffsets := make(map[string]map[int32]int64, len(response.Topics))
+	for topic, partitions := range response.Topics {
+		consumerOffsets[topic] = make(map[int32]int64, len(partitions))
+		for _, partition := range partitions {
+			if partition.Error != nil {
+				errMsg := fmt.Errorf("error finding offset block for topic %s and partition %d: %w", topic, partition.Partition, partition.Error)
+				c.logger.Error(errMsg, "")
+			}
+			consumerOffsets[topic][int32(partition.Partition)] = partition.CommittedOffset
+		}
+	}
+	return consumerOffsets, nil
+}
+
+func (c *kafkaGoClient) getProducerOffsets(ctx context.Context, topicPartitions map[string][]int32) (map[string]map[int32]int64, error) {
+	offsetRequests := make(map[string][]kafkago.OffsetRequest, len(topicPartitions))
+	for topic, partitions := range topicPartitions {
+		for _, partitionID := range partitions {
+			offsetRequests[topic] = append(offsetRequests[topic], kafkago.LastOffsetOf(int(partitionID)))
+		}
+	}
+
+	response, err := c.client.ListOffsets(ctx, &kafkago.ListOffsetsRequest{Topics: offsetRequests})
+	if err != nil {
+		return nil, err
+	}
+
+	producerOffsets := make(map[string]map[int32]int64, len(response.Topics))
+	for topic, partitions := range response.Topics {
+		producerOffsets[topic] = make(map[int32]int64, len(partitions))
+		for _, partition := range partitions {
+			if partition.Error != nil {
+				return nil, partition.Error
+			}
+			producerOffsets[topic][int32(partition.Partition)] = partition.LastOffset
+		}
+	}
+	return producerOffsets, nil
+}
+
+// close closes the idle connections of the transport, kafka-go doesn't keep any other connection open
+func (c *kafkaGoClient) close() error {
+	if transport, ok := c.client.Transport.(*kafkago.Transport); ok && transport != nil {
+		transport.CloseIdleConnections()
+	}
+	return nil
+}
+
+func toKafkaGoTopicPartitions(topicPartitions map[string][]int32) map[string][]int {
+	result := make(map[string][]int, len(topicPartitions))
+	for topic, partitions := range topicPartitions {
+		for _, partitionID := range partitions {
+			result[topic] = append(result[topic], int(partitionID))
+		}
+	}
+	return result
+}
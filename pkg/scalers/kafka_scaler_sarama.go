@@ -0,0 +1,275 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/IBM/sarama"
+	"github.com/go-logr/logr"
+
+	awsutils "github.com/kedacore/keda/v2/pkg/scalers/aws"
+	"github.com/kedacore/keda/v2/pkg/scalers/kafka"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// saramaKafkaClient is the kafka client backend based on the sarama library
+type saramaKafkaClient struct {
+	client sarama.Client
+	admin  sarama.ClusterAdmin
+	logger logr.Logger
+}
+
+func newSaramaKafkaClient(ctx context.Context, metadata kafkaMetadata, logger logr.Logger) (*saramaKafkaClient, error) {
+	config, err := getKafkaClientConfig(ctx, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("error getting kafka client config: %w", err)
+	}
+
+	client, err := sarama.NewClient(metadata.BootstrapServers, config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating kafka client: %w", err)
+	}
+
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	if err != nil {
+		if !client.Closed() {
+			client.Close()
+		}
+		return nil, fmt.Errorf("error creating kafka admin: %w", err)
+	}
+
+	return &saramaKafkaClient{
+		client: client,
+		admin:  admin,
+		logger: logger,
+	}, nil
+}
+
+func getKafkaClientConfig(ctx context.Context, metadata kafkaMetadata) (*sarama.Config, error) {
+	config := sarama.NewConfig()
+	config.Version = metadata.version
+
+	if metadata.SASLType != KafkaSASLTypeNone && metadata.SASLType != KafkaSASLTypeGSSAPI {
+		config.Net.SASL.Enable = true
+		config.Net.SASL.User = metadata.Username
+		config.Net.SASL.Password = metadata.Password
+	}
+
+	if metadata.enableTLS() {
+		config.Net.TLS.Enable = true
+		tlsConfig, err := kedautil.NewTLSConfigWithPassword(metadata.Cert, metadata.Key, metadata.KeyPassword, metadata.CA, metadata.UnsafeSsl)
+		if err != nil {
+			return nil, err
+		}
+		config.Net.TLS.Config = tlsConfig
+	}
+
+	switch metadata.SASLType {
+	case KafkaSASLTypePlaintext:
+		config.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+	case KafkaSASLTypeSCRAMSHA256:
+		config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return &kafka.XDGSCRAMClient{HashGeneratorFcn: kafka.SHA256} }
+		config.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA256
+	case KafkaSASLTypeSCRAMSHA512:
+		config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return &kafka.XDGSCRAMClient{HashGeneratorFcn: kafka.SHA512} }
+		config.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
+	case KafkaSASLTypeOAuthbearer, KafkaSASLTypeMskIam:
+		// sarama authenticates with AWS MSK IAM through the OAUTHBEARER mechanism
+		tokenProvider, err := getKafkaTokenProvider(ctx, metadata)
+		if err != nil {
+			return nil, err
+		}
+		config.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+		config.Net.SASL.TokenProvider = tokenProvider
+	case KafkaSASLTypeGSSAPI:
+		config.Net.SASL.Enable = true
+		config.Net.SASL.Mechanism = sarama.SASLTypeGSSAPI
+		if metadata.KerberosServiceName != "" {
+			config.Net.SASL.GSSAPI.ServiceName = metadata.KerberosServiceName
+		} else {
+			config.Net.SASL.GSSAPI.ServiceName = "kafka"
+		}
+		config.Net.SASL.GSSAPI.Username = metadata.Username
+		config.Net.SASL.GSSAPI.Realm = metadata.Realm
+		config.Net.SASL.GSSAPI.KerberosConfigPath = metadata.kerberosConfigPath
+		if metadata.keytabPath != "" {
+			config.Net.SASL.GSSAPI.AuthType = sarama.KRB5_KEYTAB_AUTH
+			config.Net.SASL.GSSAPI.KeyTabPath = metadata.keytabPath
+		} else {
+			config.Net.SASL.GSSAPI.AuthType = sarama.KRB5_USER_AUTH
+			config.Net.SASL.GSSAPI.Password = metadata.Password
+		}
+	}
+	return config, nil
+}
+
+// getKafkaTokenProvider returns the provider of the SASL/OAUTHBEARER tokens, shared by the client backends
+func getKafkaTokenProvider(ctx context.Context, metadata kafkaMetadata) (kafka.TokenProvider, error) {
+	if metadata.useMSK() {
+		awsAuth, err := awsutils.GetAwsConfig(ctx, metadata.AWSRegion, metadata.AWSAuthorization)
+		if err != nil {
+			return nil, fmt.Errorf("error getting AWS config: %w", err)
+		}
+		return kafka.OAuthMSKTokenProvider(awsAuth), nil
+	}
+	return kafka.OAuthBearerTokenProvider(metadata.Username, metadata.Password, metadata.OAuthTokenEndpointURI, metadata.Scopes, metadata.OAuthExtensions), nil
+}
+
+func (c *saramaKafkaClient) getTopicPartitions(_ context.Context, topics []string, group string) (map[string][]int32, error) {
+	topicsToDescribe := topics
+
+	// when no topic is specified, query to cg group to fetch all subscribed topics
+	if len(topics) == 0 {
+		listCGOffsetResponse, err := c.admin.ListConsumerGroupOffsets(group, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error listing cg offset: %w", err)
+		}
+
+		if listCGOffsetResponse.Err > 0 {
+			errMsg := fmt.Errorf("error listing cg offset: %w", listCGOffsetResponse.Err)
+			c.logger.Error(errMsg, "")
+		}
+
+		for topicName := range listCGOffsetResponse.Blocks {
+			topicsToDescribe = append(topicsToDescribe, topicName)
+		}
+	}
+
+	topicsMetadata, err := c.admin.DescribeTopics(topicsToDescribe)
+	if err != nil {
+		return nil, fmt.Errorf("error describing topics: %w", err)
+	}
+
+	if len(topics) > 0 && len(topicsMetadata) != len(topics) {
+		return nil, fmt.Errorf("expected %d topic metadata, got %d", len(topics), len(topicsMetadata))
+	}
+
+	topicPartitions := make(map[string][]int32, len(topicsMetadata))
+	for _, topicMetadata := range topicsMetadata {
+		if topicMetadata.Err > 0 {
+			errMsg := fmt.Errorf("error describing topics: %w", topicMetadata.Err)
+			c.logger.Error(errMsg, "")
+		}
+		partitions := make([]int32, 0, len(topicMetadata.Partitions))
+		for _, p := range topicMetadata.Partitions {
+			partitions = append(partitions, p.ID)
+		}
+		topicPartitions[topicMetadata.Name] = partitions
+	}
+	return topicPartitions, nil
+}
+
+func (c *saramaKafkaClient) getConsumerOffsets(_ context.Context, group string, topicPartitions map[string][]int32) (map[string]map[int32]int64, error) {
+	offsets, err := c.admin.ListConsumerGroupOffsets(group, topicPartitions)
+	if err != nil {
+		return nil, fmt.Errorf("error listing consumer group offsets: %w", err)
+	}
+	if offsets.Err > 0 {
+		errMsg := fmt.Errorf("error listing consumer group offsets: %w", offsets.Err)
+		c.logger.Error(errMsg, "")
+	}
+
+	consumerOffsets := make(map[string]map[int32]int64, len(offsets.Blocks))
+	for topic, blocks := range offsets.Blocks {
+		consumerOffsets[topic] = make(map[int32]int64, len(blocks))
+		for partitionID, block := range blocks {
+			if block.Err > 0 {
+				errMsg := fmt.Errorf("error finding offset block for topic %s and partition %d: %w", topic, partitionID, block.Err)
+				c.logger.Error(errMsg, "")
+			}
+			consumerOffsets[topic][partitionID] = block.Offset
+		}
+	}
+	return consumerOffsets, nil
+}
+
+type brokerOffsetResult struct {
+	offsetResp *sarama.OffsetResponse
+	err        error
+}
+
+func (c *saramaKafkaClient) getProducerOffsets(_ context.Context, topicPartitions map[string][]int32) (map[string]map[int32]int64, error) {
+	version := int16(0)
+	if c.client.Config().Version.IsAtLeast(sarama.V0_10_1_0) {
+		version = 1
+	}
+
+	// Step 1: build one OffsetRequest instance per broker.
+	requests := make(map[*sarama.Broker]*sarama.OffsetRequest)
+
+	for topic, partitions := range topicPartitions {
+		for _, partitionID := range partitions {
+			broker, err := c.client.Leader(topic, partitionID)
+			if err != nil {
+				return nil, err
+			}
+			request, ok := requests[broker]
+			if !ok {
+				request = &sarama.OffsetRequest{Version: version}
+				requests[broker] = request
+			}
+			request.AddBlock(topic, partitionID, sarama.OffsetNewest, 1)
+		}
+	}
+
+	// Step 2: send requests, one per broker, and collect topicPartitionsOffsets
+	resultCh := make(chan brokerOffsetResult, len(requests))
+	var wg sync.WaitGroup
+	wg.Add(len(requests))
+	for broker, request := range requests {
+		go func(brCopy *sarama.Broker, reqCopy *sarama.OffsetRequest) {
+			defer wg.Done()
+			response, err := brCopy.GetAvailableOffsets(reqCopy)
+			resultCh <- brokerOffsetResult{response, err}
+		}(broker, request)
+	}
+
+	wg.Wait()
+	close(resultCh)
+
+	topicPartitionsOffsets := make(map[string]map[int32]int64)
+	for brokerOffsetRes := range resultCh {
+		if brokerOffsetRes.err != nil {
+			return nil, brokerOffsetRes.err
+		}
+
+		for topic, blocks := range brokerOffsetRes.offsetResp.Blocks {
+			if _, found := topicPartitionsOffsets[topic]; !found {
+				topicPartitionsOffsets[topic] = make(map[int32]int64)
+			}
+			for partitionID, block := range blocks {
+				if block.Err != sarama.ErrNoError {
+					return nil, block.Err
+				}
+				topicPartitionsOffsets[topic][partitionID] = block.Offset
+			}
+		}
+	}
+
+	return topicPartitionsOffsets, nil
+}
+
+// close closes the kafka admin, the underlying client is also closed on the admin's Close() call
+func (c *saramaKafkaClient) close() error {
+	if c.admin == nil {
+		return nil
+	}
+	return c.admin.Close()
+}
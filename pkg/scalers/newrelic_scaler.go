@@ -1,10 +1,13 @@
 package scalers
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
+	"text/template"
 
 	"github.com/go-logr/logr"
 	"github.com/newrelic/newrelic-client-go/newrelic"
@@ -24,6 +27,7 @@ const (
 	threshold         = "threshold"
 	noDataError       = "noDataError"
 	scalerName        = "new-relic"
+	facetKey          = "facet"
 )
 
 type newrelicScaler struct {
@@ -34,7 +38,7 @@ type newrelicScaler struct {
 }
 
 type newrelicMetadata struct {
-	account             int
+	accounts            []int
 	region              string
 	queryKey            string
 	noDataError         bool
@@ -42,6 +46,15 @@ type newrelicMetadata struct {
 	threshold           float64
 	activationThreshold float64
 	triggerIndex        int
+
+	// facetAggregation aggregates the rows of a FACET query, by default only the first row is used
+	facetAggregation string
+	// accountAggregation aggregates the results of every account
+	accountAggregation string
+	// facetMetrics are the facets exposed as a metric each
+	facetMetrics []string
+	// metricFacets maps the metric names to the facets of facetMetrics
+	metricFacets map[string]string
 }
 
 func NewNewRelicScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
@@ -65,7 +78,7 @@ func NewNewRelicScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		log.Fatal("error initializing client:", err)
 	}
 
-	logMsg := fmt.Sprintf("Initializing New Relic Scaler (account %d in region %s)", meta.account, meta.region)
+	logMsg := fmt.Sprintf("Initializing New Relic Scaler (accounts %v in region %s)", meta.accounts, meta.region)
 
 	logger.Info(logMsg)
 
@@ -85,18 +98,56 @@ func parseNewRelicMetadata(config *scalersconfig.ScalerConfig, logger logr.Logge
 		return nil, err
 	}
 
-	t, err := strconv.Atoi(val)
-	if err != nil {
-		return nil, fmt.Errorf("error parsing %s: %w", account, err)
+	// several accounts can be given as a comma separated list
+	for _, accountID := range strings.Split(val, ",") {
+		t, err := strconv.Atoi(strings.TrimSpace(accountID))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", account, err)
+		}
+		meta.accounts = append(meta.accounts, t)
 	}
-	meta.account = t
 
 	if val, ok := config.TriggerMetadata[nrql]; ok && val != "" {
-		meta.nrql = val
+		query, err := renderNewRelicQuery(val, config)
+		if err != nil {
+			return nil, err
+		}
+		meta.nrql = query
 	} else {
 		return nil, fmt.Errorf("no %s given", nrql)
 	}
 
+	meta.accountAggregation = sumOperation
+	if val, ok := config.TriggerMetadata["accountAggregation"]; ok && val != "" {
+		if !isNewRelicAggregation(val) {
+			return nil, fmt.Errorf("accountAggregation has to be one of %s, %s or %s", sumOperation, avgOperation, maxOperation)
+		}
+		meta.accountAggregation = val
+	}
+
+	if val, ok := config.TriggerMetadata["facetAggregation"]; ok && val != "" {
+		if !isNewRelicAggregation(val) {
+			return nil, fmt.Errorf("facetAggregation has to be one of %s, %s or %s", sumOperation, avgOperation, maxOperation)
+		}
+		meta.facetAggregation = val
+	}
+
+	if val, ok := config.TriggerMetadata["facetMetrics"]; ok && val != "" {
+		if meta.facetAggregation != "" {
+			return nil, fmt.Errorf("only one of facetAggregation or facetMetrics can be given")
+		}
+		meta.metricFacets = make(map[string]string)
+		for _, facet := range strings.Split(val, ",") {
+			facet = strings.TrimSpace(facet)
+			metricName := GenerateMetricNameWithIndex(config.TriggerIndex, kedautil.NormalizeString(fmt.Sprintf("%s-%s", scalerName, facet)))
+			if _, ok := meta.metricFacets[metricName]; ok {
+				return nil, fmt.Errorf("facet %s is given twice", facet)
+			}
+			meta.facetMetrics = append(meta.facetMetrics, facet)
+			meta.metricFacets[metricName] = facet
+		}
+	}
+
 	queryKey, err := GetFromAuthOrMeta(config, queryKeyParamater)
 	if err != nil {
 		return nil, err
@@ -152,34 +203,134 @@ func (s *newrelicScaler) Close(context.Context) error {
 	return nil
 }
 
-func (s *newrelicScaler) executeNewRelicQuery(ctx context.Context) (float64, error) {
-	nrdbQuery := nrdb.NRQL(s.metadata.nrql)
-	resp, err := s.nrClient.Nrdb.QueryWithContext(ctx, s.metadata.account, nrdbQuery)
+// renderNewRelicQuery fills the {{.Namespace}}, {{.Name}} and {{.Kind}} variables of the NRQL with the scaled object
+func renderNewRelicQuery(query string, config *scalersconfig.ScalerConfig) (string, error) {
+	tmpl, err := template.New(nrql).Option("missingkey=error").Parse(query)
 	if err != nil {
-		return 0, fmt.Errorf("error running NRQL %s (%s)", s.metadata.nrql, err.Error())
+		return "", fmt.Errorf("error parsing %s: %w", nrql, err)
 	}
-	// Check for empty results set, as New Relic lib does not report these as errors
-	if len(resp.Results) == 0 {
-		if s.metadata.noDataError {
-			return 0, fmt.Errorf("query return no results %s", s.metadata.nrql)
+	var buf bytes.Buffer
+	variables := map[string]string{
+		"Namespace": config.ScalableObjectNamespace,
+		"Name":      config.ScalableObjectName,
+		"Kind":      config.ScalableObjectType,
+	}
+	if err := tmpl.Execute(&buf, variables); err != nil {
+		return "", fmt.Errorf("error filling the %s variables: %w", nrql, err)
+	}
+	return buf.String(), nil
+}
+
+func isNewRelicAggregation(aggregation string) bool {
+	switch aggregation {
+	case sumOperation, avgOperation, maxOperation:
+		return true
+	default:
+		return false
+	}
+}
+
+func aggregateNewRelicValues(values []float64, aggregation string) float64 {
+	switch aggregation {
+	case avgOperation:
+		return AvgFloatFromSlice(values)
+	case maxOperation:
+		return MaxFloatFromSlice(values)
+	default:
+		sum := 0.0
+		for _, value := range values {
+			sum += value
 		}
-		return 0, nil
+		return sum
 	}
-	// Only use the first result from the query, as the query should not be multi row
-	for _, v := range resp.Results[0] {
+}
+
+// getNewRelicResultValue returns the first numeric value of a result row
+func getNewRelicResultValue(result nrdb.NRDBResult) (float64, bool) {
+	for k, v := range result {
+		if k == facetKey {
+			continue
+		}
 		val, ok := v.(float64)
 		if ok {
-			return val, nil
+			return val, true
 		}
 	}
-	if s.metadata.noDataError {
-		return 0, fmt.Errorf("query return no results %s", s.metadata.nrql)
+	return 0, false
+}
+
+// getNewRelicResultFacet returns the facet of a result row, the facets of a FACET on several attributes are joined by commas
+func getNewRelicResultFacet(result nrdb.NRDBResult) string {
+	switch facet := result[facetKey].(type) {
+	case string:
+		return facet
+	case []interface{}:
+		facets := make([]string, len(facet))
+		for i, f := range facet {
+			facets[i] = fmt.Sprint(f)
+		}
+		return strings.Join(facets, ",")
+	default:
+		return ""
 	}
-	return 0, nil
+}
+
+// getNewRelicValue picks the value of the facet if given, or aggregates the values of the rows with the facetAggregation
+func getNewRelicValue(results []nrdb.NRDBResult, facet string, facetAggregation string) (float64, bool) {
+	if facet != "" {
+		for _, result := range results {
+			if getNewRelicResultFacet(result) == facet {
+				return getNewRelicResultValue(result)
+			}
+		}
+		return 0, false
+	}
+
+	if facetAggregation == "" {
+		// Only use the first result from the query, as the query should not be multi row
+		if len(results) == 0 {
+			return 0, false
+		}
+		return getNewRelicResultValue(results[0])
+	}
+
+	var values []float64
+	for _, result := range results {
+		if val, ok := getNewRelicResultValue(result); ok {
+			values = append(values, val)
+		}
+	}
+	if len(values) == 0 {
+		return 0, false
+	}
+	return aggregateNewRelicValues(values, facetAggregation), true
+}
+
+// executeNewRelicQuery runs the query on every account and aggregates the values of the accounts returning data
+func (s *newrelicScaler) executeNewRelicQuery(ctx context.Context, facet string) (float64, error) {
+	nrdbQuery := nrdb.NRQL(s.metadata.nrql)
+	var values []float64
+	for _, accountID := range s.metadata.accounts {
+		resp, err := s.nrClient.Nrdb.QueryWithContext(ctx, accountID, nrdbQuery)
+		if err != nil {
+			return 0, fmt.Errorf("error running NRQL %s on account %d (%s)", s.metadata.nrql, accountID, err.Error())
+		}
+		// Empty results sets are skipped, as New Relic lib does not report these as errors
+		if val, ok := getNewRelicValue(resp.Results, facet, s.metadata.facetAggregation); ok {
+			values = append(values, val)
+		}
+	}
+	if len(values) == 0 {
+		if s.metadata.noDataError {
+			return 0, fmt.Errorf("query return no results %s", s.metadata.nrql)
+		}
+		return 0, nil
+	}
+	return aggregateNewRelicValues(values, s.metadata.accountAggregation), nil
 }
 
 func (s *newrelicScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	val, err := s.executeNewRelicQuery(ctx)
+	val, err := s.executeNewRelicQuery(ctx, s.metadata.metricFacets[metricName])
 	if err != nil {
 		s.logger.Error(err, "error executing NRQL query")
 		return []external_metrics.ExternalMetricValue{}, false, err
@@ -191,6 +342,20 @@ func (s *newrelicScaler) GetMetricsAndActivity(ctx context.Context, metricName s
 }
 
 func (s *newrelicScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	if len(s.metadata.facetMetrics) > 0 {
+		metricSpecs := make([]v2.MetricSpec, 0, len(s.metadata.facetMetrics))
+		for _, facet := range s.metadata.facetMetrics {
+			externalMetric := &v2.ExternalMetricSource{
+				Metric: v2.MetricIdentifier{
+					Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("%s-%s", scalerName, facet))),
+				},
+				Target: GetMetricTargetMili(s.metricType, s.metadata.threshold),
+			}
+			metricSpecs = append(metricSpecs, v2.MetricSpec{External: externalMetric, Type: externalMetricType})
+		}
+		return metricSpecs
+	}
+
 	metricName := kedautil.NormalizeString(scalerName)
 
 	externalMetric := &v2.ExternalMetricSource{
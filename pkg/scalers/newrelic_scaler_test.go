@@ -6,6 +6,9 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	"github.com/newrelic/newrelic-client-go/pkg/nrdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
@@ -53,11 +56,30 @@ var testNewRelicMetadata = []parseNewRelicMetadataTestData{
 	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "noDataError": "false", "nrql": "SELECT average(cpuUsedCores) as result FROM K8sContainerSample WHERE containerName='coredns'"}, map[string]string{}, false},
 	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "noDataError": "0", "nrql": "SELECT average(cpuUsedCores) as result FROM K8sContainerSample WHERE containerName='coredns'"}, map[string]string{}, false},
 	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "noDataError": "1", "nrql": "SELECT average(cpuUsedCores) as result FROM K8sContainerSample WHERE containerName='coredns'"}, map[string]string{}, false},
+	// several accounts
+	{map[string]string{"account": "1, 2", "threshold": "100", "queryKey": "somekey", "accountAggregation": "max", "nrql": "SELECT average(cpuUsedCores) as result FROM K8sContainerSample WHERE containerName='coredns'"}, map[string]string{}, false},
+	// malformed account in the list
+	{map[string]string{"account": "1,ABC", "threshold": "100", "queryKey": "somekey", "nrql": "SELECT average(cpuUsedCores) as result FROM K8sContainerSample WHERE containerName='coredns'"}, map[string]string{}, true},
+	// invalid accountAggregation
+	{map[string]string{"account": "1,2", "threshold": "100", "queryKey": "somekey", "accountAggregation": "min", "nrql": "SELECT average(cpuUsedCores) as result FROM K8sContainerSample WHERE containerName='coredns'"}, map[string]string{}, true},
+	// facetAggregation
+	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "facetAggregation": "sum", "nrql": "SELECT average(cpuUsedCores) FROM K8sContainerSample FACET containerName"}, map[string]string{}, false},
+	// invalid facetAggregation
+	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "facetAggregation": "first", "nrql": "SELECT average(cpuUsedCores) FROM K8sContainerSample FACET containerName"}, map[string]string{}, true},
+	// facetMetrics
+	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "facetMetrics": "coredns,kube-proxy", "nrql": "SELECT average(cpuUsedCores) FROM K8sContainerSample FACET containerName"}, map[string]string{}, false},
+	// both facetAggregation and facetMetrics
+	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "facetAggregation": "sum", "facetMetrics": "coredns", "nrql": "SELECT average(cpuUsedCores) FROM K8sContainerSample FACET containerName"}, map[string]string{}, true},
+	// query variables
+	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "nrql": "SELECT average(cpuUsedCores) FROM K8sContainerSample WHERE namespace = '{{.Namespace}}'"}, map[string]string{}, false},
+	// unknown query variable
+	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "nrql": "SELECT average(cpuUsedCores) FROM K8sContainerSample WHERE namespace = '{{.Cluster}}'"}, map[string]string{}, true},
 }
 
 var newrelicMetricIdentifiers = []newrelicMetricIdentifier{
 	{&testNewRelicMetadata[1], 0, "s0-new-relic"},
 	{&testNewRelicMetadata[1], 1, "s1-new-relic"},
+	{&testNewRelicMetadata[22], 0, "s0-new-relic-coredns"},
 }
 
 func TestNewRelicParseMetadata(t *testing.T) {
@@ -91,3 +113,49 @@ func TestNewRelicGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestNewRelicQueryVariables(t *testing.T) {
+	meta, err := parseNewRelicMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata:         testNewRelicMetadata[24].metadata,
+		AuthParams:              testNewRelicMetadata[24].authParams,
+		ScalableObjectNamespace: "kube-system",
+		ScalableObjectName:      "coredns",
+	}, logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT average(cpuUsedCores) FROM K8sContainerSample WHERE namespace = 'kube-system'", meta.nrql)
+}
+
+func TestNewRelicGetValue(t *testing.T) {
+	results := []nrdb.NRDBResult{
+		{"facet": "coredns", "containerName": "coredns", "average.cpuUsedCores": 2.0},
+		{"facet": "kube-proxy", "containerName": "kube-proxy", "average.cpuUsedCores": 4.0},
+		{"facet": []interface{}{"kube-system", "etcd"}, "average.cpuUsedCores": 6.0},
+	}
+
+	tests := []struct {
+		name             string
+		results          []nrdb.NRDBResult
+		facet            string
+		facetAggregation string
+		expected         float64
+		found            bool
+	}{
+		{name: "first row", results: results, expected: 2, found: true},
+		{name: "sum of the facets", results: results, facetAggregation: sumOperation, expected: 12, found: true},
+		{name: "average of the facets", results: results, facetAggregation: avgOperation, expected: 4, found: true},
+		{name: "max of the facets", results: results, facetAggregation: maxOperation, expected: 6, found: true},
+		{name: "single facet", results: results, facet: "kube-proxy", expected: 4, found: true},
+		{name: "facet on several attributes", results: results, facet: "kube-system,etcd", expected: 6, found: true},
+		{name: "missing facet", results: results, facet: "kubelet", found: false},
+		{name: "no results", results: nil, found: false},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			value, found := getNewRelicValue(tt.results, tt.facet, tt.facetAggregation)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
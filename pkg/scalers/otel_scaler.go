@@ -0,0 +1,138 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/xhit/go-str2duration/v2"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/otlpreceiver"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// otelScaler queries the OpenTelemetry metrics of its namespace received by the OTLP receiver of the operator,
+// the exports of the namespace are authenticated with the bearer token of the TriggerAuthentication of the trigger
+type otelScaler struct {
+	metricType  v2.MetricTargetType
+	metadata    *otelMetadata
+	store       *otlpreceiver.Store
+	namespace   string
+	revokeToken func()
+	logger      logr.Logger
+}
+
+type otelMetadata struct {
+	triggerIndex int
+
+	MetricName            string  `keda:"name=metricName,            order=triggerMetadata"`
+	LabelSelector         string  `keda:"name=labelSelector,         order=triggerMetadata, optional"`
+	Window                string  `keda:"name=window,                order=triggerMetadata, default=1m"`
+	Aggregation           string  `keda:"name=aggregation,           order=triggerMetadata, enum=last;avg;min;max;sum;rate, default=last"`
+	SeriesAggregation     string  `keda:"name=seriesAggregation,     order=triggerMetadata, enum=sum;avg;min;max, default=sum"`
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
+
+	BearerToken string `keda:"name=bearerToken, order=authParams"`
+
+	selector labels.Selector
+	window   time.Duration
+}
+
+// NewOtelScaler creates a new otelScaler
+func NewOtelScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseOtelMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing otel metadata: %w", err)
+	}
+
+	store := otlpreceiver.GetDefaultStore()
+	if store == nil {
+		return nil, fmt.Errorf("the OTLP receiver of the operator isn't enabled, set --otlp-grpc-bind-address or --otlp-http-bind-address")
+	}
+
+	return &otelScaler{
+		metricType:  metricType,
+		metadata:    meta,
+		store:       store,
+		namespace:   config.ScalableObjectNamespace,
+		revokeToken: store.AllowToken(config.ScalableObjectNamespace, meta.BearerToken),
+		logger:      InitializeLogger(config, "otel_scaler"),
+	}, nil
+}
+
+func parseOtelMetadata(config *scalersconfig.ScalerConfig) (*otelMetadata, error) {
+	meta := &otelMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	meta.triggerIndex = config.TriggerIndex
+
+	selector, err := labels.Parse(meta.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid labelSelector: %w", err)
+	}
+	meta.selector = selector
+
+	window, err := str2duration.ParseDuration(meta.Window)
+	if err != nil {
+		return nil, fmt.Errorf("window parsing error %w", err)
+	}
+	if window <= 0 {
+		return nil, fmt.Errorf("window must be greater than 0")
+	}
+	meta.window = window
+
+	if !config.AsMetricSource && meta.TargetValue <= 0 {
+		return nil, fmt.Errorf("targetValue must be greater than 0")
+	}
+	return meta, nil
+}
+
+func (s *otelScaler) Close(context.Context) error {
+	s.revokeToken()
+	return nil
+}
+
+func (s *otelScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("otel-%s", s.metadata.MetricName))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the aggregation of the received series, 0 when nothing was received in the window
+func (s *otelScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	val, seriesCount, err := s.store.Query(otlpreceiver.Query{
+		Namespace:         s.namespace,
+		MetricName:        s.metadata.MetricName,
+		Selector:          s.metadata.selector,
+		Window:            s.metadata.window,
+		Aggregation:       s.metadata.Aggregation,
+		SeriesAggregation: s.metadata.SeriesAggregation,
+	}, time.Now())
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error querying the received metrics: %w", err)
+	}
+	s.logger.V(1).Info("Queried the received metrics", "value", val, "series", seriesCount)
+
+	metric := GenerateMetricInMili(metricName, val)
+
+	return []external_metrics.ExternalMetricValue{metric}, val > s.metadata.ActivationTargetValue, nil
+}
@@ -0,0 +1,146 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/otlpreceiver"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseOtelMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+}
+
+type otelMetricIdentifier struct {
+	metadataTestData *parseOtelMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testOtelMetadata = []parseOtelMetadataTestData{
+	{map[string]string{}, true},
+	// all properly formed
+	{map[string]string{"metricName": "queue.depth", "targetValue": "10"}, false},
+	// all properly formed, with all the options
+	{map[string]string{"metricName": "queue.depth", "labelSelector": "service.name=worker,queue in (orders,payments)", "window": "5m", "aggregation": "max", "seriesAggregation": "avg", "targetValue": "10", "activationTargetValue": "2"}, false},
+	// missing metricName
+	{map[string]string{"targetValue": "10"}, true},
+	// invalid labelSelector
+	{map[string]string{"metricName": "queue.depth", "labelSelector": "queue in", "targetValue": "10"}, true},
+	// invalid window
+	{map[string]string{"metricName": "queue.depth", "window": "a while", "targetValue": "10"}, true},
+	// zero window
+	{map[string]string{"metricName": "queue.depth", "window": "0s", "targetValue": "10"}, true},
+	// invalid aggregation
+	{map[string]string{"metricName": "queue.depth", "aggregation": "median", "targetValue": "10"}, true},
+	// invalid seriesAggregation
+	{map[string]string{"metricName": "queue.depth", "seriesAggregation": "last", "targetValue": "10"}, true},
+	// missing targetValue
+	{map[string]string{"metricName": "queue.depth"}, true},
+}
+
+var otelMetricIdentifiers = []otelMetricIdentifier{
+	{&testOtelMetadata[1], 0, "s0-otel-queue-depth"},
+	{&testOtelMetadata[1], 1, "s1-otel-queue-depth"},
+}
+
+var testOtelAuthParams = map[string]string{"bearerToken": "token"}
+
+func setTestOtelStore(t *testing.T, store *otlpreceiver.Store) {
+	otlpreceiver.SetDefaultStore(store)
+	t.Cleanup(func() { otlpreceiver.SetDefaultStore(nil) })
+}
+
+func TestOtelParseMetadata(t *testing.T) {
+	setTestOtelStore(t, otlpreceiver.NewStore(10*time.Minute, 100))
+	for _, testData := range testOtelMetadata {
+		_, err := NewOtelScaler(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testOtelAuthParams})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for %v", testData.metadata)
+		}
+	}
+
+	_, err := NewOtelScaler(&scalersconfig.ScalerConfig{TriggerMetadata: testOtelMetadata[1].metadata})
+	assert.ErrorContains(t, err, "bearerToken", "the exports must be authenticated")
+}
+
+func TestOtelReceiverDisabled(t *testing.T) {
+	_, err := NewOtelScaler(&scalersconfig.ScalerConfig{TriggerMetadata: testOtelMetadata[1].metadata, AuthParams: testOtelAuthParams})
+	assert.ErrorContains(t, err, "OTLP receiver")
+}
+
+func TestOtelGetMetricSpecForScaling(t *testing.T) {
+	setTestOtelStore(t, otlpreceiver.NewStore(10*time.Minute, 100))
+	for _, testData := range otelMetricIdentifiers {
+		scaler, err := NewOtelScaler(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testOtelAuthParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+
+		metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName, testData.name)
+		}
+	}
+}
+
+func TestOtelGetMetricsAndActivity(t *testing.T) {
+	now := time.Now()
+	store := otlpreceiver.NewStore(10*time.Minute, 100)
+	setTestOtelStore(t, store)
+	orders := otlpreceiver.SeriesRef{Namespace: "default", MetricName: "queue.depth", Labels: map[string]string{"queue": "orders"}}
+	payments := otlpreceiver.SeriesRef{Namespace: "default", MetricName: "queue.depth", Labels: map[string]string{"queue": "payments"}}
+	otherNamespace := otlpreceiver.SeriesRef{Namespace: "other", MetricName: "queue.depth", Labels: map[string]string{"queue": "orders"}}
+	store.Append(orders, now.Add(-2*time.Minute), 40, now)
+	store.Append(orders, now.Add(-30*time.Second), 6, now)
+	store.Append(payments, now.Add(-30*time.Second), 3, now)
+	store.Append(otherNamespace, now.Add(-30*time.Second), 100, now)
+
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		value    int64
+		active   bool
+	}{
+		{"sum of the last values", map[string]string{"metricName": "queue.depth", "targetValue": "10"}, 9, true},
+		{"selected series", map[string]string{"metricName": "queue.depth", "labelSelector": "queue=orders", "targetValue": "10"}, 6, true},
+		{"max over the window", map[string]string{"metricName": "queue.depth", "labelSelector": "queue=orders", "window": "5m", "aggregation": "max", "targetValue": "10"}, 40, true},
+		{"below the activation", map[string]string{"metricName": "queue.depth", "targetValue": "10", "activationTargetValue": "9"}, 9, false},
+		{"nothing received", map[string]string{"metricName": "jobs.pending", "targetValue": "10"}, 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scaler, err := NewOtelScaler(&scalersconfig.ScalerConfig{TriggerMetadata: test.metadata, AuthParams: testOtelAuthParams, ScalableObjectNamespace: "default"})
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = scaler.Close(context.Background()) })
+
+			metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "s0-otel-queue-depth")
+			require.NoError(t, err)
+			assert.Equal(t, test.value, metrics[0].Value.Value())
+			assert.Equal(t, test.active, active)
+		})
+	}
+}
+
+func TestOtelAllowsTheTokenOfItsNamespace(t *testing.T) {
+	store := otlpreceiver.NewStore(10*time.Minute, 100)
+	setTestOtelStore(t, store)
+
+	scaler, err := NewOtelScaler(&scalersconfig.ScalerConfig{TriggerMetadata: testOtelMetadata[1].metadata, AuthParams: testOtelAuthParams, ScalableObjectNamespace: "default"})
+	require.NoError(t, err)
+	assert.True(t, store.IsTokenAllowed("token", "default"))
+	assert.False(t, store.IsTokenAllowed("token", "other"))
+
+	require.NoError(t, scaler.Close(context.Background()))
+	assert.False(t, store.IsTokenAllowed("token", ""), "the token must be revoked once the scaler is closed")
+}
@@ -0,0 +1,390 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/go-logr/logr"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	v2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	podMetricsAggregationSum = "sum"
+	podMetricsAggregationAvg = "avg"
+	podMetricsAggregationMax = "max"
+
+	// podMetricsMaxConcurrentScrapes bounds the pods scraped at the same time by a scaler
+	podMetricsMaxConcurrentScrapes = 10
+)
+
+// podMetricsScaler scrapes a Prometheus metric from the pods of the scale target and aggregates it across the pods,
+// the pods are found with the selector of the scale target unless a podSelector is set
+type podMetricsScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *podMetricsMetadata
+	kubeClient client.Client
+	podReader  client.Reader
+	httpClient *http.Client
+	logger     logr.Logger
+
+	scalableObjectName      string
+	scalableObjectNamespace string
+	scalableObjectType      string
+}
+
+type podMetricsMetadata struct {
+	triggerIndex int
+
+	MetricName            string            `keda:"name=metricName,            order=triggerMetadata"`
+	LabelMatcher          map[string]string `keda:"name=labelMatcher,          order=triggerMetadata, optional"`
+	Port                  string            `keda:"name=port,                  order=triggerMetadata"`
+	Path                  string            `keda:"name=path,                  order=triggerMetadata, default=/metrics"`
+	Scheme                string            `keda:"name=scheme,                order=triggerMetadata, enum=http;https, default=http"`
+	Aggregation           string            `keda:"name=aggregation,           order=triggerMetadata, enum=sum;avg;max, default=sum"`
+	PodSelector           string            `keda:"name=podSelector,           order=triggerMetadata, optional"`
+	TargetValue           float64           `keda:"name=targetValue,           order=triggerMetadata, optional"`
+	ActivationTargetValue float64           `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
+	UnsafeSsl             bool              `keda:"name=unsafeSsl,             order=triggerMetadata, default=false"`
+
+	BearerToken string `keda:"name=bearerToken, order=authParams, optional"`
+
+	podSelector labels.Selector
+}
+
+// NewPodMetricsScaler creates a new podMetricsScaler, the pods are listed with the podReader which reads from
+// the API server directly, so the Pods of the cluster aren't cached by the operator
+func NewPodMetricsScaler(kubeClient client.Client, podReader client.Reader, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parsePodMetricsMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing pod metrics metadata: %w", err)
+	}
+
+	return &podMetricsScaler{
+		metricType:              metricType,
+		metadata:                meta,
+		kubeClient:              kubeClient,
+		podReader:               podReader,
+		httpClient:              kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl),
+		logger:                  InitializeLogger(config, "pod_metrics_scaler"),
+		scalableObjectName:      config.ScalableObjectName,
+		scalableObjectNamespace: config.ScalableObjectNamespace,
+		scalableObjectType:      config.ScalableObjectType,
+	}, nil
+}
+
+func parsePodMetricsMetadata(config *scalersconfig.ScalerConfig) (*podMetricsMetadata, error) {
+	meta := &podMetricsMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	meta.triggerIndex = config.TriggerIndex
+
+	if meta.PodSelector != "" {
+		podSelector, err := labels.Parse(meta.PodSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid podSelector: %w", err)
+		}
+		meta.podSelector = podSelector
+	}
+	if port, err := strconv.Atoi(meta.Port); err == nil && (port <= 0 || port > 65535) {
+		return nil, fmt.Errorf("port must be between 1 and 65535")
+	}
+	if !config.AsMetricSource && meta.TargetValue <= 0 {
+		return nil, fmt.Errorf("targetValue must be greater than 0")
+	}
+	return meta, nil
+}
+
+func (s *podMetricsScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+func (s *podMetricsScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("pod-metrics-%s", s.metadata.MetricName))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *podMetricsScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	val, err := s.getMetricValue(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error scraping the pods metrics: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, val)
+
+	return []external_metrics.ExternalMetricValue{metric}, val > s.metadata.ActivationTargetValue, nil
+}
+
+// getMetricValue aggregates the values of the pods which could be scraped
+func (s *podMetricsScaler) getMetricValue(ctx context.Context) (float64, error) {
+	podValues, err := s.GetPodMetrics(ctx)
+	if err != nil || len(podValues) == 0 {
+		return 0, err
+	}
+
+	values := make([]float64, 0, len(podValues))
+	for _, value := range podValues {
+		values = append(values, value)
+	}
+	return aggregatePodMetrics(s.metadata.Aggregation, values), nil
+}
+
+// GetPodMetrics scrapes the running pods in parallel, at most podMetricsMaxConcurrentScrapes at a time,
+// the pods which can't be scraped are left out, it fails only when none of the pods can be scraped
+func (s *podMetricsScaler) GetPodMetrics(ctx context.Context) (map[string]float64, error) {
+	selector, err := s.getPodSelector(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	podList := &corev1.PodList{}
+	if err := s.podReader.List(ctx, podList, client.InNamespace(s.scalableObjectNamespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+
+	var pods []*corev1.Pod
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.Phase == corev1.PodRunning && pod.Status.PodIP != "" && pod.DeletionTimestamp == nil {
+			pods = append(pods, pod)
+		}
+	}
+	if len(pods) == 0 {
+		return map[string]float64{}, nil
+	}
+
+	values := make([]float64, len(pods))
+	errs := make([]error, len(pods))
+	wg := sync.WaitGroup{}
+	scrapes := make(chan struct{}, podMetricsMaxConcurrentScrapes)
+	for i, pod := range pods {
+		wg.Add(1)
+		scrapes <- struct{}{}
+		go func(i int, pod *corev1.Pod) {
+			defer func() {
+				<-scrapes
+				wg.Done()
+			}()
+			values[i], errs[i] = s.scrapePod(ctx, pod)
+		}(i, pod)
+	}
+	wg.Wait()
+
+	scraped := map[string]float64{}
+	for i, err := range errs {
+		if err != nil {
+			s.logger.V(1).Info("Error scraping the pod metrics", "pod", pods[i].Name, "error", err.Error())
+			continue
+		}
+		scraped[pods[i].Name] = values[i]
+	}
+	if len(scraped) == 0 {
+		return nil, fmt.Errorf("none of the %d pods could be scraped: %w", len(pods), errors.Join(errs...))
+	}
+	return scraped, nil
+}
+
+// getPodSelector returns the podSelector of the metadata, or the selector of the pods of the scale target
+func (s *podMetricsScaler) getPodSelector(ctx context.Context) (labels.Selector, error) {
+	if s.metadata.podSelector != nil {
+		return s.metadata.podSelector, nil
+	}
+	return GetScaleTargetPodSelector(ctx, s.kubeClient, s.scalableObjectType, s.scalableObjectName, s.scalableObjectNamespace)
+}
+
+// GetScaleTargetPodSelector returns the selector of the pods of a ScaledJob, or of the scale target of a ScaledObject
+// read from its spec.selector
+func GetScaleTargetPodSelector(ctx context.Context, kubeClient client.Client, scalableObjectType, scalableObjectName, scalableObjectNamespace string) (labels.Selector, error) {
+	if scalableObjectType == "ScaledJob" {
+		return labels.SelectorFromSet(labels.Set{"scaledjob.keda.sh/name": scalableObjectName}), nil
+	}
+
+	scaledObject := &kedav1alpha1.ScaledObject{}
+	if err := kubeClient.Get(ctx, types.NamespacedName{Name: scalableObjectName, Namespace: scalableObjectNamespace}, scaledObject); err != nil {
+		return nil, fmt.Errorf("error getting the scaledObject: %w", err)
+	}
+	scaleTargetRef := scaledObject.Spec.ScaleTargetRef
+	if scaleTargetRef == nil {
+		return nil, fmt.Errorf("scaledObject has no scaleTargetRef")
+	}
+	apiVersion, kind := scaleTargetRef.APIVersion, scaleTargetRef.Kind
+	if apiVersion == "" {
+		apiVersion = "apps/v1"
+	}
+	if kind == "" {
+		kind = "Deployment"
+	}
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scaleTargetRef apiVersion: %w", err)
+	}
+
+	target := &unstructured.Unstructured{}
+	target.SetGroupVersionKind(gv.WithKind(kind))
+	if err := kubeClient.Get(ctx, types.NamespacedName{Name: scaleTargetRef.Name, Namespace: scalableObjectNamespace}, target); err != nil {
+		return nil, fmt.Errorf("error getting the scale target: %w", err)
+	}
+	selectorMap, found, err := unstructured.NestedMap(target.Object, "spec", "selector")
+	if err != nil || !found {
+		return nil, fmt.Errorf("scale target %s/%s has no spec.selector", kind, scaleTargetRef.Name)
+	}
+	labelSelector := &metav1.LabelSelector{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(selectorMap, labelSelector); err != nil {
+		return nil, fmt.Errorf("invalid spec.selector of the scale target: %w", err)
+	}
+	return metav1.LabelSelectorAsSelector(labelSelector)
+}
+
+// scrapePod returns the sum of the series of the metric matching the label matcher exposed by the pod
+func (s *podMetricsScaler) scrapePod(ctx context.Context, pod *corev1.Pod) (float64, error) {
+	port, err := podMetricsPort(pod, s.metadata.Port)
+	if err != nil {
+		return 0, err
+	}
+	url := fmt.Sprintf("%s://%s%s", s.metadata.Scheme, net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(port)), s.metadata.Path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", string(expfmt.FmtText))
+	if s.metadata.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.metadata.BearerToken)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("%s returned %d", url, resp.StatusCode)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing the metrics of %s: %w", url, err)
+	}
+	family, ok := families[s.metadata.MetricName]
+	if !ok {
+		return 0, fmt.Errorf("metric %s not found", s.metadata.MetricName)
+	}
+
+	found := false
+	value := float64(0)
+	for _, metric := range family.GetMetric() {
+		if !matchesPodMetricLabels(metric, s.metadata.LabelMatcher) {
+			continue
+		}
+		sample, ok := podMetricSample(metric)
+		if !ok {
+			return 0, fmt.Errorf("metric %s of type %s isn't supported, only counters, gauges and untyped metrics are", s.metadata.MetricName, family.GetType())
+		}
+		found = true
+		value += sample
+	}
+	if !found {
+		return 0, fmt.Errorf("metric %s has no series matching %v", s.metadata.MetricName, s.metadata.LabelMatcher)
+	}
+	return value, nil
+}
+
+// podMetricsPort returns the port number, the port can also be the name of a container port of the pod
+func podMetricsPort(pod *corev1.Pod, port string) (int, error) {
+	if number, err := strconv.Atoi(port); err == nil {
+		return number, nil
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Name == port {
+				return int(containerPort.ContainerPort), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("pod %s has no container port named %s", pod.Name, port)
+}
+
+func matchesPodMetricLabels(metric *dto.Metric, matcher map[string]string) bool {
+	for name, value := range matcher {
+		matched := false
+		for _, label := range metric.GetLabel() {
+			if label.GetName() == name {
+				matched = label.GetValue() == value
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func podMetricSample(metric *dto.Metric) (float64, bool) {
+	switch {
+	case metric.Gauge != nil:
+		return metric.Gauge.GetValue(), true
+	case metric.Counter != nil:
+		return metric.Counter.GetValue(), true
+	case metric.Untyped != nil:
+		return metric.Untyped.GetValue(), true
+	default:
+		return 0, false
+	}
+}
+
+func aggregatePodMetrics(aggregation string, values []float64) float64 {
+	switch aggregation {
+	case podMetricsAggregationMax:
+		result := values[0]
+		for _, value := range values[1:] {
+			result = math.Max(result, value)
+		}
+		return result
+	case podMetricsAggregationAvg:
+		return aggregatePodMetrics(podMetricsAggregationSum, values) / float64(len(values))
+	default:
+		result := float64(0)
+		for _, value := range values {
+			result += value
+		}
+		return result
+	}
+}
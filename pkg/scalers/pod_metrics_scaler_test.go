@@ -0,0 +1,260 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parsePodMetricsMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+}
+
+type podMetricsMetricIdentifier struct {
+	metadataTestData *parsePodMetricsMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testPodMetricsMetadata = []parsePodMetricsMetadataTestData{
+	{map[string]string{}, true},
+	// all properly formed
+	{map[string]string{"metricName": "queue_depth", "port": "9090", "targetValue": "10"}, false},
+	// all properly formed, with all the options
+	{map[string]string{"metricName": "queue_depth", "labelMatcher": "queue=orders,tenant=a", "port": "metrics", "path": "/stats", "scheme": "https", "aggregation": "max", "podSelector": "app=worker", "targetValue": "10", "activationTargetValue": "2", "unsafeSsl": "true"}, false},
+	// missing metricName
+	{map[string]string{"port": "9090", "targetValue": "10"}, true},
+	// missing port
+	{map[string]string{"metricName": "queue_depth", "targetValue": "10"}, true},
+	// invalid port
+	{map[string]string{"metricName": "queue_depth", "port": "70000", "targetValue": "10"}, true},
+	// invalid aggregation
+	{map[string]string{"metricName": "queue_depth", "port": "9090", "targetValue": "10", "aggregation": "min"}, true},
+	// invalid podSelector
+	{map[string]string{"metricName": "queue_depth", "port": "9090", "targetValue": "10", "podSelector": "app in"}, true},
+	// missing targetValue
+	{map[string]string{"metricName": "queue_depth", "port": "9090"}, true},
+}
+
+var podMetricsMetricIdentifiers = []podMetricsMetricIdentifier{
+	{&testPodMetricsMetadata[1], 0, "s0-pod-metrics-queue_depth"},
+	{&testPodMetricsMetadata[1], 1, "s1-pod-metrics-queue_depth"},
+}
+
+func TestPodMetricsParseMetadata(t *testing.T) {
+	for _, testData := range testPodMetricsMetadata {
+		_, err := NewPodMetricsScaler(fake.NewClientBuilder().Build(), fake.NewClientBuilder().Build(), &scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for %v", testData.metadata)
+		}
+	}
+}
+
+func TestPodMetricsGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range podMetricsMetricIdentifiers {
+		scaler, err := NewPodMetricsScaler(fake.NewClientBuilder().Build(), fake.NewClientBuilder().Build(), &scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+
+		metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName, testData.name)
+		}
+	}
+}
+
+func TestAggregatePodMetrics(t *testing.T) {
+	values := []float64{4, 10, 1}
+	assert.Equal(t, float64(15), aggregatePodMetrics(podMetricsAggregationSum, values))
+	assert.Equal(t, float64(5), aggregatePodMetrics(podMetricsAggregationAvg, values))
+	assert.Equal(t, float64(10), aggregatePodMetrics(podMetricsAggregationMax, values))
+}
+
+// newPodMetricsTestServer serves the metrics of a pod, the pods of the tests all get the address of the server
+func newPodMetricsTestServer(t *testing.T, body string) (string, string) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	return host, port
+}
+
+func newPodMetricsTestPod(name, ip string, labels map[string]string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name:  "app",
+			Ports: []corev1.ContainerPort{{Name: "metrics", ContainerPort: 9090}},
+		}}},
+		Status: corev1.PodStatus{Phase: phase, PodIP: ip},
+	}
+}
+
+func TestPodMetricsScrapeScaleTargetPods(t *testing.T) {
+	ip, port := newPodMetricsTestServer(t, `# HELP queue_depth The depth of the queue.
+# TYPE queue_depth gauge
+queue_depth{queue="orders"} 6
+queue_depth{queue="orders",priority="high"} 2
+queue_depth{queue="payments"} 100
+`)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	workerLabels := map[string]string{"app": "worker"}
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&kedav1alpha1.ScaledObject{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"},
+			Spec:       kedav1alpha1.ScaledObjectSpec{ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "worker"}},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: workerLabels}},
+		},
+		newPodMetricsTestPod("worker-1", ip, workerLabels, corev1.PodRunning),
+		newPodMetricsTestPod("worker-2", ip, workerLabels, corev1.PodRunning),
+		newPodMetricsTestPod("worker-3", ip, workerLabels, corev1.PodPending),
+		newPodMetricsTestPod("other", ip, map[string]string{"app": "other"}, corev1.PodRunning),
+	).Build()
+
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		value    int64
+		active   bool
+		isError  bool
+	}{
+		{"sum of the matching series", map[string]string{"labelMatcher": "queue=orders"}, 16, true, false},
+		{"avg across the pods", map[string]string{"labelMatcher": "queue=payments", "aggregation": "avg"}, 100, true, false},
+		{"below the activation target", map[string]string{"labelMatcher": "queue=orders", "activationTargetValue": "20"}, 16, false, false},
+		{"no matching series", map[string]string{"labelMatcher": "queue=unknown"}, 0, false, true},
+		{"unknown metric", map[string]string{"metricName": "unknown"}, 0, false, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metadata := map[string]string{"metricName": "queue_depth", "port": port, "targetValue": "10"}
+			for key, value := range test.metadata {
+				metadata[key] = value
+			}
+			scaler, err := NewPodMetricsScaler(kubeClient, kubeClient, &scalersconfig.ScalerConfig{
+				TriggerMetadata:         metadata,
+				ScalableObjectName:      "worker",
+				ScalableObjectNamespace: "default",
+				ScalableObjectType:      "ScaledObject",
+			})
+			require.NoError(t, err)
+
+			metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "s0-pod-metrics-queue_depth")
+			if test.isError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.value*1000, metrics[0].Value.MilliValue())
+			assert.Equal(t, test.active, active)
+		})
+	}
+}
+
+func TestPodMetricsGetPodMetrics(t *testing.T) {
+	ip, port := newPodMetricsTestServer(t, `# TYPE busy_workers gauge
+busy_workers 3
+`)
+
+	workerLabels := map[string]string{"app": "worker"}
+	kubeClient := fake.NewClientBuilder().WithObjects(
+		newPodMetricsTestPod("worker-1", ip, workerLabels, corev1.PodRunning),
+		newPodMetricsTestPod("worker-2", "127.0.0.2", workerLabels, corev1.PodRunning),
+		newPodMetricsTestPod("worker-3", ip, workerLabels, corev1.PodPending),
+	).Build()
+	scaler, err := NewPodMetricsScaler(kubeClient, kubeClient, &scalersconfig.ScalerConfig{
+		TriggerMetadata:         map[string]string{"metricName": "busy_workers", "port": port, "podSelector": "app=worker"},
+		ScalableObjectName:      "worker",
+		ScalableObjectNamespace: "default",
+		ScalableObjectType:      "ScaledObject",
+		AsMetricSource:          true,
+		GlobalHTTPTimeout:       time.Second,
+	})
+	require.NoError(t, err)
+
+	podValues, err := scaler.(PodMetricsScaler).GetPodMetrics(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"worker-1": 3}, podValues)
+}
+
+func TestPodMetricsScrapesAreBounded(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			observed := maxInFlight.Load()
+			if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		fmt.Fprint(w, "busy_workers 1\n")
+	}))
+	t.Cleanup(server.Close)
+	ip, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+
+	builder := fake.NewClientBuilder()
+	for i := 0; i < 3*podMetricsMaxConcurrentScrapes; i++ {
+		builder = builder.WithObjects(newPodMetricsTestPod(fmt.Sprintf("worker-%d", i), ip, map[string]string{"app": "worker"}, corev1.PodRunning))
+	}
+	kubeClient := builder.Build()
+	scaler, err := NewPodMetricsScaler(kubeClient, kubeClient, &scalersconfig.ScalerConfig{
+		TriggerMetadata:         map[string]string{"metricName": "busy_workers", "port": port, "podSelector": "app=worker"},
+		ScalableObjectNamespace: "default",
+		AsMetricSource:          true,
+		GlobalHTTPTimeout:       time.Second,
+	})
+	require.NoError(t, err)
+
+	podValues, err := scaler.(PodMetricsScaler).GetPodMetrics(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, podValues, 3*podMetricsMaxConcurrentScrapes)
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(podMetricsMaxConcurrentScrapes))
+}
+
+func TestPodMetricsNamedPort(t *testing.T) {
+	pod := newPodMetricsTestPod("worker", "10.0.0.1", nil, corev1.PodRunning)
+
+	port, err := podMetricsPort(pod, "metrics")
+	require.NoError(t, err)
+	assert.Equal(t, 9090, port)
+
+	_, err = podMetricsPort(pod, "unknown")
+	assert.Error(t, err)
+}
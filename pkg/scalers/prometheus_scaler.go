@@ -67,6 +67,24 @@ type prometheusMetadata struct {
 	CortexOrgID string `keda:"name=cortexOrgID, order=triggerMetadata, optional, deprecated=use customHeaders instead"`
 }
 
+type promRangeQueryResult struct {
+	Status string `json:"status"`
+
+	Data struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric struct{}        `json:"metric"`
+			Values [][]interface{} `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// promSample is a sample of a Prometheus range query
+type promSample struct {
+	Timestamp time.Time
+	Value     float64
+}
+
 type promQueryResult struct {
 	Status string `json:"status"`
 
@@ -81,6 +99,10 @@ type promQueryResult struct {
 
 // NewPrometheusScaler creates a new prometheusScaler
 func NewPrometheusScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	return newPrometheusScaler(config)
+}
+
+func newPrometheusScaler(config *scalersconfig.ScalerConfig) (*prometheusScaler, error) {
 	metricType, err := GetMetricTargetType(config)
 	if err != nil {
 		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
@@ -197,25 +219,22 @@ func (s *prometheusScaler) GetMetricSpecForScaling(context.Context) []v2.MetricS
 	return []v2.MetricSpec{metricSpec}
 }
 
-func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error) {
-	t := time.Now().UTC().Format(time.RFC3339)
-	queryEscaped := url_pkg.QueryEscape(s.metadata.Query)
-	url := fmt.Sprintf("%s/api/v1/query?query=%s&time=%s", s.metadata.ServerAddress, queryEscaped, t)
-
+// executePromRequest sends a GET request to the Prometheus HTTP API path with the query parameters, the namespace,
+// the custom headers and the authentication of the trigger, and returns the body of a successful response
+func (s *prometheusScaler) executePromRequest(ctx context.Context, path string, params url_pkg.Values) ([]byte, error) {
 	// set 'namespace' parameter for namespaced Prometheus requests (e.g. for Thanos Querier)
 	if s.metadata.Namespace != "" {
-		url = fmt.Sprintf("%s&namespace=%s", url, s.metadata.Namespace)
+		params.Set("namespace", s.metadata.Namespace)
 	}
 
 	for queryParameterKey, queryParameterValue := range s.metadata.QueryParameters {
-		queryParameterKeyEscaped := url_pkg.QueryEscape(queryParameterKey)
-		queryParameterValueEscaped := url_pkg.QueryEscape(queryParameterValue)
-		url = fmt.Sprintf("%s&%s=%s", url, queryParameterKeyEscaped, queryParameterValueEscaped)
+		params.Set(queryParameterKey, queryParameterValue)
 	}
 
+	url := fmt.Sprintf("%s%s?%s", s.metadata.ServerAddress, path, params.Encode())
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return -1, err
+		return nil, err
 	}
 
 	for headerName, headerValue := range s.metadata.CustomHeaders {
@@ -235,18 +254,30 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 
 	r, err := s.httpClient.Do(req)
 	if err != nil {
-		return -1, err
+		return nil, err
 	}
 
 	b, err := io.ReadAll(r.Body)
 	if err != nil {
-		return -1, err
+		return nil, err
 	}
 	defer r.Body.Close()
 
 	if !(r.StatusCode >= 200 && r.StatusCode <= 299) {
 		err := fmt.Errorf("prometheus query api returned error. status: %d response: %s", r.StatusCode, string(b))
 		s.logger.Error(err, "prometheus query api returned error")
+		return nil, err
+	}
+	return b, nil
+}
+
+func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error) {
+	params := url_pkg.Values{}
+	params.Set("query", s.metadata.Query)
+	params.Set("time", time.Now().UTC().Format(time.RFC3339))
+
+	b, err := s.executePromRequest(ctx, "/api/v1/query", params)
+	if err != nil {
 		return -1, err
 	}
 
@@ -300,6 +331,59 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 	return v, nil
 }
 
+// ExecutePromRangeQuery returns the samples of the query between start and end with the resolution step,
+// the query must return a single series. Samples which aren't finite numbers are skipped.
+func (s *prometheusScaler) ExecutePromRangeQuery(ctx context.Context, start time.Time, end time.Time, step time.Duration) ([]promSample, error) {
+	params := url_pkg.Values{}
+	params.Set("query", s.metadata.Query)
+	params.Set("start", start.UTC().Format(time.RFC3339))
+	params.Set("end", end.UTC().Format(time.RFC3339))
+	params.Set("step", strconv.FormatFloat(step.Seconds(), 'f', -1, 64))
+
+	b, err := s.executePromRequest(ctx, "/api/v1/query_range", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result promRangeQueryResult
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil, err
+	}
+
+	if len(result.Data.Result) == 0 {
+		return nil, nil
+	} else if len(result.Data.Result) > 1 {
+		return nil, fmt.Errorf("prometheus query %s returned multiple series", s.metadata.Query)
+	}
+
+	samples := make([]promSample, 0, len(result.Data.Result[0].Values))
+	for _, value := range result.Data.Result[0].Values {
+		if len(value) < 2 {
+			return nil, fmt.Errorf("prometheus query %s didn't return enough values", s.metadata.Query)
+		}
+		timestamp, ok := value[0].(float64)
+		if !ok {
+			return nil, fmt.Errorf("prometheus query %s returned an invalid timestamp %v", s.metadata.Query, value[0])
+		}
+		str, ok := value[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("prometheus query %s returned an invalid value %v", s.metadata.Query, value[1])
+		}
+		v, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return nil, err
+		}
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		samples = append(samples, promSample{
+			Timestamp: time.Unix(0, int64(timestamp*float64(time.Second))).UTC(),
+			Value:     v,
+		})
+	}
+	return samples, nil
+}
+
 func (s *prometheusScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
 	val, err := s.ExecutePromQuery(ctx)
 	if err != nil {
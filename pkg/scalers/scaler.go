@@ -62,6 +62,15 @@ type PushScaler interface {
 	Run(ctx context.Context, active chan<- bool)
 }
 
+// PodMetricsScaler interface
+type PodMetricsScaler interface {
+	Scaler
+
+	// GetPodMetrics returns the metric value of every pod of the scale target by pod name,
+	// the pods which can't be scraped are left out
+	GetPodMetrics(ctx context.Context) (map[string]float64, error)
+}
+
 var (
 	// ErrScalerUnsupportedUtilizationMetricType is returned when v2.UtilizationMetricType
 	// is provided as the metric target type for scaler.
@@ -366,7 +366,8 @@ func setConfigValueHelper(params Params, valFromConfig string, field reflect.Val
 		field.SetString(valFromConfig)
 		return nil
 	}
-	if paramValue.Type().ConvertibleTo(field.Type()) {
+	// a string is convertible to []rune too, a []int32 is parsed as a list of numbers
+	if paramValue.Type().ConvertibleTo(field.Type()) && !(field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Int32) {
 		field.Set(paramValue.Convert(field.Type()))
 		return nil
 	}
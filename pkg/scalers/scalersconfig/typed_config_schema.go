@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalersconfig
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+)
+
+// ParamType is the JSON type of a triggerMetadata parameter of a typed config
+type ParamType string
+
+// Constants that represent the JSON types of the parameters
+const (
+	ParamTypeString  ParamType = "string"
+	ParamTypeInteger ParamType = "integer"
+	ParamTypeNumber  ParamType = "number"
+	ParamTypeBoolean ParamType = "boolean"
+	ParamTypeArray   ParamType = "array"
+	ParamTypeObject  ParamType = "object"
+)
+
+// ParamSchema describes a parameter of a typed config which can be set in the triggerMetadata
+type ParamSchema struct {
+	// Name is the key of the parameter in the triggerMetadata
+	Name string
+
+	// Type is the JSON type of the parameter
+	Type ParamType
+
+	// ItemsType is the JSON type of the array items or of the object values
+	ItemsType ParamType
+
+	// Range is set when the array items can also be ranges of integers
+	Range bool
+
+	// Required is set when the parameter has to be set in the triggerMetadata, it isn't set for
+	// parameters which are optional, have a default value or can be read from the resolvedEnv or authParams
+	Required bool
+
+	// Default is the default value of the parameter
+	Default string
+
+	// Enum is the list of possible values for the parameter
+	Enum []string
+
+	// Deprecated is the deprecation message of the parameter, or "deprecated" if there is no message
+	Deprecated string
+
+	// FromEnv is set when the parameter can also be read from the container environment variable
+	// named by the <name>FromEnv triggerMetadata key
+	FromEnv bool
+
+	// EnvOnly is set when the parameter can't be set in the triggerMetadata itself, only
+	// the <name>FromEnv key can be set
+	EnvOnly bool
+}
+
+// TypedConfigSchema returns the parameters of the typed config which can be set in the triggerMetadata,
+// directly or through the <name>FromEnv key, in the order they are declared and including the parameters
+// of the nested structures
+func TypedConfigSchema(typedConfig any) ([]ParamSchema, error) {
+	t := reflect.TypeOf(typedConfig)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("typedConfig must be a struct, has kind %q", t.Kind())
+	}
+	return typedConfigSchema(t, false)
+}
+
+// typedConfigSchema is a function that returns the parameters of the struct type
+// this can be called recursively to describe nested structures
+func typedConfigSchema(t reflect.Type, parentOptional bool) ([]ParamSchema, error) {
+	var schema []ParamSchema
+	for i := 0; i < t.NumField(); i++ {
+		fieldType := t.Field(i)
+		tag, exists := fieldType.Tag.Lookup("keda")
+		if !exists {
+			continue
+		}
+		params, err := paramsFromTag(tag, fieldType)
+		if err != nil {
+			return nil, err
+		}
+		optional := params.Optional || parentOptional
+		if params.IsNested() {
+			nestedType := fieldType.Type
+			for nestedType.Kind() == reflect.Pointer {
+				nestedType = nestedType.Elem()
+			}
+			if nestedType.Kind() != reflect.Struct {
+				return nil, fmt.Errorf("nested parameter %q must be a struct, has kind %q", params.FieldName, nestedType.Kind())
+			}
+			nested, err := typedConfigSchema(nestedType, optional)
+			if err != nil {
+				return nil, err
+			}
+			schema = append(schema, nested...)
+			continue
+		}
+		inMetadata := slices.Contains(params.Order, TriggerMetadata)
+		fromEnv := slices.Contains(params.Order, ResolvedEnv)
+		if !inMetadata && !fromEnv {
+			continue
+		}
+		paramType, itemsType := paramTypeOf(fieldType.Type)
+		schema = append(schema, ParamSchema{
+			Name:       params.Name,
+			Type:       paramType,
+			ItemsType:  itemsType,
+			Range:      params.RangeSeparator != "" && itemsType == ParamTypeInteger,
+			Required:   inMetadata && !optional && !params.IsDeprecated() && params.Default == "" && len(params.Order) == 1,
+			Default:    params.Default,
+			Enum:       params.Enum,
+			Deprecated: params.Deprecated,
+			FromEnv:    fromEnv,
+			EnvOnly:    !inMetadata,
+		})
+	}
+	return schema, nil
+}
+
+// paramTypeOf is a function that returns the JSON type of the field type and of its items, it matches
+// the way setConfigValueHelper parses the values
+func paramTypeOf(t reflect.Type) (ParamType, ParamType) {
+	if t == reflect.TypeOf(url.Values{}) {
+		return ParamTypeString, ""
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return ParamTypeBoolean, ""
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return ParamTypeInteger, ""
+	case reflect.Float32, reflect.Float64:
+		return ParamTypeNumber, ""
+	case reflect.Slice:
+		itemsType, _ := paramTypeOf(t.Elem())
+		return ParamTypeArray, itemsType
+	case reflect.Map:
+		itemsType, _ := paramTypeOf(t.Elem())
+		return ParamTypeObject, itemsType
+	default:
+		return ParamTypeString, ""
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalersconfig
+
+import (
+	"net/url"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+// TestTypedConfigSchema tests the schema of the triggerMetadata parameters of a typed config
+func TestTypedConfigSchema(t *testing.T) {
+	RegisterTestingT(t)
+
+	type nestedStruct struct {
+		Modes []string `keda:"name=modes, order=triggerMetadata, enum=a;b"`
+		Token string   `keda:"name=token, order=authParams"`
+	}
+
+	type testStruct struct {
+		StringVal     string            `keda:"name=stringVal,     order=triggerMetadata"`
+		IntVal        int64             `keda:"name=intVal,        order=triggerMetadata, default=10"`
+		FloatVal      float64           `keda:"name=floatVal,      order=triggerMetadata;resolvedEnv"`
+		BoolVal       bool              `keda:"name=boolVal,       order=resolvedEnv"`
+		Partitions    []int             `keda:"name=partitions,    order=triggerMetadata, optional, range"`
+		Headers       map[string]string `keda:"name=headers,       order=triggerMetadata, optional"`
+		Params        url.Values        `keda:"name=params,        order=triggerMetadata, optional"`
+		DeprecatedVal string            `keda:"name=deprecatedVal, order=triggerMetadata, deprecated=use stringVal"`
+		AuthVal       string            `keda:"name=auth,          order=authParams"`
+		Nested        *nestedStruct     `keda:"optional"`
+	}
+
+	schema, err := TypedConfigSchema(testStruct{})
+	Expect(err).To(BeNil())
+	Expect(schema).To(Equal([]ParamSchema{
+		{Name: "stringVal", Type: ParamTypeString, Required: true},
+		{Name: "intVal", Type: ParamTypeInteger, Default: "10"},
+		{Name: "floatVal", Type: ParamTypeNumber, FromEnv: true},
+		{Name: "boolVal", Type: ParamTypeBoolean, FromEnv: true, EnvOnly: true},
+		{Name: "partitions", Type: ParamTypeArray, ItemsType: ParamTypeInteger, Range: true},
+		{Name: "headers", Type: ParamTypeObject, ItemsType: ParamTypeString},
+		{Name: "params", Type: ParamTypeString},
+		{Name: "deprecatedVal", Type: ParamTypeString, Deprecated: "use stringVal"},
+		{Name: "modes", Type: ParamTypeArray, ItemsType: ParamTypeString, Enum: []string{"a", "b"}},
+	}))
+
+	_, err = TypedConfigSchema("not a struct")
+	Expect(err).NotTo(BeNil())
+}
@@ -218,12 +218,14 @@ func TestSlice(t *testing.T) {
 		TriggerMetadata: map[string]string{
 			"sliceVal":           "1,2,3",
 			"sliceValWithSpaces": "1, 2, 3",
+			"sliceInt32Val":      "1,2,3",
 		},
 	}
 
 	type testStruct struct {
-		SliceVal           []int `keda:"name=sliceVal, order=triggerMetadata"`
-		SliceValWithSpaces []int `keda:"name=sliceValWithSpaces, order=triggerMetadata"`
+		SliceVal           []int   `keda:"name=sliceVal, order=triggerMetadata"`
+		SliceValWithSpaces []int   `keda:"name=sliceValWithSpaces, order=triggerMetadata"`
+		SliceInt32Val      []int32 `keda:"name=sliceInt32Val, order=triggerMetadata"`
 	}
 
 	ts := testStruct{}
@@ -237,6 +239,7 @@ func TestSlice(t *testing.T) {
 	Expect(ts.SliceValWithSpaces[0]).To(Equal(1))
 	Expect(ts.SliceValWithSpaces[1]).To(Equal(2))
 	Expect(ts.SliceValWithSpaces[2]).To(Equal(3))
+	Expect(ts.SliceInt32Val).To(Equal([]int32{1, 2, 3}))
 }
 
 // TestEnum tests the enum type
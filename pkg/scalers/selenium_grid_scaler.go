@@ -9,6 +9,7 @@ import (
 	"io"
 	"math"
 	"net/http"
+	"reflect"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -36,8 +37,13 @@ type seleniumGridScalerMetadata struct {
 	BrowserVersion      string `keda:"name=browserVersion,           order=triggerMetadata, optional, default=latest"`
 	UnsafeSsl           bool   `keda:"name=unsafeSsl,                order=triggerMetadata, optional, default=false"`
 	PlatformName        string `keda:"name=platformName,             order=triggerMetadata, optional, default=linux"`
+	NodeMaxSessions     int64  `keda:"name=nodeMaxSessions,          order=triggerMetadata, optional"`
+	Capabilities        string `keda:"name=capabilities,             order=triggerMetadata, optional"`
 
 	TargetValue int64
+
+	// capabilities are the extra capabilities the sessions and the node stereotypes have to match
+	capabilities map[string]interface{}
 }
 
 type seleniumResponse struct {
@@ -47,6 +53,7 @@ type seleniumResponse struct {
 type data struct {
 	Grid         grid         `json:"grid"`
 	SessionsInfo sessionsInfo `json:"sessionsInfo"`
+	NodesInfo    nodesInfo    `json:"nodesInfo"`
 }
 
 type grid struct {
@@ -59,6 +66,23 @@ type sessionsInfo struct {
 	Sessions             []seleniumSession `json:"sessions"`
 }
 
+type nodesInfo struct {
+	Nodes []seleniumNode `json:"nodes"`
+}
+
+type seleniumNode struct {
+	ID           string `json:"id"`
+	Status       string `json:"status"`
+	SessionCount int64  `json:"sessionCount"`
+	MaxSession   int64  `json:"maxSession"`
+	Stereotypes  string `json:"stereotypes"`
+}
+
+type seleniumStereotype struct {
+	Slots      int64                  `json:"slots"`
+	Stereotype map[string]interface{} `json:"stereotype"`
+}
+
 type seleniumSession struct {
 	ID           string `json:"id"`
 	Capabilities string `json:"capabilities"`
@@ -114,6 +138,16 @@ func parseSeleniumGridScalerMetadata(config *scalersconfig.ScalerConfig) (*selen
 	if meta.SessionBrowserName == "" {
 		meta.SessionBrowserName = meta.BrowserName
 	}
+
+	if meta.NodeMaxSessions < 0 {
+		return nil, fmt.Errorf("nodeMaxSessions must not be negative")
+	}
+
+	if meta.Capabilities != "" {
+		if err := json.Unmarshal([]byte(meta.Capabilities), &meta.capabilities); err != nil {
+			return nil, fmt.Errorf("error parsing capabilities, it must be a JSON object: %w", err)
+		}
+	}
 	return meta, nil
 }
 
@@ -152,7 +186,7 @@ func (s *seleniumGridScaler) GetMetricSpecForScaling(context.Context) []v2.Metri
 
 func (s *seleniumGridScaler) getSessionsCount(ctx context.Context, logger logr.Logger) (int64, error) {
 	body, err := json.Marshal(map[string]string{
-		"query": "{ grid { maxSession, nodeCount }, sessionsInfo { sessionQueueRequests, sessions { id, capabilities, nodeId } } }",
+		"query": "{ grid { maxSession, nodeCount }, nodesInfo { nodes { id, status, sessionCount, maxSession, stereotypes } }, sessionsInfo { sessionQueueRequests, sessions { id, capabilities, nodeId } } }",
 	})
 
 	if err != nil {
@@ -179,31 +213,33 @@ func (s *seleniumGridScaler) getSessionsCount(ctx context.Context, logger logr.L
 	if err != nil {
 		return -1, err
 	}
-	v, err := getCountFromSeleniumResponse(b, s.metadata.BrowserName, s.metadata.BrowserVersion, s.metadata.SessionBrowserName, s.metadata.PlatformName, logger)
+	v, err := getCountFromSeleniumResponse(b, s.metadata, logger)
 	if err != nil {
 		return -1, err
 	}
 	return v, nil
 }
 
-func getCountFromSeleniumResponse(b []byte, browserName string, browserVersion string, sessionBrowserName string, platformName string, logger logr.Logger) (int64, error) {
-	var count int64
+// getCountFromSeleniumResponse returns the nodes needed for the ongoing sessions and for the queued sessions
+// the free slots of the running nodes can't take
+func getCountFromSeleniumResponse(b []byte, meta *seleniumGridScalerMetadata, logger logr.Logger) (int64, error) {
 	var seleniumResponse = seleniumResponse{}
 
 	if err := json.Unmarshal(b, &seleniumResponse); err != nil {
 		return 0, err
 	}
 
+	var queued int64
 	var sessionQueueRequests = seleniumResponse.Data.SessionsInfo.SessionQueueRequests
 	for _, sessionQueueRequest := range sessionQueueRequests {
 		var capability = capability{}
 		if err := json.Unmarshal([]byte(sessionQueueRequest), &capability); err == nil {
-			if capability.BrowserName == browserName {
-				var platformNameMatches = capability.PlatformName == "" || strings.EqualFold(capability.PlatformName, platformName)
-				if strings.HasPrefix(capability.BrowserVersion, browserVersion) && platformNameMatches {
-					count++
-				} else if len(strings.TrimSpace(capability.BrowserVersion)) == 0 && browserVersion == DefaultBrowserVersion && platformNameMatches {
-					count++
+			if capability.BrowserName == meta.BrowserName && matchesCapabilities(sessionQueueRequest, meta.capabilities) {
+				var platformNameMatches = capability.PlatformName == "" || strings.EqualFold(capability.PlatformName, meta.PlatformName)
+				if strings.HasPrefix(capability.BrowserVersion, meta.BrowserVersion) && platformNameMatches {
+					queued++
+				} else if len(strings.TrimSpace(capability.BrowserVersion)) == 0 && meta.BrowserVersion == DefaultBrowserVersion && platformNameMatches {
+					queued++
 				}
 			}
 		} else {
@@ -211,16 +247,17 @@ func getCountFromSeleniumResponse(b []byte, browserName string, browserVersion s
 		}
 	}
 
+	var ongoing int64
 	var sessions = seleniumResponse.Data.SessionsInfo.Sessions
 	for _, session := range sessions {
 		var capability = capability{}
 		if err := json.Unmarshal([]byte(session.Capabilities), &capability); err == nil {
-			var platformNameMatches = capability.PlatformName == "" || strings.EqualFold(capability.PlatformName, platformName)
-			if capability.BrowserName == sessionBrowserName {
-				if strings.HasPrefix(capability.BrowserVersion, browserVersion) && platformNameMatches {
-					count++
-				} else if browserVersion == DefaultBrowserVersion && platformNameMatches {
-					count++
+			var platformNameMatches = capability.PlatformName == "" || strings.EqualFold(capability.PlatformName, meta.PlatformName)
+			if capability.BrowserName == meta.SessionBrowserName && matchesCapabilities(session.Capabilities, meta.capabilities) {
+				if strings.HasPrefix(capability.BrowserVersion, meta.BrowserVersion) && platformNameMatches {
+					ongoing++
+				} else if meta.BrowserVersion == DefaultBrowserVersion && platformNameMatches {
+					ongoing++
 				}
 			}
 		} else {
@@ -228,6 +265,16 @@ func getCountFromSeleniumResponse(b []byte, browserName string, browserVersion s
 		}
 	}
 
+	// queued sessions the free slots of the running nodes can take don't need a new node
+	var count = ongoing
+	if pending := queued - getAvailableSlots(seleniumResponse.Data.NodesInfo.Nodes, meta, logger); pending > 0 {
+		count += pending
+	}
+
+	if meta.NodeMaxSessions > 0 {
+		return int64(math.Ceil(float64(count) / float64(meta.NodeMaxSessions))), nil
+	}
+
 	var gridMaxSession = int64(seleniumResponse.Data.Grid.MaxSession)
 	var gridNodeCount = int64(seleniumResponse.Data.Grid.NodeCount)
 
@@ -238,3 +285,70 @@ func getCountFromSeleniumResponse(b []byte, browserName string, browserVersion s
 	}
 	return count, nil
 }
+
+// getAvailableSlots returns the free slots of the running nodes with a stereotype matching the scaler
+func getAvailableSlots(nodes []seleniumNode, meta *seleniumGridScalerMetadata, logger logr.Logger) int64 {
+	var available int64
+	for _, node := range nodes {
+		if !strings.EqualFold(node.Status, "UP") {
+			continue
+		}
+		var stereotypes []seleniumStereotype
+		if err := json.Unmarshal([]byte(node.Stereotypes), &stereotypes); err != nil {
+			logger.Error(err, fmt.Sprintf("Error when unmarshaling stereotypes of node %s: %s", node.ID, err))
+			continue
+		}
+
+		var slots int64
+		for _, stereotype := range stereotypes {
+			if matchesStereotype(stereotype.Stereotype, meta) {
+				slots += stereotype.Slots
+			}
+		}
+		if node.MaxSession > 0 && slots > node.MaxSession {
+			slots = node.MaxSession
+		}
+		if free := slots - node.SessionCount; free > 0 {
+			available += free
+		}
+	}
+	return available
+}
+
+func matchesStereotype(stereotype map[string]interface{}, meta *seleniumGridScalerMetadata) bool {
+	browserName, _ := stereotype["browserName"].(string)
+	if browserName != meta.BrowserName {
+		return false
+	}
+	browserVersion, _ := stereotype["browserVersion"].(string)
+	if meta.BrowserVersion != DefaultBrowserVersion && !strings.HasPrefix(browserVersion, meta.BrowserVersion) {
+		return false
+	}
+	platformName, _ := stereotype["platformName"].(string)
+	if platformName != "" && !strings.EqualFold(platformName, meta.PlatformName) {
+		return false
+	}
+	for name, value := range meta.capabilities {
+		if !reflect.DeepEqual(stereotype[name], value) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesCapabilities checks that the JSON capabilities contain all the expected capabilities
+func matchesCapabilities(capabilities string, expected map[string]interface{}) bool {
+	if len(expected) == 0 {
+		return true
+	}
+	var actual map[string]interface{}
+	if err := json.Unmarshal([]byte(capabilities), &actual); err != nil {
+		return false
+	}
+	for name, value := range expected {
+		if !reflect.DeepEqual(actual[name], value) {
+			return false
+		}
+	}
+	return true
+}
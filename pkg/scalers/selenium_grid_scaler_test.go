@@ -16,6 +16,8 @@ func Test_getCountFromSeleniumResponse(t *testing.T) {
 		sessionBrowserName string
 		browserVersion     string
 		platformName       string
+		nodeMaxSessions    int64
+		capabilities       map[string]interface{}
 	}
 	tests := []struct {
 		name    string
@@ -501,10 +503,110 @@ func Test_getCountFromSeleniumResponse(t *testing.T) {
 			want:    2,
 			wantErr: false,
 		},
+		{
+			name: "free slots of matching nodes should take the queued sessions",
+			args: args{
+				b: []byte(`{
+					"data": {
+						"grid":{
+							"maxSession": 4,
+							"nodeCount": 2
+						},
+						"nodesInfo": {
+							"nodes": [
+								{
+									"id": "node-1",
+									"status": "UP",
+									"sessionCount": 1,
+									"maxSession": 2,
+									"stereotypes": "[{\"slots\": 2, \"stereotype\": {\"browserName\": \"chrome\", \"platformName\": \"linux\"}}]"
+								},
+								{
+									"id": "node-2",
+									"status": "UP",
+									"sessionCount": 0,
+									"maxSession": 2,
+									"stereotypes": "[{\"slots\": 2, \"stereotype\": {\"browserName\": \"firefox\", \"platformName\": \"linux\"}}]"
+								}
+							]
+						},
+						"sessionsInfo": {
+							"sessionQueueRequests": ["{\n  \"browserName\": \"chrome\"\n}","{\n  \"browserName\": \"chrome\"\n}","{\n  \"browserName\": \"chrome\"\n}","{\n  \"browserName\": \"chrome\"\n}"],
+							"sessions": [
+								{
+									"id": "0f9c5a941aa4d755a54b84be1f6535b1",
+									"capabilities": "{\n  \"browserName\": \"chrome\",\n  \"browserVersion\": \"91.0.4472.114\",\n  \"platformName\": \"linux\"\n}",
+									"nodeId": "node-1"
+								}
+							]
+						}
+					}
+				}`),
+				browserName:        "chrome",
+				sessionBrowserName: "chrome",
+				browserVersion:     "latest",
+				platformName:       "linux",
+				nodeMaxSessions:    2,
+			},
+			// 1 ongoing session and 3 queued sessions not taken by the free slot of node-1
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name: "queued sessions and nodes not matching the capabilities should be ignored",
+			args: args{
+				b: []byte(`{
+					"data": {
+						"grid":{
+							"maxSession": 2,
+							"nodeCount": 2
+						},
+						"nodesInfo": {
+							"nodes": [
+								{
+									"id": "node-1",
+									"status": "UP",
+									"sessionCount": 0,
+									"maxSession": 1,
+									"stereotypes": "[{\"slots\": 1, \"stereotype\": {\"browserName\": \"chrome\", \"se:downloadsEnabled\": false}}]"
+								},
+								{
+									"id": "node-2",
+									"status": "DOWN",
+									"sessionCount": 0,
+									"maxSession": 1,
+									"stereotypes": "[{\"slots\": 1, \"stereotype\": {\"browserName\": \"chrome\", \"se:downloadsEnabled\": true}}]"
+								}
+							]
+						},
+						"sessionsInfo": {
+							"sessionQueueRequests": ["{\n  \"browserName\": \"chrome\",\n  \"se:downloadsEnabled\": true\n}","{\n  \"browserName\": \"chrome\"\n}"],
+							"sessions": []
+						}
+					}
+				}`),
+				browserName:        "chrome",
+				sessionBrowserName: "chrome",
+				browserVersion:     "latest",
+				platformName:       "linux",
+				nodeMaxSessions:    1,
+				capabilities:       map[string]interface{}{"se:downloadsEnabled": true},
+			},
+			want:    1,
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := getCountFromSeleniumResponse(tt.args.b, tt.args.browserName, tt.args.browserVersion, tt.args.sessionBrowserName, tt.args.platformName, logr.Discard())
+			meta := &seleniumGridScalerMetadata{
+				BrowserName:        tt.args.browserName,
+				SessionBrowserName: tt.args.sessionBrowserName,
+				BrowserVersion:     tt.args.browserVersion,
+				PlatformName:       tt.args.platformName,
+				NodeMaxSessions:    tt.args.nodeMaxSessions,
+				capabilities:       tt.args.capabilities,
+			}
+			got, err := getCountFromSeleniumResponse(tt.args.b, meta, logr.Discard())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("getCountFromSeleniumResponse() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -724,6 +826,57 @@ func Test_parseSeleniumGridScalerMetadata(t *testing.T) {
 				PlatformName:        "Windows 11",
 			},
 		},
+		{
+			name: "valid nodeMaxSessions and capabilities should return metadata",
+			args: args{
+				config: &scalersconfig.ScalerConfig{
+					TriggerMetadata: map[string]string{
+						"url":             "http://selenium-hub:4444/graphql",
+						"browserName":     "chrome",
+						"nodeMaxSessions": "3",
+						"capabilities":    `{"se:downloadsEnabled": true}`,
+					},
+				},
+			},
+			wantErr: false,
+			want: &seleniumGridScalerMetadata{
+				URL:                "http://selenium-hub:4444/graphql",
+				BrowserName:        "chrome",
+				SessionBrowserName: "chrome",
+				TargetValue:        1,
+				BrowserVersion:     "latest",
+				PlatformName:       "linux",
+				NodeMaxSessions:    3,
+				Capabilities:       `{"se:downloadsEnabled": true}`,
+				capabilities:       map[string]interface{}{"se:downloadsEnabled": true},
+			},
+		},
+		{
+			name: "invalid capabilities should throw an error",
+			args: args{
+				config: &scalersconfig.ScalerConfig{
+					TriggerMetadata: map[string]string{
+						"url":          "http://selenium-hub:4444/graphql",
+						"browserName":  "chrome",
+						"capabilities": "se:downloadsEnabled=true",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative nodeMaxSessions should throw an error",
+			args: args{
+				config: &scalersconfig.ScalerConfig{
+					TriggerMetadata: map[string]string{
+						"url":             "http://selenium-hub:4444/graphql",
+						"browserName":     "chrome",
+						"nodeMaxSessions": "-1",
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
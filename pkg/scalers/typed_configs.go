@@ -0,0 +1,36 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalers
+
+// TypedConfigs maps the trigger types whose metadata is parsed with ScalerConfig.TypedConfig to their typed config,
+// the typed trigger configs of the keda.sh/v1beta1 API are generated from them.
+// A scaler migrated to a typed config has to be added here.
+var TypedConfigs = map[string]any{
+	"activemq":           activeMQMetadata{},
+	"apache-kafka":       kafkaMetadata{},
+	"arangodb":           arangoDBMetadata{},
+	"artemis-queue":      artemisMetadata{},
+	"aws-cloudwatch":     awsCloudwatchMetadata{},
+	"forecast":           forecastMetadata{},
+	"kafka":              kafkaMetadata{},
+	"otel":               otelMetadata{},
+	"pod-metrics":        podMetricsMetadata{},
+	"prometheus":         prometheusMetadata{},
+	"selenium-grid":      seleniumGridScalerMetadata{},
+	"solace-event-queue": SolaceMetadata{},
+	"webhook":            webhookMetadata{},
+}
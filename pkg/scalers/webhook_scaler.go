@@ -0,0 +1,389 @@
+package scalers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/xhit/go-str2duration/v2"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// WebhookTriggerPathPrefix is the path prefix of the webhook trigger endpoints,
+	// an endpoint is served at <prefix><namespace>/<scaledobject|scaledjob>/<name>/<trigger name or index>
+	WebhookTriggerPathPrefix = "/triggers/"
+
+	webhookTriggerMaxBodySize = 64 * 1024
+	webhookSignaturePrefix    = "sha256="
+
+	// webhookTriggerUnusedRetention bounds how long the value of a trigger without scaler is kept when it has no TTL
+	webhookTriggerUnusedRetention = time.Hour
+)
+
+// webhookScaler is a push scaler whose metric value and activity are pushed by external systems to an
+// endpoint served by the operator, authenticated with a bearer token or an HMAC signature of the body
+type webhookScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *webhookMetadata
+	endpoint   *webhookTriggerEndpoint
+	logger     logr.Logger
+	closeOnce  sync.Once
+}
+
+type webhookMetadata struct {
+	triggerIndex int
+
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata, default=1"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
+	ValueTTL              string  `keda:"name=valueTTL,              order=triggerMetadata, optional"`
+	SignatureHeader       string  `keda:"name=signatureHeader,       order=triggerMetadata, default=X-Keda-Signature-256"`
+
+	BearerToken string `keda:"name=bearerToken, order=authParams, optional"`
+	HMACSecret  string `keda:"name=hmacSecret,  order=authParams, optional"`
+
+	valueTTL time.Duration
+}
+
+// webhookTriggerPayload is the body accepted by a webhook trigger endpoint, the value expires after the ttl
+type webhookTriggerPayload struct {
+	Value  *float64 `json:"value,omitempty"`
+	Active *bool    `json:"active,omitempty"`
+	TTL    string   `json:"ttl,omitempty"`
+}
+
+// webhookTriggerEndpoint holds the last pushed value of a webhook trigger, it's shared by the scalers
+// built for the trigger and kept by path once they are all closed, so the value survives a refresh of
+// the scalers cache and a restart of the scale loop
+type webhookTriggerEndpoint struct {
+	mutex sync.Mutex
+
+	bearerToken           string
+	hmacSecret            string
+	signatureHeader       string
+	activationTargetValue float64
+	valueTTL              time.Duration
+
+	value       float64
+	active      bool
+	expiresAt   time.Time
+	subscribers map[chan bool]struct{}
+	references  int
+	unusedSince time.Time
+}
+
+// webhookTriggerEndpoints are the endpoints of the webhook triggers, keyed by their path
+var webhookTriggerEndpoints = struct {
+	sync.Mutex
+	endpoints map[string]*webhookTriggerEndpoint
+}{endpoints: map[string]*webhookTriggerEndpoint{}}
+
+// NewWebhookScaler creates a new webhookScaler
+func NewWebhookScaler(config *scalersconfig.ScalerConfig) (PushScaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseWebhookMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing webhook metadata: %w", err)
+	}
+
+	return &webhookScaler{
+		metricType: metricType,
+		metadata:   meta,
+		endpoint:   registerWebhookTriggerEndpoint(WebhookTriggerPath(config), meta),
+		logger:     InitializeLogger(config, "webhook_scaler"),
+	}, nil
+}
+
+func parseWebhookMetadata(config *scalersconfig.ScalerConfig) (*webhookMetadata, error) {
+	meta := &webhookMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	meta.triggerIndex = config.TriggerIndex
+
+	if meta.BearerToken == "" && meta.HMACSecret == "" {
+		return nil, fmt.Errorf("either bearerToken or hmacSecret must be provided by the TriggerAuthentication")
+	}
+	if meta.TargetValue <= 0 {
+		return nil, fmt.Errorf("targetValue must be greater than 0")
+	}
+	if meta.ValueTTL != "" {
+		ttl, err := str2duration.ParseDuration(meta.ValueTTL)
+		if err != nil {
+			return nil, fmt.Errorf("valueTTL parsing error %w", err)
+		}
+		if ttl < 0 {
+			return nil, fmt.Errorf("valueTTL must not be negative")
+		}
+		meta.valueTTL = ttl
+	}
+	return meta, nil
+}
+
+// WebhookTriggerPath returns the path of the endpoint of a webhook trigger
+func WebhookTriggerPath(config *scalersconfig.ScalerConfig) string {
+	trigger := config.TriggerName
+	if trigger == "" {
+		trigger = strconv.Itoa(config.TriggerIndex)
+	}
+	return WebhookTriggerPathPrefix + strings.Join([]string{config.ScalableObjectNamespace, strings.ToLower(config.ScalableObjectType), config.ScalableObjectName, trigger}, "/")
+}
+
+// registerWebhookTriggerEndpoint returns the endpoint of the path, with the auth and the options of the metadata
+func registerWebhookTriggerEndpoint(path string, meta *webhookMetadata) *webhookTriggerEndpoint {
+	webhookTriggerEndpoints.Lock()
+	defer webhookTriggerEndpoints.Unlock()
+
+	endpoint, ok := webhookTriggerEndpoints.endpoints[path]
+	if !ok {
+		endpoint = &webhookTriggerEndpoint{subscribers: map[chan bool]struct{}{}}
+		webhookTriggerEndpoints.endpoints[path] = endpoint
+	}
+
+	endpoint.mutex.Lock()
+	defer endpoint.mutex.Unlock()
+	endpoint.bearerToken = meta.BearerToken
+	endpoint.hmacSecret = meta.HMACSecret
+	endpoint.signatureHeader = meta.SignatureHeader
+	endpoint.activationTargetValue = meta.ActivationTargetValue
+	endpoint.valueTTL = meta.valueTTL
+	endpoint.references++
+	endpoint.unusedSince = time.Time{}
+	return endpoint
+}
+
+// unregisterWebhookTriggerEndpoint stops serving the endpoint once none of the scalers of the trigger use it,
+// its value is kept until it expires so a scaler built again for the trigger gets it back
+func unregisterWebhookTriggerEndpoint(endpoint *webhookTriggerEndpoint) {
+	webhookTriggerEndpoints.Lock()
+	defer webhookTriggerEndpoints.Unlock()
+
+	now := time.Now()
+	endpoint.mutex.Lock()
+	endpoint.references--
+	if endpoint.references == 0 {
+		endpoint.unusedSince = now
+	}
+	endpoint.mutex.Unlock()
+
+	pruneWebhookTriggerEndpoints(now)
+}
+
+// pruneWebhookTriggerEndpoints removes the unused endpoints whose value expired, the caller must hold the lock
+// of the endpoints
+func pruneWebhookTriggerEndpoints(now time.Time) {
+	for path, endpoint := range webhookTriggerEndpoints.endpoints {
+		endpoint.mutex.Lock()
+		expired := (!endpoint.expiresAt.IsZero() && now.After(endpoint.expiresAt)) ||
+			now.Sub(endpoint.unusedSince) > webhookTriggerUnusedRetention
+		if endpoint.references == 0 && expired {
+			delete(webhookTriggerEndpoints.endpoints, path)
+		}
+		endpoint.mutex.Unlock()
+	}
+}
+
+func (s *webhookScaler) Close(context.Context) error {
+	s.closeOnce.Do(func() { unregisterWebhookTriggerEndpoint(s.endpoint) })
+	return nil
+}
+
+func (s *webhookScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString("webhook")),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the last pushed value, or 0 and not active once it expired
+func (s *webhookScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, active := s.endpoint.current(time.Now())
+	return []external_metrics.ExternalMetricValue{GenerateMetricInMili(metricName, value)}, active, nil
+}
+
+// Run forwards the activity pushed to the endpoint of the trigger, it closes the active channel once done
+func (s *webhookScaler) Run(ctx context.Context, active chan<- bool) {
+	defer close(active)
+
+	pushed := s.endpoint.subscribe()
+	defer s.endpoint.unsubscribe(pushed)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case isActive := <-pushed:
+			s.logger.V(1).Info("Activity pushed to the webhook trigger", "active", isActive)
+			select {
+			case active <- isActive:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+func (e *webhookTriggerEndpoint) subscribe() chan bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	pushed := make(chan bool, 1)
+	e.subscribers[pushed] = struct{}{}
+	return pushed
+}
+
+func (e *webhookTriggerEndpoint) unsubscribe(pushed chan bool) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	delete(e.subscribers, pushed)
+}
+
+func (e *webhookTriggerEndpoint) isServed() bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.references > 0
+}
+
+func (e *webhookTriggerEndpoint) current(now time.Time) (float64, bool) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.valueAt(now)
+}
+
+// valueAt returns the value and the activity at the given time, the caller must hold the mutex
+func (e *webhookTriggerEndpoint) valueAt(now time.Time) (float64, bool) {
+	if !e.expiresAt.IsZero() && now.After(e.expiresAt) {
+		return 0, false
+	}
+	return e.value, e.active
+}
+
+// push stores the pushed value and notifies the running scalers, only the last pushed activity
+// is kept for a scaler which didn't forward the previous one yet
+func (e *webhookTriggerEndpoint) push(payload webhookTriggerPayload, ttl time.Duration, now time.Time) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.value, _ = e.valueAt(now)
+	if payload.Value != nil {
+		e.value = *payload.Value
+	}
+	if payload.Active != nil {
+		e.active = *payload.Active
+	} else {
+		e.active = e.value > e.activationTargetValue
+	}
+	e.expiresAt = time.Time{}
+	if ttl > 0 {
+		e.expiresAt = now.Add(ttl)
+	}
+
+	for pushed := range e.subscribers {
+		select {
+		case <-pushed:
+		default:
+		}
+		pushed <- e.active
+	}
+}
+
+// authorize checks the bearer token or the HMAC signature of the body, either is enough when both are configured
+func (e *webhookTriggerEndpoint) authorize(r *http.Request, body []byte) bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.bearerToken != "" {
+		if token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); found &&
+			subtle.ConstantTimeCompare([]byte(token), []byte(e.bearerToken)) == 1 {
+			return true
+		}
+	}
+	if e.hmacSecret != "" {
+		signature, err := hex.DecodeString(strings.TrimPrefix(r.Header.Get(e.signatureHeader), webhookSignaturePrefix))
+		if err != nil || len(signature) == 0 {
+			return false
+		}
+		mac := hmac.New(sha256.New, []byte(e.hmacSecret))
+		mac.Write(body)
+		return hmac.Equal(signature, mac.Sum(nil))
+	}
+	return false
+}
+
+// WebhookTriggerHandler returns the handler of the webhook trigger endpoints, a push is a POST of a JSON body
+// with a value and/or an active flag and an optional ttl overriding the valueTTL of the trigger
+func WebhookTriggerHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		webhookTriggerEndpoints.Lock()
+		endpoint, ok := webhookTriggerEndpoints.endpoints[r.URL.Path]
+		webhookTriggerEndpoints.Unlock()
+		if !ok || !endpoint.isServed() {
+			http.NotFound(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, webhookTriggerMaxBodySize))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error reading the body: %s", err), http.StatusBadRequest)
+			return
+		}
+		// an unknown trigger and a bad signature aren't told apart to not disclose the existing triggers
+		if !endpoint.authorize(r, body) {
+			http.NotFound(w, r)
+			return
+		}
+
+		payload := webhookTriggerPayload{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			http.Error(w, fmt.Sprintf("invalid payload: %s", err), http.StatusBadRequest)
+			return
+		}
+		if payload.Value == nil && payload.Active == nil {
+			http.Error(w, "invalid payload: either value or active must be set", http.StatusBadRequest)
+			return
+		}
+		endpoint.mutex.Lock()
+		ttl := endpoint.valueTTL
+		endpoint.mutex.Unlock()
+		if payload.TTL != "" {
+			ttl, err = str2duration.ParseDuration(payload.TTL)
+			if err != nil || ttl < 0 {
+				http.Error(w, fmt.Sprintf("invalid payload: invalid ttl %q", payload.TTL), http.StatusBadRequest)
+				return
+			}
+		}
+
+		endpoint.push(payload, ttl, time.Now())
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
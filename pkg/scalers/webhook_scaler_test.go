@@ -0,0 +1,253 @@
+package scalers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseWebhookMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type webhookMetricIdentifier struct {
+	metadataTestData *parseWebhookMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testWebhookMetadata = []parseWebhookMetadataTestData{
+	// no auth
+	{map[string]string{}, map[string]string{}, true},
+	// bearer token with the defaults
+	{map[string]string{}, map[string]string{"bearerToken": "token"}, false},
+	// hmac secret with all the options
+	{map[string]string{"targetValue": "5", "activationTargetValue": "1", "valueTTL": "10m", "signatureHeader": "X-Hub-Signature-256"}, map[string]string{"hmacSecret": "secret"}, false},
+	// invalid targetValue
+	{map[string]string{"targetValue": "0"}, map[string]string{"bearerToken": "token"}, true},
+	// invalid valueTTL
+	{map[string]string{"valueTTL": "soon"}, map[string]string{"bearerToken": "token"}, true},
+	// negative valueTTL
+	{map[string]string{"valueTTL": "-1m"}, map[string]string{"bearerToken": "token"}, true},
+}
+
+var webhookMetricIdentifiers = []webhookMetricIdentifier{
+	{&testWebhookMetadata[1], 0, "s0-webhook"},
+	{&testWebhookMetadata[1], 1, "s1-webhook"},
+}
+
+func TestWebhookParseMetadata(t *testing.T) {
+	for _, testData := range testWebhookMetadata {
+		scaler, err := NewWebhookScaler(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for %v", testData.metadata)
+		}
+		if scaler != nil {
+			_ = scaler.Close(context.Background())
+		}
+	}
+}
+
+func TestWebhookGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range webhookMetricIdentifiers {
+		scaler, err := NewWebhookScaler(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+
+		metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName, testData.name)
+		}
+		_ = scaler.Close(context.Background())
+	}
+}
+
+func newTestWebhookScaler(t *testing.T, metadata, authParams map[string]string) (PushScaler, string) {
+	config := &scalersconfig.ScalerConfig{
+		TriggerMetadata:         metadata,
+		AuthParams:              authParams,
+		ScalableObjectName:      "app",
+		ScalableObjectNamespace: "default",
+		ScalableObjectType:      "ScaledObject",
+		TriggerName:             "ci",
+	}
+	scaler, err := NewWebhookScaler(config)
+	require.NoError(t, err)
+	path := WebhookTriggerPath(config)
+	t.Cleanup(func() {
+		_ = scaler.Close(context.Background())
+		// the kept value must not leak into the next test
+		webhookTriggerEndpoints.Lock()
+		delete(webhookTriggerEndpoints.endpoints, path)
+		webhookTriggerEndpoints.Unlock()
+	})
+	return scaler, path
+}
+
+func pushWebhookTrigger(path, body string, header http.Header) int {
+	r := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	for key, values := range header {
+		r.Header[key] = values
+	}
+	w := httptest.NewRecorder()
+	WebhookTriggerHandler().ServeHTTP(w, r)
+	return w.Code
+}
+
+func TestWebhookTriggerBearerToken(t *testing.T) {
+	scaler, path := newTestWebhookScaler(t, map[string]string{"targetValue": "2"}, map[string]string{"bearerToken": "token"})
+	assert.Equal(t, "/triggers/default/scaledobject/app/ci", path)
+
+	assert.Equal(t, http.StatusNotFound, pushWebhookTrigger(path, `{"value": 4}`, http.Header{"Authorization": {"Bearer wrong"}}))
+	assert.Equal(t, http.StatusNotFound, pushWebhookTrigger(path+"-other", `{"value": 4}`, http.Header{"Authorization": {"Bearer token"}}))
+	assert.Equal(t, http.StatusBadRequest, pushWebhookTrigger(path, `{}`, http.Header{"Authorization": {"Bearer token"}}))
+	assert.Equal(t, http.StatusAccepted, pushWebhookTrigger(path, `{"value": 4}`, http.Header{"Authorization": {"Bearer token"}}))
+
+	metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "s0-webhook")
+	require.NoError(t, err)
+	assert.True(t, active)
+	assert.Equal(t, int64(4000), metrics[0].Value.MilliValue())
+
+	// an activity flag alone keeps the last value
+	assert.Equal(t, http.StatusAccepted, pushWebhookTrigger(path, `{"active": false}`, http.Header{"Authorization": {"Bearer token"}}))
+	metrics, active, err = scaler.GetMetricsAndActivity(context.Background(), "s0-webhook")
+	require.NoError(t, err)
+	assert.False(t, active)
+	assert.Equal(t, int64(4000), metrics[0].Value.MilliValue())
+}
+
+func TestWebhookTriggerHMACSignature(t *testing.T) {
+	scaler, path := newTestWebhookScaler(t, map[string]string{"activationTargetValue": "5"}, map[string]string{"hmacSecret": "secret"})
+
+	body := `{"value": 3}`
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(body))
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.Equal(t, http.StatusNotFound, pushWebhookTrigger(path, body, http.Header{"X-Keda-Signature-256": {"sha256=00"}}))
+	assert.Equal(t, http.StatusNotFound, pushWebhookTrigger(path, `{"value": 30}`, http.Header{"X-Keda-Signature-256": {signature}}))
+	assert.Equal(t, http.StatusAccepted, pushWebhookTrigger(path, body, http.Header{"X-Keda-Signature-256": {signature}}))
+
+	// the value is below the activation target
+	_, active, err := scaler.GetMetricsAndActivity(context.Background(), "s0-webhook")
+	require.NoError(t, err)
+	assert.False(t, active)
+}
+
+func TestWebhookTriggerTTL(t *testing.T) {
+	scaler, path := newTestWebhookScaler(t, map[string]string{"valueTTL": "1h"}, map[string]string{"bearerToken": "token"})
+	endpoint := scaler.(*webhookScaler).endpoint
+
+	assert.Equal(t, http.StatusBadRequest, pushWebhookTrigger(path, `{"value": 4, "ttl": "later"}`, http.Header{"Authorization": {"Bearer token"}}))
+	assert.Equal(t, http.StatusAccepted, pushWebhookTrigger(path, `{"value": 4, "ttl": "1m"}`, http.Header{"Authorization": {"Bearer token"}}))
+
+	value, active := endpoint.current(time.Now().Add(30 * time.Second))
+	assert.Equal(t, float64(4), value)
+	assert.True(t, active)
+	value, active = endpoint.current(time.Now().Add(2 * time.Minute))
+	assert.Equal(t, float64(0), value)
+	assert.False(t, active)
+}
+
+func TestWebhookTriggerRun(t *testing.T) {
+	scaler, path := newTestWebhookScaler(t, map[string]string{}, map[string]string{"bearerToken": "token"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	activeCh := make(chan bool)
+	go scaler.Run(ctx, activeCh)
+
+	// wait for the scaler to subscribe to the endpoint
+	endpoint := scaler.(*webhookScaler).endpoint
+	require.Eventually(t, func() bool {
+		endpoint.mutex.Lock()
+		defer endpoint.mutex.Unlock()
+		return len(endpoint.subscribers) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, http.StatusAccepted, pushWebhookTrigger(path, `{"active": true}`, http.Header{"Authorization": {"Bearer token"}}))
+	select {
+	case active := <-activeCh:
+		assert.True(t, active)
+	case <-time.After(time.Second):
+		t.Fatal("the pushed activity wasn't forwarded")
+	}
+
+	cancel()
+	_, open := <-activeCh
+	assert.False(t, open, "the active channel must be closed")
+}
+
+func TestWebhookTriggerEndpointSurvivesRefresh(t *testing.T) {
+	scaler, path := newTestWebhookScaler(t, map[string]string{}, map[string]string{"bearerToken": "token"})
+	assert.Equal(t, http.StatusAccepted, pushWebhookTrigger(path, `{"value": 7}`, http.Header{"Authorization": {"Bearer token"}}))
+
+	// a refreshed scaler is created before the previous one is closed
+	refreshed, _ := newTestWebhookScaler(t, map[string]string{}, map[string]string{"bearerToken": "token"})
+	require.NoError(t, scaler.Close(context.Background()))
+
+	metrics, _, err := refreshed.GetMetricsAndActivity(context.Background(), "s0-webhook")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7000), metrics[0].Value.MilliValue())
+}
+
+func TestWebhookTriggerValueSurvivesRestart(t *testing.T) {
+	scaler, path := newTestWebhookScaler(t, map[string]string{"valueTTL": "1h"}, map[string]string{"bearerToken": "token"})
+	assert.Equal(t, http.StatusAccepted, pushWebhookTrigger(path, `{"value": 5}`, http.Header{"Authorization": {"Bearer token"}}))
+
+	// the scalers cache is cleared before the scalers are built again
+	require.NoError(t, scaler.Close(context.Background()))
+	assert.Equal(t, http.StatusNotFound, pushWebhookTrigger(path, `{"value": 6}`, http.Header{"Authorization": {"Bearer token"}}))
+
+	rebuilt, _ := newTestWebhookScaler(t, map[string]string{"valueTTL": "1h"}, map[string]string{"bearerToken": "token"})
+	metrics, active, err := rebuilt.GetMetricsAndActivity(context.Background(), "s0-webhook")
+	require.NoError(t, err)
+	assert.True(t, active)
+	assert.Equal(t, int64(5000), metrics[0].Value.MilliValue())
+
+	// an expired value of an unused trigger is removed
+	require.NoError(t, rebuilt.Close(context.Background()))
+	webhookTriggerEndpoints.Lock()
+	pruneWebhookTriggerEndpoints(time.Now().Add(2 * time.Hour))
+	_, kept := webhookTriggerEndpoints.endpoints[path]
+	webhookTriggerEndpoints.Unlock()
+	assert.False(t, kept)
+}
+
+func TestWebhookTriggerServerLeaderLabel(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "keda-operator-0", Namespace: "keda", Labels: map[string]string{"app": "keda-operator"}}}
+	client := fake.NewClientBuilder().WithObjects(pod).Build()
+	server := NewWebhookTriggerServer(client, ":0", pod.Name, pod.Namespace, logr.Discard())
+
+	require.NoError(t, server.SetLeaderLabel(context.Background(), true))
+	labeled := &corev1.Pod{}
+	require.NoError(t, client.Get(context.Background(), types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}, labeled))
+	assert.Equal(t, map[string]string{"app": "keda-operator", WebhookTriggerLeaderLabel: "true"}, labeled.Labels)
+
+	require.NoError(t, server.SetLeaderLabel(context.Background(), false))
+	unlabeled := &corev1.Pod{}
+	require.NoError(t, client.Get(context.Background(), types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}, unlabeled))
+	assert.Equal(t, map[string]string{"app": "keda-operator"}, unlabeled.Labels)
+}
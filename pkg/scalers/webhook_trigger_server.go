@@ -0,0 +1,101 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WebhookTriggerLeaderLabel is set on the operator pod serving the webhook trigger endpoints. The pushed values are
+// only received by the leader, which runs the scalers, so the Service of the endpoints selects the pod with this label.
+const WebhookTriggerLeaderLabel = "keda.sh/webhook-triggers-leader"
+
+// WebhookTriggerServer serves the webhook trigger endpoints on the leader and labels its pod
+// while it serves them, the endpoints are plain HTTP and TLS is terminated in front of the Service
+type WebhookTriggerServer struct {
+	client       client.Client
+	server       *http.Server
+	podName      string
+	podNamespace string
+	logger       logr.Logger
+}
+
+// NewWebhookTriggerServer creates a WebhookTriggerServer for the operator pod, the pod isn't labeled without a pod name
+func NewWebhookTriggerServer(client client.Client, address, podName, podNamespace string, logger logr.Logger) *WebhookTriggerServer {
+	mux := http.NewServeMux()
+	mux.Handle(WebhookTriggerPathPrefix, WebhookTriggerHandler())
+	return &WebhookTriggerServer{
+		client: client,
+		server: &http.Server{
+			Addr:              address,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		podName:      podName,
+		podNamespace: podNamespace,
+		logger:       logger,
+	}
+}
+
+// Start listens on the address, labels the pod once the endpoints are served and removes the label on shutdown
+func (s *WebhookTriggerServer) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return fmt.Errorf("error listening on %s: %w", s.server.Addr, err)
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		s.logger.Info("Starting webhook trigger server", "address", s.server.Addr)
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errChan <- err
+		}
+	}()
+
+	if err := s.SetLeaderLabel(ctx, true); err != nil {
+		s.logger.Error(err, "error labeling the pod serving the webhook triggers, the pushes aren't routed to it")
+	}
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.SetLeaderLabel(shutdownCtx, false); err != nil {
+		s.logger.Error(err, "error removing the webhook triggers leader label of the pod")
+	}
+	return s.server.Shutdown(shutdownCtx)
+}
+
+// NeedLeaderElection is needed to implement LeaderElectionRunnable interface
+// of controller-runtime. The server runs on the leader, whose scalers receive the pushed values.
+func (s *WebhookTriggerServer) NeedLeaderElection() bool {
+	return true
+}
+
+// SetLeaderLabel sets or removes the WebhookTriggerLeaderLabel of the pod, it's removed at startup as well
+// because a pod which lost the leadership keeps its labels when the operator restarts
+func (s *WebhookTriggerServer) SetLeaderLabel(ctx context.Context, leader bool) error {
+	if s.podName == "" {
+		return nil
+	}
+	value := "null"
+	if leader {
+		value = `"true"`
+	}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: s.podName, Namespace: s.podNamespace}}
+	patch := fmt.Sprintf(`{"metadata":{"labels":{%q:%s}}}`, WebhookTriggerLeaderLabel, value)
+	return s.client.Patch(ctx, pod, client.RawPatch(types.MergePatchType, []byte(patch)))
+}
@@ -40,6 +40,8 @@ type ScalersCache struct {
 	ScalableObjectGeneration int64
 	Recorder                 record.EventRecorder
 	CompiledFormula          *vm.Program
+	// PodDeletionCostScaler scrapes the per-pod signal of the pod deletion cost when it isn't reported by a trigger
+	PodDeletionCostScaler scalers.PodMetricsScaler
 }
 
 type ScalerBuilder struct {
@@ -71,6 +73,29 @@ func (c *ScalersCache) GetPushScalers() []scalers.PushScaler {
 	return result
 }
 
+// GetPodDeletionCostScaler returns the scaler reporting the per-pod signal of the pod deletion cost of the ScaledObject,
+// either the pod-metrics trigger it names or the scaler built from its metadata
+func (c *ScalersCache) GetPodDeletionCostScaler() (scalers.PodMetricsScaler, error) {
+	if c.PodDeletionCostScaler != nil {
+		return c.PodDeletionCostScaler, nil
+	}
+	if c.ScaledObject == nil || c.ScaledObject.GetPodDeletionCost() == nil {
+		return nil, fmt.Errorf("pod deletion cost isn't defined")
+	}
+
+	triggerName := c.ScaledObject.GetPodDeletionCost().TriggerName
+	for _, s := range c.Scalers {
+		if s.ScalerConfig.TriggerName != triggerName {
+			continue
+		}
+		if ps, ok := s.Scaler.(scalers.PodMetricsScaler); ok {
+			return ps, nil
+		}
+		return nil, fmt.Errorf("trigger %s doesn't report per-pod values", triggerName)
+	}
+	return nil, fmt.Errorf("trigger %s not found", triggerName)
+}
+
 // Close closes all scalers in the cache
 func (c *ScalersCache) Close(ctx context.Context) {
 	scalers := c.Scalers
@@ -81,6 +106,12 @@ func (c *ScalersCache) Close(ctx context.Context) {
 			log.Error(err, "error closing scaler", "scaler", s)
 		}
 	}
+	if c.PodDeletionCostScaler != nil {
+		if err := c.PodDeletionCostScaler.Close(ctx); err != nil {
+			log.Error(err, "error closing pod deletion cost scaler")
+		}
+		c.PodDeletionCostScaler = nil
+	}
 }
 
 // GetMetricSpecForScaling returns metrics specs for all scalers in the cache
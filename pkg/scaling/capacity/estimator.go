@@ -0,0 +1,223 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacity
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Capacity is the estimated capacity of the cluster for a pod template
+type Capacity struct {
+	// Scheduled is the count of pods matching the selector which are assigned to a node
+	Scheduled int64
+	// Schedulable is the count of additional pods of the template which fit on the nodes
+	Schedulable int64
+}
+
+// Estimator estimates how many pods of a pod template the cluster nodes can schedule
+type Estimator interface {
+	Estimate(ctx context.Context, namespace string, selector labels.Selector, podSpec *corev1.PodSpec) (Capacity, error)
+}
+
+type estimator struct {
+	reader client.Reader
+}
+
+// listPageSize is the count of nodes and pods read per request of the estimation
+const listPageSize = 500
+
+// NewEstimator creates an Estimator listing nodes and pods through the reader. The reader is expected to read
+// from the API server directly, like the API reader of the manager: an informer cache would hold every Node and
+// Pod of the cluster in memory, and with WATCH_NAMESPACE set it would only hold the pods of the watched namespaces,
+// so the resources used by the other pods wouldn't be accounted for. The operator needs to list the nodes and
+// the pods of all the namespaces, an operator only granted namespaced roles can't estimate the capacity.
+func NewEstimator(reader client.Reader) Estimator {
+	return &estimator{reader: reader}
+}
+
+// Estimate returns the count of pods matching the selector in the namespace which are already scheduled and
+// the count of additional pods of the podSpec which fit on the nodes. A pod fits on a Ready and schedulable node
+// matching its node selector, whose NoSchedule and NoExecute taints it tolerates, and whose allocatable resources
+// minus the requests of the pods already running on the node cover the pod requests.
+func (e *estimator) Estimate(ctx context.Context, namespace string, selector labels.Selector, podSpec *corev1.PodSpec) (Capacity, error) {
+	nodes := &corev1.NodeList{}
+	if err := e.listPages(ctx, func() client.ObjectList { return &corev1.NodeList{} }, func(page client.ObjectList) {
+		nodes.Items = append(nodes.Items, page.(*corev1.NodeList).Items...)
+	}); err != nil {
+		return Capacity{}, err
+	}
+	pods := &corev1.PodList{}
+	if err := e.listPages(ctx, func() client.ObjectList { return &corev1.PodList{} }, func(page client.ObjectList) {
+		pods.Items = append(pods.Items, page.(*corev1.PodList).Items...)
+	}); err != nil {
+		return Capacity{}, err
+	}
+
+	capacity := Capacity{}
+	usedResources := map[string]corev1.ResourceList{}
+	podCounts := map[string]int64{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if selector != nil && pod.Namespace == namespace && selector.Matches(labels.Set(pod.Labels)) {
+			capacity.Scheduled++
+		}
+		used, ok := usedResources[pod.Spec.NodeName]
+		if !ok {
+			used = corev1.ResourceList{}
+			usedResources[pod.Spec.NodeName] = used
+		}
+		addResourceList(used, PodRequests(&pod.Spec))
+		podCounts[pod.Spec.NodeName]++
+	}
+
+	requests := PodRequests(podSpec)
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if !isNodeSchedulable(node, podSpec) {
+			continue
+		}
+		capacity.Schedulable += podsFittingOnNode(node, usedResources[node.Name], podCounts[node.Name], requests)
+	}
+	return capacity, nil
+}
+
+// listPages lists the objects page by page, so a large cluster isn't read in a single response
+func (e *estimator) listPages(ctx context.Context, newPage func() client.ObjectList, appendPage func(client.ObjectList)) error {
+	continueToken := ""
+	for {
+		page := newPage()
+		if err := e.reader.List(ctx, page, client.Limit(listPageSize), client.Continue(continueToken)); err != nil {
+			return err
+		}
+		appendPage(page)
+		continueToken = page.GetContinue()
+		if continueToken == "" {
+			return nil
+		}
+	}
+}
+
+// PodRequests returns the resources requested by the pod, the largest init container request
+// is taken into account as init containers run one after another before the containers
+func PodRequests(podSpec *corev1.PodSpec) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, container := range podSpec.Containers {
+		addResourceList(requests, container.Resources.Requests)
+	}
+	for _, container := range podSpec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if value, ok := requests[name]; !ok || quantity.Cmp(value) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	addResourceList(requests, podSpec.Overhead)
+	return requests
+}
+
+// isNodeSchedulable checks whether the pod can be scheduled on the node regardless of its free resources
+func isNodeSchedulable(node *corev1.Node, podSpec *corev1.PodSpec) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+
+	ready := false
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			ready = condition.Status == corev1.ConditionTrue
+			break
+		}
+	}
+	if !ready {
+		return false
+	}
+
+	if !labels.SelectorFromSet(podSpec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return false
+	}
+
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		tolerated := false
+		for j := range podSpec.Tolerations {
+			if podSpec.Tolerations[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+// podsFittingOnNode returns how many pods with the requests fit in the free resources of the node
+func podsFittingOnNode(node *corev1.Node, used corev1.ResourceList, podCount int64, requests corev1.ResourceList) int64 {
+	bounded := false
+	fitting := int64(0)
+	if allocatablePods, ok := node.Status.Allocatable[corev1.ResourcePods]; ok {
+		bounded = true
+		fitting = allocatablePods.Value() - podCount
+	}
+
+	for name, request := range requests {
+		if request.IsZero() || name == corev1.ResourcePods {
+			continue
+		}
+		allocatable, ok := node.Status.Allocatable[name]
+		if !ok {
+			return 0
+		}
+		free := allocatable.DeepCopy()
+		if value, ok := used[name]; ok {
+			free.Sub(value)
+		}
+		count := free.MilliValue() / request.MilliValue()
+		if !bounded || count < fitting {
+			bounded = true
+			fitting = count
+		}
+	}
+
+	if !bounded || fitting < 0 {
+		// a node which doesn't report any allocatable resource isn't considered
+		return 0
+	}
+	return fitting
+}
+
+func addResourceList(list, other corev1.ResourceList) {
+	for name, quantity := range other {
+		if value, ok := list[name]; ok {
+			value.Add(quantity)
+			list[name] = value
+		} else {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
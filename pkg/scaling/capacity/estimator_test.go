@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacity
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func createNode(name string, cpu string, memory string, modifiers ...func(*corev1.Node)) *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"pool": "default"}},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+				corev1.ResourcePods:   resource.MustParse("110"),
+			},
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	for _, modifier := range modifiers {
+		modifier(node)
+	}
+	return node
+}
+
+func createPod(name string, nodeName string, cpu string, memory string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "worker"}},
+		Spec: corev1.PodSpec{
+			NodeName:   nodeName,
+			Containers: []corev1.Container{{Name: "worker", Resources: createRequirements(cpu, memory)}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func createRequirements(cpu string, memory string) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		},
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "worker", Resources: createRequirements("500m", "1Gi")}}}
+	selector := labels.SelectorFromSet(labels.Set{"app": "worker"})
+
+	tests := []struct {
+		name                string
+		objects             []runtime.Object
+		podSpec             *corev1.PodSpec
+		expectedScheduled   int64
+		expectedSchedulable int64
+	}{
+		{
+			name:                "empty nodes",
+			objects:             []runtime.Object{createNode("node-1", "2", "8Gi"), createNode("node-2", "1", "8Gi")},
+			podSpec:             podSpec,
+			expectedSchedulable: 6,
+		},
+		{
+			name: "running pods use the node resources",
+			objects: []runtime.Object{
+				createNode("node-1", "2", "8Gi"),
+				createPod("worker-1", "node-1", "500m", "1Gi"),
+				createPod("worker-2", "node-1", "500m", "1Gi"),
+			},
+			podSpec:             podSpec,
+			expectedScheduled:   2,
+			expectedSchedulable: 2,
+		},
+		{
+			name:                "the scarcest resource limits the pods",
+			objects:             []runtime.Object{createNode("node-1", "4", "2Gi")},
+			podSpec:             podSpec,
+			expectedSchedulable: 2,
+		},
+		{
+			name: "unschedulable and not ready nodes are skipped",
+			objects: []runtime.Object{
+				createNode("node-1", "2", "8Gi", func(node *corev1.Node) { node.Spec.Unschedulable = true }),
+				createNode("node-2", "2", "8Gi", func(node *corev1.Node) {
+					node.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}}
+				}),
+				createNode("node-3", "1", "8Gi"),
+			},
+			podSpec:             podSpec,
+			expectedSchedulable: 2,
+		},
+		{
+			name: "tainted nodes are skipped unless the taint is tolerated",
+			objects: []runtime.Object{
+				createNode("node-1", "2", "8Gi", func(node *corev1.Node) {
+					node.Spec.Taints = []corev1.Taint{{Key: "gpu", Effect: corev1.TaintEffectNoSchedule}}
+				}),
+				createNode("node-2", "2", "8Gi", func(node *corev1.Node) {
+					node.Spec.Taints = []corev1.Taint{{Key: "spot", Effect: corev1.TaintEffectNoSchedule}}
+				}),
+			},
+			podSpec: &corev1.PodSpec{
+				Containers:  podSpec.Containers,
+				Tolerations: []corev1.Toleration{{Key: "spot", Operator: corev1.TolerationOpExists}},
+			},
+			expectedSchedulable: 4,
+		},
+		{
+			name: "nodes not matching the node selector are skipped",
+			objects: []runtime.Object{
+				createNode("node-1", "2", "8Gi"),
+				createNode("node-2", "2", "8Gi", func(node *corev1.Node) { node.Labels["pool"] = "batch" }),
+			},
+			podSpec: &corev1.PodSpec{
+				Containers:   podSpec.Containers,
+				NodeSelector: map[string]string{"pool": "batch"},
+			},
+			expectedSchedulable: 4,
+		},
+		{
+			name: "allocatable pods limit the pods",
+			objects: []runtime.Object{
+				createNode("node-1", "2", "8Gi", func(node *corev1.Node) {
+					node.Status.Allocatable[corev1.ResourcePods] = resource.MustParse("3")
+				}),
+				createPod("other", "node-1", "0", "0"),
+			},
+			podSpec:             podSpec,
+			expectedScheduled:   1,
+			expectedSchedulable: 2,
+		},
+		{
+			name: "overcommitted nodes don't have capacity",
+			objects: []runtime.Object{
+				createNode("node-1", "1", "8Gi"),
+				createPod("worker-1", "node-1", "2", "1Gi"),
+			},
+			podSpec:           podSpec,
+			expectedScheduled: 1,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			estimator := NewEstimator(fake.NewClientBuilder().WithRuntimeObjects(tt.objects...).Build())
+			capacity, err := estimator.Estimate(context.Background(), "default", selector, tt.podSpec)
+			if err != nil {
+				t.Fatalf("Expected no error but got %v", err)
+			}
+			if capacity.Scheduled != tt.expectedScheduled {
+				t.Errorf("Expected %d scheduled pods, got %d", tt.expectedScheduled, capacity.Scheduled)
+			}
+			if capacity.Schedulable != tt.expectedSchedulable {
+				t.Errorf("Expected %d schedulable pods, got %d", tt.expectedSchedulable, capacity.Schedulable)
+			}
+		})
+	}
+}
+
+func TestPodRequests(t *testing.T) {
+	podSpec := &corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "init", Resources: createRequirements("2", "128Mi")}},
+		Containers: []corev1.Container{
+			{Name: "app", Resources: createRequirements("500m", "256Mi")},
+			{Name: "sidecar", Resources: createRequirements("100m", "64Mi")},
+		},
+		Overhead: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m")},
+	}
+
+	requests := PodRequests(podSpec)
+	if cpu := requests[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse("2050m")) != 0 {
+		t.Errorf("Expected 2050m cpu, got %s", cpu.String())
+	}
+	if memory := requests[corev1.ResourceMemory]; memory.Cmp(resource.MustParse("320Mi")) != 0 {
+		t.Errorf("Expected 320Mi memory, got %s", memory.String())
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedastatus "github.com/kedacore/keda/v2/pkg/status"
+)
+
+const (
+	capacityLimitedReason       = "CapacityLimited"
+	capacityNotLimitedReason    = "CapacityNotLimited"
+	capacityNotLimitedMessage   = "Scaling is not limited by the cluster capacity"
+	capacityEstimationErrReason = "CapacityEstimationFailed"
+)
+
+// applyCapacityGuard caps the max replicas of the HPA to the replicas the cluster nodes can schedule for the pod template
+// of the scale target, and lifts the cap once there is enough capacity again. The cap is stored in the status, so the
+// HPA generated by the ScaledObject controller keeps it too.
+func (e *scaleExecutor) applyCapacityGuard(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, podTemplate *corev1.PodTemplateSpec, selector *metav1.LabelSelector) {
+	guard := scaledObject.GetCapacityGuard()
+	maxReplicas := scaledObject.GetHPAMaxReplicas()
+	if !guard.IsCapacityGuardEnabled() {
+		if scaledObject.Status.CapacityLimitedReplicas != nil {
+			e.updateCapacityLimitedReplicas(ctx, logger, scaledObject, nil, maxReplicas, metav1.ConditionFalse, capacityNotLimitedReason, capacityNotLimitedMessage)
+		}
+		return
+	}
+	if podTemplate == nil {
+		logger.V(1).Info("Capacity guard is only supported for Deployments and StatefulSets", "scaleTarget.Kind", scaledObject.Status.ScaleTargetKind)
+		return
+	}
+
+	podSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		logger.Error(err, "Error parsing the selector of the scale target for the capacity guard")
+		return
+	}
+	capacity, err := e.capacityEstimator.Estimate(ctx, scaledObject.Namespace, podSelector, &podTemplate.Spec)
+	if err != nil {
+		logger.Error(err, "Error estimating the cluster capacity for the scale target")
+		if condition := scaledObject.Status.Conditions.GetCapacityLimitedCondition(); !condition.IsUnknown() || condition.Reason != capacityEstimationErrReason {
+			if err := e.setCapacityLimitedCondition(ctx, logger, scaledObject, metav1.ConditionUnknown, capacityEstimationErrReason, err.Error()); err != nil {
+				logger.Error(err, "Error setting capacity limited condition")
+			}
+		}
+		return
+	}
+
+	limit := capacity.Scheduled + capacity.Schedulable + int64(guard.Headroom)
+	if floor := int64(*scaledObject.GetHPAMinReplicas()); limit < floor {
+		limit = floor
+	}
+	if limit >= int64(maxReplicas) {
+		condition := scaledObject.Status.Conditions.GetCapacityLimitedCondition()
+		if scaledObject.Status.CapacityLimitedReplicas != nil || !condition.IsFalse() {
+			e.updateCapacityLimitedReplicas(ctx, logger, scaledObject, nil, maxReplicas, metav1.ConditionFalse, capacityNotLimitedReason, capacityNotLimitedMessage)
+		}
+		return
+	}
+
+	limited := int32(limit)
+	if current := scaledObject.Status.CapacityLimitedReplicas; current == nil || *current != limited {
+		logger.Info("Capping the max replicas to the cluster capacity", "maxReplicas", maxReplicas, "capacityLimitedReplicas", limited)
+		message := fmt.Sprintf("Max replicas are capped from %d to %d because the cluster can't schedule more pods", maxReplicas, limited)
+		e.updateCapacityLimitedReplicas(ctx, logger, scaledObject, &limited, limited, metav1.ConditionTrue, capacityLimitedReason, message)
+	}
+}
+
+// updateCapacityLimitedReplicas stores the capped replicas in the status, sets the CapacityLimited condition and
+// updates the max replicas of the HPA
+func (e *scaleExecutor) updateCapacityLimitedReplicas(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, limited *int32, hpaMaxReplicas int32, conditionStatus metav1.ConditionStatus, reason string, message string) {
+	if scaledObject.Status.HpaName != "" {
+		hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+		if err := e.client.Get(ctx, client.ObjectKey{Name: scaledObject.Status.HpaName, Namespace: scaledObject.Namespace}, hpa); err != nil {
+			logger.Error(err, "Error getting the HPA to apply the capacity guard")
+			return
+		}
+		if hpa.Spec.MaxReplicas != hpaMaxReplicas {
+			patch := client.MergeFrom(hpa.DeepCopy())
+			hpa.Spec.MaxReplicas = hpaMaxReplicas
+			if hpa.Spec.MinReplicas != nil && *hpa.Spec.MinReplicas > hpaMaxReplicas {
+				hpa.Spec.MinReplicas = ptr.To(hpaMaxReplicas)
+			}
+			if err := e.client.Patch(ctx, hpa, patch); err != nil {
+				logger.Error(err, "Error updating the HPA max replicas for the capacity guard")
+				return
+			}
+		}
+	}
+
+	status := scaledObject.Status.DeepCopy()
+	status.CapacityLimitedReplicas = limited
+	status.Conditions.SetCapacityLimitedCondition(conditionStatus, reason, message)
+	if err := kedastatus.UpdateScaledObjectStatus(ctx, e.client, logger, scaledObject, status); err != nil {
+		logger.Error(err, "Error updating the capacity limited replicas")
+	}
+}
+
+// capJobsToCapacity caps the count of Jobs to create to the Jobs the cluster nodes can schedule, a Job runs
+// as many pods as its parallelism, and updates the CapacityLimited condition of the ScaledJob
+func (e *scaleExecutor) capJobsToCapacity(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, maxScale int64) int64 {
+	guard := scaledJob.Spec.CapacityGuard
+	if !guard.IsCapacityGuardEnabled() || scaledJob.Spec.JobTargetRef == nil {
+		return maxScale
+	}
+
+	condition := scaledJob.Status.Conditions.GetCapacityLimitedCondition()
+	capacity, err := e.capacityEstimator.Estimate(ctx, scaledJob.Namespace, nil, &scaledJob.Spec.JobTargetRef.Template.Spec)
+	if err != nil {
+		logger.Error(err, "Error estimating the cluster capacity for the jobs")
+		if !condition.IsUnknown() || condition.Reason != capacityEstimationErrReason {
+			if err := e.setCapacityLimitedCondition(ctx, logger, scaledJob, metav1.ConditionUnknown, capacityEstimationErrReason, err.Error()); err != nil {
+				logger.Error(err, "Error setting capacity limited condition")
+			}
+		}
+		return maxScale
+	}
+
+	parallelism := int64(1)
+	if p := scaledJob.Spec.JobTargetRef.Parallelism; p != nil && *p > 1 {
+		parallelism = int64(*p)
+	}
+	limit := (capacity.Schedulable + int64(guard.Headroom)) / parallelism
+	if limit >= maxScale {
+		if !condition.IsFalse() {
+			if err := e.setCapacityLimitedCondition(ctx, logger, scaledJob, metav1.ConditionFalse, capacityNotLimitedReason, capacityNotLimitedMessage); err != nil {
+				logger.Error(err, "Error setting capacity limited condition")
+			}
+		}
+		return maxScale
+	}
+
+	logger.Info("Capping the jobs to the cluster capacity", "maxScale", maxScale, "capacityLimitedJobs", limit)
+	message := fmt.Sprintf("Jobs are capped from %d to %d because the cluster can't schedule more pods", maxScale, limit)
+	if !condition.IsTrue() || condition.Message != message {
+		if err := e.setCapacityLimitedCondition(ctx, logger, scaledJob, metav1.ConditionTrue, capacityLimitedReason, message); err != nil {
+			logger.Error(err, "Error setting capacity limited condition")
+		}
+	}
+	return limit
+}
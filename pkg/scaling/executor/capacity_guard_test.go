@@ -0,0 +1,195 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
+	"github.com/kedacore/keda/v2/pkg/scaling/capacity"
+)
+
+type staticEstimator struct {
+	capacity capacity.Capacity
+}
+
+func (e *staticEstimator) Estimate(_ context.Context, _ string, _ labels.Selector, _ *corev1.PodSpec) (capacity.Capacity, error) {
+	return e.capacity, nil
+}
+
+func TestCapJobsToCapacity(t *testing.T) {
+	tests := []struct {
+		name        string
+		guard       *kedav1alpha1.CapacityGuard
+		schedulable int64
+		parallelism *int32
+		maxScale    int64
+		expected    int64
+		condition   metav1.ConditionStatus
+	}{
+		{
+			name:        "guard disabled",
+			guard:       nil,
+			schedulable: 1,
+			maxScale:    10,
+			expected:    10,
+			condition:   metav1.ConditionUnknown,
+		},
+		{
+			name:        "enough capacity",
+			guard:       &kedav1alpha1.CapacityGuard{Enabled: true},
+			schedulable: 10,
+			maxScale:    5,
+			expected:    5,
+			condition:   metav1.ConditionFalse,
+		},
+		{
+			name:        "jobs capped to the capacity",
+			guard:       &kedav1alpha1.CapacityGuard{Enabled: true},
+			schedulable: 3,
+			maxScale:    5,
+			expected:    3,
+			condition:   metav1.ConditionTrue,
+		},
+		{
+			name:        "headroom is added to the capacity",
+			guard:       &kedav1alpha1.CapacityGuard{Enabled: true, Headroom: 1},
+			schedulable: 0,
+			maxScale:    5,
+			expected:    1,
+			condition:   metav1.ConditionTrue,
+		},
+		{
+			name:        "capacity is divided by the job parallelism",
+			guard:       &kedav1alpha1.CapacityGuard{Enabled: true},
+			schedulable: 7,
+			parallelism: ptr.To[int32](3),
+			maxScale:    5,
+			expected:    2,
+			condition:   metav1.ConditionTrue,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			client := mock_client.NewMockClient(ctrl)
+			statusWriter := mock_client.NewMockStatusWriter(ctrl)
+			client.EXPECT().Status().AnyTimes().Return(statusWriter)
+			statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+			scaleExecutor := getMockScaleExecutor(client)
+			scaleExecutor.capacityEstimator = &staticEstimator{capacity: capacity.Capacity{Schedulable: tt.schedulable}}
+
+			scaledJob := getMockScaledJobWithDefault()
+			scaledJob.Spec.CapacityGuard = tt.guard
+			scaledJob.Spec.JobTargetRef = &batchv1.JobSpec{Parallelism: tt.parallelism}
+			scaledJob.Status.Conditions = *kedav1alpha1.GetInitializedConditions()
+
+			maxScale := scaleExecutor.capJobsToCapacity(context.TODO(), logf.Log.WithName("CapJobsToCapacityTest"), scaledJob, tt.maxScale)
+
+			assert.Equal(t, tt.expected, maxScale)
+			condition := scaledJob.Status.Conditions.GetCapacityLimitedCondition()
+			assert.Equal(t, tt.condition, condition.Status)
+		})
+	}
+}
+
+func TestCapacityGuardCapsHPAMaxReplicas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+	scaleExecutor.capacityEstimator = &staticEstimator{capacity: capacity.Capacity{Scheduled: 2, Schedulable: 1}}
+
+	scaledObject := getCapacityGuardScaledObject()
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, autoscalingv2.HorizontalPodAutoscaler{
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{MinReplicas: ptr.To[int32](1), MaxReplicas: 10},
+	})
+	client.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, obj runtimeclient.Object, _ runtimeclient.Patch, _ ...runtimeclient.PatchOption) error {
+		hpa := obj.(*autoscalingv2.HorizontalPodAutoscaler)
+		assert.Equal(t, int32(4), hpa.Spec.MaxReplicas)
+		return nil
+	})
+	client.EXPECT().Status().Return(statusWriter)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any())
+
+	scaleExecutor.applyCapacityGuard(context.TODO(), logf.Log.WithName("CapacityGuardTest"), scaledObject, &corev1.PodTemplateSpec{}, &metav1.LabelSelector{})
+
+	assert.Equal(t, ptr.To[int32](4), scaledObject.Status.CapacityLimitedReplicas)
+	condition := scaledObject.Status.Conditions.GetCapacityLimitedCondition()
+	assert.True(t, condition.IsTrue())
+}
+
+func TestCapacityGuardRestoresHPAMaxReplicas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+	scaleExecutor.capacityEstimator = &staticEstimator{capacity: capacity.Capacity{Scheduled: 4, Schedulable: 20}}
+
+	scaledObject := getCapacityGuardScaledObject()
+	scaledObject.Status.CapacityLimitedReplicas = ptr.To[int32](4)
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, autoscalingv2.HorizontalPodAutoscaler{
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{MinReplicas: ptr.To[int32](1), MaxReplicas: 4},
+	})
+	client.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, obj runtimeclient.Object, _ runtimeclient.Patch, _ ...runtimeclient.PatchOption) error {
+		hpa := obj.(*autoscalingv2.HorizontalPodAutoscaler)
+		assert.Equal(t, int32(10), hpa.Spec.MaxReplicas)
+		return nil
+	})
+	client.EXPECT().Status().Return(statusWriter)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any())
+
+	scaleExecutor.applyCapacityGuard(context.TODO(), logf.Log.WithName("CapacityGuardTest"), scaledObject, &corev1.PodTemplateSpec{}, &metav1.LabelSelector{})
+
+	assert.Nil(t, scaledObject.Status.CapacityLimitedReplicas)
+	condition := scaledObject.Status.Conditions.GetCapacityLimitedCondition()
+	assert.True(t, condition.IsFalse())
+}
+
+func getCapacityGuardScaledObject() *kedav1alpha1.ScaledObject {
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "namespace"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef:  &kedav1alpha1.ScaleTarget{Name: "name"},
+			MaxReplicaCount: ptr.To[int32](10),
+			Advanced: &kedav1alpha1.AdvancedConfig{
+				CapacityGuard: &kedav1alpha1.CapacityGuard{Enabled: true, Headroom: 1},
+			},
+		},
+		Status: kedav1alpha1.ScaledObjectStatus{HpaName: "keda-hpa-name"},
+	}
+	scaledObject.Status.Conditions = *kedav1alpha1.GetInitializedConditions()
+	return scaledObject
+}
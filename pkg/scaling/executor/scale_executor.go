@@ -19,6 +19,7 @@ package executor
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,6 +30,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling/capacity"
 	kedastatus "github.com/kedacore/keda/v2/pkg/status"
 )
 
@@ -37,10 +39,13 @@ const (
 	defaultCooldownPeriod = 5 * 60 // 5 minutes
 )
 
-// ScaleExecutor contains methods RequestJobScale and RequestScale
+// ScaleExecutor contains methods RequestJobScale, RequestScale and PopLastScale
 type ScaleExecutor interface {
 	RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, scaleTo int64, maxScale int64)
 	RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool, options *ScaleExecutorOptions)
+	// PopLastScale returns the last scale of the scale target done by KEDA which isn't recorded in the status
+	// of the ScaledObject yet, the caller writes it along with its own status update
+	PopLastScale(scaledObject *kedav1alpha1.ScaledObject) (metav1.Time, int32, bool)
 }
 
 // ScaleExecutorOptions contains the optional parameters for the RequestScale method.
@@ -49,21 +54,32 @@ type ScaleExecutorOptions struct {
 }
 
 type scaleExecutor struct {
-	client           runtimeclient.Client
-	scaleClient      scale.ScalesGetter
-	reconcilerScheme *runtime.Scheme
-	logger           logr.Logger
-	recorder         record.EventRecorder
+	client            runtimeclient.Client
+	scaleClient       scale.ScalesGetter
+	reconcilerScheme  *runtime.Scheme
+	logger            logr.Logger
+	recorder          record.EventRecorder
+	capacityEstimator capacity.Estimator
+	// lastScales holds the scales done by KEDA until they are written to the ScaledObject status
+	lastScales sync.Map
 }
 
-// NewScaleExecutor creates a ScaleExecutor object
-func NewScaleExecutor(client runtimeclient.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, recorder record.EventRecorder) ScaleExecutor {
+// lastScale is a scale of the scale target done by KEDA
+type lastScale struct {
+	time     metav1.Time
+	replicas int32
+}
+
+// NewScaleExecutor creates a ScaleExecutor object, the apiReader reads from the API server directly
+// for the estimations of the capacity guard
+func NewScaleExecutor(client runtimeclient.Client, apiReader runtimeclient.Reader, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, recorder record.EventRecorder) ScaleExecutor {
 	return &scaleExecutor{
-		client:           client,
-		scaleClient:      scaleClient,
-		reconcilerScheme: reconcilerScheme,
-		logger:           logf.Log.WithName("scaleexecutor"),
-		recorder:         recorder,
+		client:            client,
+		scaleClient:       scaleClient,
+		reconcilerScheme:  reconcilerScheme,
+		logger:            logf.Log.WithName("scaleexecutor"),
+		recorder:          recorder,
+		capacityEstimator: capacity.NewEstimator(apiReader),
 	}
 }
 
@@ -131,3 +147,10 @@ func (e *scaleExecutor) setFallbackCondition(ctx context.Context, logger logr.Lo
 	}
 	return e.setCondition(ctx, logger, object, status, reason, message, fallback)
 }
+
+func (e *scaleExecutor) setCapacityLimitedCondition(ctx context.Context, logger logr.Logger, object interface{}, status metav1.ConditionStatus, reason string, message string) error {
+	capacityLimited := func(conditions kedav1alpha1.Conditions, status metav1.ConditionStatus, reason string, message string) {
+		conditions.SetCapacityLimitedCondition(status, reason, message)
+	}
+	return e.setCondition(ctx, logger, object, status, reason, message, capacityLimited)
+}
@@ -48,6 +48,7 @@ func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1al
 
 	effectiveMaxScale, scaleTo := e.getScalingDecision(scaledJob, runningJobCount, scaleTo, maxScale, pendingJobCount, logger)
 
+	effectiveMaxScale = e.capJobsToCapacity(ctx, logger, scaledJob, effectiveMaxScale)
 	if effectiveMaxScale < 0 {
 		effectiveMaxScale = 0
 	}
@@ -80,7 +81,14 @@ func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1al
 		}
 	}
 
-	err := e.cleanUp(ctx, scaledJob)
+	// the versions are counted before the finished Jobs are cleaned up, the succeeded Jobs of the new version
+	// decide when the Jobs of the previous versions are stopped
+	err := e.rolloutJobVersions(ctx, logger, scaledJob)
+	if err != nil {
+		logger.Error(err, "Failed to roll out job versions")
+	}
+
+	err = e.cleanUp(ctx, scaledJob)
 	if err != nil {
 		logger.Error(err, "Failed to cleanUp jobs")
 	}
@@ -124,14 +132,15 @@ func (e *scaleExecutor) generateJobs(logger logr.Logger, scaledJob *kedav1alpha1
 	if scaledJob.Spec.JobTargetRef.Template.Labels == nil {
 		scaledJob.Spec.JobTargetRef.Template.Labels = map[string]string{}
 	}
-	scaledJob.Spec.JobTargetRef.Template.Labels["scaledjob.keda.sh/name"] = scaledJob.GetName()
+	scaledJob.Spec.JobTargetRef.Template.Labels[kedav1alpha1.ScaledJobNameLabel] = scaledJob.GetName()
 
 	labels := map[string]string{
-		"app.kubernetes.io/name":       scaledJob.GetName(),
-		"app.kubernetes.io/version":    version.Version,
-		"app.kubernetes.io/part-of":    scaledJob.GetName(),
-		"app.kubernetes.io/managed-by": "keda-operator",
-		"scaledjob.keda.sh/name":       scaledJob.GetName(),
+		"app.kubernetes.io/name":           scaledJob.GetName(),
+		"app.kubernetes.io/version":        version.Version,
+		"app.kubernetes.io/part-of":        scaledJob.GetName(),
+		"app.kubernetes.io/managed-by":     "keda-operator",
+		kedav1alpha1.ScaledJobNameLabel:    scaledJob.GetName(),
+		kedav1alpha1.ScaledJobVersionLabel: scaledJob.Version(),
 	}
 	for key, value := range scaledJob.ObjectMeta.Labels {
 		labels[key] = value
@@ -176,12 +185,22 @@ func (e *scaleExecutor) isJobFinished(j *batchv1.Job) bool {
 	return false
 }
 
+// countedJobsLabels returns the labels of the Jobs counted as running or pending, the Jobs of the previous
+// versions of the ScaledJob are left out when the scaling strategy excludes them
+func countedJobsLabels(scaledJob *kedav1alpha1.ScaledJob) map[string]string {
+	labels := map[string]string{kedav1alpha1.ScaledJobNameLabel: scaledJob.GetName()}
+	if scaledJob.Spec.ScalingStrategy.ExcludePreviousVersions {
+		labels[kedav1alpha1.ScaledJobVersionLabel] = scaledJob.Version()
+	}
+	return labels
+}
+
 func (e *scaleExecutor) getRunningJobCount(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) int64 {
 	var runningJobs int64
 
 	opts := []client.ListOption{
 		client.InNamespace(scaledJob.GetNamespace()),
-		client.MatchingLabels(map[string]string{"scaledjob.keda.sh/name": scaledJob.GetName()}),
+		client.MatchingLabels(countedJobsLabels(scaledJob)),
 	}
 
 	jobs := &batchv1.JobList{}
@@ -255,7 +274,7 @@ func (e *scaleExecutor) getPendingJobCount(ctx context.Context, scaledJob *kedav
 
 	opts := []client.ListOption{
 		client.InNamespace(scaledJob.GetNamespace()),
-		client.MatchingLabels(map[string]string{"scaledjob.keda.sh/name": scaledJob.GetName()}),
+		client.MatchingLabels(countedJobsLabels(scaledJob)),
 	}
 
 	jobs := &batchv1.JobList{}
@@ -290,7 +309,7 @@ func (e *scaleExecutor) cleanUp(ctx context.Context, scaledJob *kedav1alpha1.Sca
 
 	opts := []client.ListOption{
 		client.InNamespace(scaledJob.GetNamespace()),
-		client.MatchingLabels(map[string]string{"scaledjob.keda.sh/name": scaledJob.GetName()}),
+		client.MatchingLabels(map[string]string{kedav1alpha1.ScaledJobNameLabel: scaledJob.GetName()}),
 	}
 
 	jobs := &batchv1.JobList{}
@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	kedastatus "github.com/kedacore/keda/v2/pkg/status"
+)
+
+// unversionedJobs is the version the Jobs created before the versions of the ScaledJob were tracked are counted under
+const unversionedJobs = "unversioned"
+
+// rolloutJobVersions counts the Jobs of every version of the ScaledJob in its status and, with the gradual
+// rollout strategy, stops the running Jobs of the previous versions once a condition of stopPreviousVersion is met
+func (e *scaleExecutor) rolloutJobVersions(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob) error {
+	rollout := scaledJob.Status.Rollout
+	if rollout == nil {
+		// the version of the ScaledJob isn't recorded by the controller yet
+		return nil
+	}
+
+	opts := []client.ListOption{
+		client.InNamespace(scaledJob.GetNamespace()),
+		client.MatchingLabels(map[string]string{kedav1alpha1.ScaledJobNameLabel: scaledJob.GetName()}),
+	}
+	jobs := &batchv1.JobList{}
+	if err := e.client.List(ctx, jobs, opts...); err != nil {
+		return err
+	}
+
+	versions := map[string]*kedav1alpha1.ScaledJobVersionStatus{
+		rollout.CurrentVersion: {Version: rollout.CurrentVersion},
+	}
+	var previousVersionJobs []batchv1.Job
+	for _, job := range jobs.Items {
+		job := job
+		version := jobVersion(&job)
+		status, found := versions[version]
+		if !found {
+			status = &kedav1alpha1.ScaledJobVersionStatus{Version: version}
+			versions[version] = status
+		}
+		switch e.getFinishedJobConditionType(&job) {
+		case batchv1.JobComplete:
+			status.Succeeded++
+		case batchv1.JobFailed:
+			status.Failed++
+		default:
+			status.Running++
+			if version != rollout.CurrentVersion {
+				previousVersionJobs = append(previousVersionJobs, job)
+			}
+		}
+	}
+
+	if len(previousVersionJobs) > 0 && shouldStopPreviousVersion(scaledJob, versions[rollout.CurrentVersion]) {
+		propagationPolicy := metav1.DeletePropagationBackground
+		if scaledJob.Spec.Rollout.PropagationPolicy == "foreground" {
+			propagationPolicy = metav1.DeletePropagationForeground
+		}
+		for _, job := range previousVersionJobs {
+			job := job
+			if err := e.client.Delete(ctx, &job, client.PropagationPolicy(propagationPolicy)); err != nil {
+				return err
+			}
+			versions[jobVersion(&job)].Running--
+			logger.Info("Stopped a job of a previous version of the scaledJob", "job.Name", job.Name, "version", jobVersion(&job))
+		}
+		e.recorder.Eventf(scaledJob, corev1.EventTypeNormal, eventreason.KEDAPreviousVersionJobsStopped, "Stopped %d jobs of the previous versions", len(previousVersionJobs))
+	}
+
+	versionStatuses := make([]kedav1alpha1.ScaledJobVersionStatus, 0, len(versions))
+	for _, status := range versions {
+		versionStatuses = append(versionStatuses, *status)
+	}
+	// the current version comes first, then the previous ones by name
+	sort.Slice(versionStatuses, func(i, j int) bool {
+		if versionStatuses[i].Version == rollout.CurrentVersion || versionStatuses[j].Version == rollout.CurrentVersion {
+			return versionStatuses[i].Version == rollout.CurrentVersion
+		}
+		return versionStatuses[i].Version < versionStatuses[j].Version
+	})
+	if reflect.DeepEqual(rollout.Versions, versionStatuses) {
+		return nil
+	}
+
+	updated := rollout.DeepCopy()
+	updated.Versions = versionStatuses
+	return kedastatus.UpdateScaledJobRolloutStatus(ctx, e.client, logger, scaledJob, updated)
+}
+
+// shouldStopPreviousVersion returns whether the running Jobs of the previous versions of the ScaledJob are stopped,
+// once the grace period after the rollout is over or enough Jobs of the current version have succeeded
+func shouldStopPreviousVersion(scaledJob *kedav1alpha1.ScaledJob, current *kedav1alpha1.ScaledJobVersionStatus) bool {
+	stop := scaledJob.Spec.Rollout.StopPreviousVersion
+	if scaledJob.GetRolloutStrategy() != "gradual" || stop == nil {
+		return false
+	}
+
+	rolloutTime := scaledJob.Status.Rollout.RolloutTime
+	if stop.GracePeriod != nil && rolloutTime != nil && time.Since(rolloutTime.Time) >= stop.GracePeriod.Duration {
+		return true
+	}
+	return stop.SucceededJobs != nil && current.Succeeded >= *stop.SucceededJobs
+}
+
+func jobVersion(job *batchv1.Job) string {
+	if version, found := job.GetLabels()[kedav1alpha1.ScaledJobVersionLabel]; found {
+		return version
+	}
+	return unversionedJobs
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func getRolloutScaledJob(rollout kedav1alpha1.Rollout, rolloutTime time.Time) *kedav1alpha1.ScaledJob {
+	scaledJob := &kedav1alpha1.ScaledJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec: kedav1alpha1.ScaledJobSpec{
+			JobTargetRef: &batchv1.JobSpec{},
+			Rollout:      rollout,
+		},
+	}
+	scaledJob.Status.Rollout = &kedav1alpha1.ScaledJobRolloutStatus{
+		CurrentVersion: scaledJob.Version(),
+		RolloutTime:    &metav1.Time{Time: rolloutTime},
+	}
+	return scaledJob
+}
+
+func getVersionedJob(name, version string, conditionType batchv1.JobConditionType) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels:    map[string]string{kedav1alpha1.ScaledJobNameLabel: "test"},
+		},
+	}
+	if version != "" {
+		job.Labels[kedav1alpha1.ScaledJobVersionLabel] = version
+	}
+	if conditionType != "" {
+		job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}}
+	}
+	return job
+}
+
+func getRolloutScaleExecutor(t *testing.T, scaledJob *kedav1alpha1.ScaledJob, jobs ...runtimeclient.Object) (*scaleExecutor, runtimeclient.Client) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(kedav1alpha1.AddToScheme(scheme))
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(append(jobs, scaledJob.DeepCopy())...).
+		WithStatusSubresource(&kedav1alpha1.ScaledJob{}).
+		Build()
+
+	return &scaleExecutor{
+		client:           client,
+		reconcilerScheme: scheme,
+		logger:           logf.Log.WithName("scaleexecutor"),
+		recorder:         record.NewFakeRecorder(10),
+	}, client
+}
+
+func TestRolloutJobVersions(t *testing.T) {
+	tests := []struct {
+		name            string
+		rollout         kedav1alpha1.Rollout
+		rolloutTime     time.Time
+		expectedStopped bool
+	}{
+		{
+			name:            "immediate strategy doesn't stop previous versions",
+			rollout:         kedav1alpha1.Rollout{StopPreviousVersion: &kedav1alpha1.StopPreviousVersion{SucceededJobs: ptr.To[int32](1)}},
+			rolloutTime:     time.Now(),
+			expectedStopped: false,
+		},
+		{
+			name:            "gradual strategy without stopPreviousVersion",
+			rollout:         kedav1alpha1.Rollout{Strategy: "gradual"},
+			rolloutTime:     time.Now().Add(-time.Hour),
+			expectedStopped: false,
+		},
+		{
+			name:            "grace period not over",
+			rollout:         kedav1alpha1.Rollout{Strategy: "gradual", StopPreviousVersion: &kedav1alpha1.StopPreviousVersion{GracePeriod: &metav1.Duration{Duration: time.Hour}}},
+			rolloutTime:     time.Now(),
+			expectedStopped: false,
+		},
+		{
+			name:            "grace period over",
+			rollout:         kedav1alpha1.Rollout{Strategy: "gradual", StopPreviousVersion: &kedav1alpha1.StopPreviousVersion{GracePeriod: &metav1.Duration{Duration: time.Hour}}},
+			rolloutTime:     time.Now().Add(-2 * time.Hour),
+			expectedStopped: true,
+		},
+		{
+			name:            "not enough jobs of the current version succeeded",
+			rollout:         kedav1alpha1.Rollout{Strategy: "gradual", StopPreviousVersion: &kedav1alpha1.StopPreviousVersion{SucceededJobs: ptr.To[int32](2)}},
+			rolloutTime:     time.Now(),
+			expectedStopped: false,
+		},
+		{
+			name:            "jobs of the current version succeeded",
+			rollout:         kedav1alpha1.Rollout{Strategy: "gradual", StopPreviousVersion: &kedav1alpha1.StopPreviousVersion{SucceededJobs: ptr.To[int32](1)}},
+			rolloutTime:     time.Now(),
+			expectedStopped: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			scaledJob := getRolloutScaledJob(tt.rollout, tt.rolloutTime)
+			current := scaledJob.Version()
+			executor, client := getRolloutScaleExecutor(t, scaledJob,
+				getVersionedJob("current-running", current, ""),
+				getVersionedJob("current-succeeded", current, batchv1.JobComplete),
+				getVersionedJob("previous-running", "previous", ""),
+				getVersionedJob("previous-failed", "previous", batchv1.JobFailed),
+				getVersionedJob("unversioned-running", "", ""),
+			)
+
+			err := executor.rolloutJobVersions(ctx, logf.Log, scaledJob)
+			require.NoError(t, err)
+
+			jobs := &batchv1.JobList{}
+			require.NoError(t, client.List(ctx, jobs))
+			remaining := map[string]bool{}
+			for _, job := range jobs.Items {
+				remaining[job.Name] = true
+			}
+			assert.True(t, remaining["current-running"])
+			assert.True(t, remaining["previous-failed"])
+			assert.Equal(t, !tt.expectedStopped, remaining["previous-running"])
+			assert.Equal(t, !tt.expectedStopped, remaining["unversioned-running"])
+
+			previousRunning := int32(1)
+			if tt.expectedStopped {
+				previousRunning = 0
+			}
+			expectedVersions := []kedav1alpha1.ScaledJobVersionStatus{
+				{Version: current, Running: 1, Succeeded: 1},
+				{Version: "previous", Running: previousRunning, Failed: 1},
+				{Version: unversionedJobs, Running: previousRunning},
+			}
+			assert.Equal(t, expectedVersions, scaledJob.Status.Rollout.Versions)
+
+			stored := &kedav1alpha1.ScaledJob{}
+			require.NoError(t, client.Get(ctx, runtimeclient.ObjectKeyFromObject(scaledJob), stored))
+			assert.Equal(t, expectedVersions, stored.Status.Rollout.Versions)
+		})
+	}
+}
+
+func TestRunningJobCountExcludesPreviousVersions(t *testing.T) {
+	ctx := context.Background()
+	scaledJob := getRolloutScaledJob(kedav1alpha1.Rollout{Strategy: "gradual"}, time.Now())
+	current := scaledJob.Version()
+	jobs := []runtimeclient.Object{
+		getVersionedJob("current-running", current, ""),
+		getVersionedJob("previous-running", "previous", ""),
+		getVersionedJob("unversioned-running", "", ""),
+	}
+
+	executor, _ := getRolloutScaleExecutor(t, scaledJob, jobs...)
+	assert.Equal(t, int64(3), executor.getRunningJobCount(ctx, scaledJob))
+
+	scaledJob.Spec.ScalingStrategy.ExcludePreviousVersions = true
+	assert.Equal(t, int64(1), executor.getRunningJobCount(ctx, scaledJob))
+}
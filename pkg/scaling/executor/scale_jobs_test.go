@@ -333,6 +333,7 @@ func TestGenerateJobs(t *testing.T) {
 	client := mock_client.NewMockClient(ctrl)
 	scaleExecutor := getMockScaleExecutor(client)
 	scaledJob := getMockScaledJobWithDefaultStrategyAndMeta("test")
+	expectedLabels[kedav1alpha1.ScaledJobVersionLabel] = scaledJob.Version()
 
 	jobs := scaleExecutor.generateJobs(logger, scaledJob, 2)
 
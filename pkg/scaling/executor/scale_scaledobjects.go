@@ -26,6 +26,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -42,6 +43,8 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 	// to reduce API calls. Everything else uses the scale subresource.
 	var currentScale *autoscalingv1.Scale
 	var currentReplicas int32
+	var podTemplate *corev1.PodTemplateSpec
+	var podSelector *metav1.LabelSelector
 	targetName := scaledObject.Spec.ScaleTargetRef.Name
 	targetGVKR := scaledObject.Status.ScaleTargetGVKR
 	switch {
@@ -53,6 +56,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			return
 		}
 		currentReplicas = *deployment.Spec.Replicas
+		podTemplate, podSelector = &deployment.Spec.Template, deployment.Spec.Selector
 	case targetGVKR.Group == "apps" && targetGVKR.Kind == "StatefulSet":
 		statefulSet := &appsv1.StatefulSet{}
 		err := e.client.Get(ctx, client.ObjectKey{Name: targetName, Namespace: scaledObject.Namespace}, statefulSet)
@@ -61,6 +65,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			return
 		}
 		currentReplicas = *statefulSet.Spec.Replicas
+		podTemplate, podSelector = &statefulSet.Spec.Template, statefulSet.Spec.Selector
 	default:
 		var err error
 		currentScale, err = e.getScaleTargetScale(ctx, scaledObject)
@@ -90,6 +95,10 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 		logger.Error(err, "error getting the paused replica count on the current ScaledObject.")
 		return
 	}
+	// followers are synced on every scale loop, so they also follow the scaling done by the HPA,
+	// the paused replica count and the scale loops which end early because of an error
+	defer e.scaleFollowers(ctx, logger, scaledObject)
+
 	status := scaledObject.Status.DeepCopy()
 	if pausedCount != nil {
 		// Scale the target to the paused replica count
@@ -116,6 +125,8 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 		return
 	}
 
+	e.applyCapacityGuard(ctx, logger, scaledObject, podTemplate, podSelector)
+
 	// if scaledObject.Spec.MinReplicaCount is not set, then set the default value (0)
 	minReplicas := int32(0)
 	if scaledObject.Spec.MinReplicaCount != nil {
@@ -134,6 +145,18 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// AND
 			// replica count is equal to 0
 
+			// ScaledObjects generated for a ScaledGroup wake up only once the previous wake stage is ready,
+			// otherwise the next scale loop tries it again
+			if !e.isWakeOrderSatisfied(ctx, logger, scaledObject) {
+				activeCondition := scaledObject.Status.Conditions.GetActiveCondition()
+				if !activeCondition.IsFalse() || activeCondition.Reason != "WaitingForWakeOrder" {
+					if err := e.setActiveCondition(ctx, logger, scaledObject, metav1.ConditionFalse, "WaitingForWakeOrder", "Scaling is waiting for the previous wake stage of the ScaledGroup to be ready"); err != nil {
+						logger.Error(err, "Error setting active condition when waiting for the wake order")
+					}
+				}
+				return
+			}
+
 			// Scale the ScaleTarget up
 			e.scaleFromZeroOrIdle(ctx, logger, scaledObject, currentScale, options.ActiveTriggers)
 		case isError:
@@ -328,6 +351,136 @@ func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Log
 	}
 }
 
+// isWakeOrderSatisfied checks that the scale targets of all ScaledObjects listed in
+// the ScaledGroupWakeAfterAnnotation have at least one ready replica
+func (e *scaleExecutor) isWakeOrderSatisfied(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) bool {
+	wakeAfter := scaledObject.GetAnnotations()[kedav1alpha1.ScaledGroupWakeAfterAnnotation]
+	if wakeAfter == "" {
+		return true
+	}
+
+	for _, name := range strings.Split(wakeAfter, ",") {
+		dependency := &kedav1alpha1.ScaledObject{}
+		err := e.client.Get(ctx, client.ObjectKey{Name: strings.TrimSpace(name), Namespace: scaledObject.Namespace}, dependency)
+		if errors.IsNotFound(err) {
+			// the dependency has left the group, there is nothing to wait for
+			continue
+		}
+		if err != nil {
+			logger.Error(err, "Error getting ScaledObject to wake up after", "dependency", name)
+			return false
+		}
+
+		readyReplicas, err := e.getReadyReplicas(ctx, dependency)
+		if err != nil {
+			logger.Error(err, "Error getting ready replicas of ScaledObject to wake up after", "dependency", name)
+			return false
+		}
+		if readyReplicas == 0 {
+			logger.V(1).Info("Waiting for ScaleTarget of the previous wake stage to be ready", "dependency", name)
+			return false
+		}
+	}
+	return true
+}
+
+// getReadyReplicas returns the count of ready replicas of the ScaledObject's scale target,
+// targets which aren't Deployments or StatefulSets report the replicas count from the /scale subresource
+func (e *scaleExecutor) getReadyReplicas(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (int32, error) {
+	targetGVKR := scaledObject.Status.ScaleTargetGVKR
+	if targetGVKR == nil {
+		return 0, nil
+	}
+
+	targetName := scaledObject.Spec.ScaleTargetRef.Name
+	switch {
+	case targetGVKR.Group == "apps" && targetGVKR.Kind == "Deployment":
+		deployment := &appsv1.Deployment{}
+		if err := e.client.Get(ctx, client.ObjectKey{Name: targetName, Namespace: scaledObject.Namespace}, deployment); err != nil {
+			return 0, err
+		}
+		return deployment.Status.ReadyReplicas, nil
+	case targetGVKR.Group == "apps" && targetGVKR.Kind == "StatefulSet":
+		statefulSet := &appsv1.StatefulSet{}
+		if err := e.client.Get(ctx, client.ObjectKey{Name: targetName, Namespace: scaledObject.Namespace}, statefulSet); err != nil {
+			return 0, err
+		}
+		return statefulSet.Status.ReadyReplicas, nil
+	default:
+		scale, err := e.getScaleTargetScale(ctx, scaledObject)
+		if err != nil {
+			return 0, err
+		}
+		return scale.Status.Replicas, nil
+	}
+}
+
+// scaleFollowers sets the replica count of every follower resolved by the ScaledObject controller
+// according to the current replica count of the scale target
+func (e *scaleExecutor) scaleFollowers(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) {
+	if len(scaledObject.Spec.Followers) == 0 || len(scaledObject.Status.Followers) == 0 {
+		return
+	}
+
+	// the scale target might have been scaled in this loop, so the replica count is read from the /scale subresource and not from the cache
+	leaderScale, err := e.getScaleTargetScale(ctx, scaledObject)
+	if err != nil {
+		logger.Error(err, "Error getting information on the current Scale of the scaleTarget for followers")
+		return
+	}
+
+	for i := range scaledObject.Spec.Followers {
+		follower := &scaledObject.Spec.Followers[i]
+		followerStatus := getFollowerStatus(scaledObject, follower)
+		if followerStatus == nil {
+			// the follower wasn't resolved by the controller yet
+			continue
+		}
+
+		replicas, err := follower.GetDesiredReplicas(leaderScale.Spec.Replicas)
+		if err != nil {
+			logger.Error(err, "Error computing replicas count of follower", "follower", follower.ScaleTargetRef.Name)
+			continue
+		}
+
+		gr := followerStatus.ScaleTargetGVKR.GroupResource()
+		scale, err := e.scaleClient.Scales(scaledObject.Namespace).Get(ctx, gr, follower.ScaleTargetRef.Name, metav1.GetOptions{})
+		if err != nil {
+			logger.Error(err, "Error getting information on the current Scale of follower", "follower", follower.ScaleTargetRef.Name)
+			continue
+		}
+		if scale.Spec.Replicas == replicas {
+			continue
+		}
+
+		currentReplicas := scale.Spec.Replicas
+		scale.Spec.Replicas = replicas
+		if _, err := e.scaleClient.Scales(scaledObject.Namespace).Update(ctx, gr, scale, metav1.UpdateOptions{}); err != nil {
+			logger.Error(err, "Error scaling follower", "follower", follower.ScaleTargetRef.Name)
+			continue
+		}
+		logger.Info("Successfully scaled follower",
+			"follower", follower.ScaleTargetRef.Name,
+			"Leader Replicas Count", leaderScale.Spec.Replicas,
+			"Original Replicas Count", currentReplicas,
+			"New Replicas Count", replicas)
+	}
+}
+
+// getFollowerStatus returns the status of the follower or nil if it isn't resolved yet
+func getFollowerStatus(scaledObject *kedav1alpha1.ScaledObject, follower *kedav1alpha1.Follower) *kedav1alpha1.FollowerStatus {
+	for i := range scaledObject.Status.Followers {
+		status := &scaledObject.Status.Followers[i]
+		if status.Name != follower.ScaleTargetRef.Name {
+			continue
+		}
+		if follower.ScaleTargetRef.Kind == "" || status.ScaleTargetGVKR.Kind == follower.ScaleTargetRef.Kind {
+			return status
+		}
+	}
+	return nil
+}
+
 func (e *scaleExecutor) getScaleTargetScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (*autoscalingv1.Scale, error) {
 	return e.scaleClient.Scales(scaledObject.Namespace).Get(ctx, scaledObject.Status.ScaleTargetGVKR.GroupResource(), scaledObject.Spec.ScaleTargetRef.Name, metav1.GetOptions{})
 }
@@ -347,9 +500,23 @@ func (e *scaleExecutor) updateScaleOnScaleTarget(ctx context.Context, scaledObje
 	scale.Spec.Replicas = replicas
 
 	_, err := e.scaleClient.Scales(scaledObject.Namespace).Update(ctx, scaledObject.Status.ScaleTargetGVKR.GroupResource(), scale, metav1.UpdateOptions{})
+	if err == nil && currentReplicas != replicas {
+		e.lastScales.Store(scaledObject.GenerateIdentifier(), lastScale{time: metav1.Now(), replicas: replicas})
+	}
 	return currentReplicas, err
 }
 
+// PopLastScale returns the time and the replica count of the last scale of the scale target done by KEDA,
+// it isn't written by the executor to avoid an extra status patch on every scale
+func (e *scaleExecutor) PopLastScale(scaledObject *kedav1alpha1.ScaledObject) (metav1.Time, int32, bool) {
+	value, ok := e.lastScales.LoadAndDelete(scaledObject.GenerateIdentifier())
+	if !ok {
+		return metav1.Time{}, 0, false
+	}
+	scale := value.(lastScale)
+	return scale.time, scale.replicas, true
+}
+
 // getIdleOrMinimumReplicaCount returns true if the second value returned is from IdleReplicaCount
 // it returns false if it is from MinReplicaCount followed by the actual value
 func getIdleOrMinimumReplicaCount(scaledObject *kedav1alpha1.ScaledObject) (bool, int32) {
@@ -25,8 +25,12 @@ import (
 	"go.uber.org/mock/gomock"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
@@ -41,7 +45,7 @@ func TestScaleToFallbackReplicasWhenNotActiveAndIsError(t *testing.T) {
 	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
 	statusWriter := mock_client.NewMockStatusWriter(ctrl)
 
-	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+	scaleExecutor := NewScaleExecutor(client, client, mockScaleClient, nil, recorder)
 
 	scaledObject := v1alpha1.ScaledObject{
 		ObjectMeta: v1.ObjectMeta{
@@ -103,7 +107,7 @@ func TestScaleToMinReplicasWhenNotActive(t *testing.T) {
 	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
 	statusWriter := mock_client.NewMockStatusWriter(ctrl)
 
-	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+	scaleExecutor := NewScaleExecutor(client, client, mockScaleClient, nil, recorder)
 
 	minReplicas := int32(0)
 
@@ -152,6 +156,11 @@ func TestScaleToMinReplicasWhenNotActive(t *testing.T) {
 	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false, &ScaleExecutorOptions{})
 
 	assert.Equal(t, minReplicas, scale.Spec.Replicas)
+	_, desiredReplicas, ok := scaleExecutor.PopLastScale(&scaledObject)
+	assert.True(t, ok)
+	assert.Equal(t, minReplicas, desiredReplicas)
+	_, _, ok = scaleExecutor.PopLastScale(&scaledObject)
+	assert.False(t, ok, "the last scale must be returned once")
 	condition := scaledObject.Status.Conditions.GetActiveCondition()
 	assert.Equal(t, true, condition.IsFalse())
 }
@@ -164,7 +173,7 @@ func TestScaleToMinReplicasFromLowerInitialReplicaCount(t *testing.T) {
 	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
 	statusWriter := mock_client.NewMockStatusWriter(ctrl)
 
-	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+	scaleExecutor := NewScaleExecutor(client, client, mockScaleClient, nil, recorder)
 
 	minReplicas := int32(5)
 
@@ -225,7 +234,7 @@ func TestScaleFromMinReplicasWhenActive(t *testing.T) {
 	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
 	statusWriter := mock_client.NewMockStatusWriter(ctrl)
 
-	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+	scaleExecutor := NewScaleExecutor(client, client, mockScaleClient, nil, recorder)
 
 	minReplicas := int32(0)
 
@@ -266,7 +275,7 @@ func TestScaleFromMinReplicasWhenActive(t *testing.T) {
 	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(scale, nil)
 	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any())
 
-	client.EXPECT().Status().Times(2).Return(statusWriter).Times(3)
+	client.EXPECT().Status().Return(statusWriter).Times(3)
 	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
 
 	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, &ScaleExecutorOptions{})
@@ -284,7 +293,7 @@ func TestScaleToIdleReplicasWhenNotActive(t *testing.T) {
 	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
 	statusWriter := mock_client.NewMockStatusWriter(ctrl)
 
-	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+	scaleExecutor := NewScaleExecutor(client, client, mockScaleClient, nil, recorder)
 
 	idleReplicas := int32(0)
 	minReplicas := int32(5)
@@ -347,7 +356,7 @@ func TestScaleFromIdleToMinReplicasWhenActive(t *testing.T) {
 	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
 	statusWriter := mock_client.NewMockStatusWriter(ctrl)
 
-	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+	scaleExecutor := NewScaleExecutor(client, client, mockScaleClient, nil, recorder)
 
 	idleReplicas := int32(0)
 	minReplicas := int32(5)
@@ -390,7 +399,7 @@ func TestScaleFromIdleToMinReplicasWhenActive(t *testing.T) {
 	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(scale, nil)
 	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any())
 
-	client.EXPECT().Status().Times(2).Return(statusWriter).Times(3)
+	client.EXPECT().Status().Return(statusWriter).Times(3)
 	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
 
 	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, &ScaleExecutorOptions{})
@@ -408,7 +417,7 @@ func TestScaleToPausedReplicasCount(t *testing.T) {
 	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
 	statusWriter := mock_client.NewMockStatusWriter(ctrl)
 
-	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+	scaleExecutor := NewScaleExecutor(client, client, mockScaleClient, nil, recorder)
 
 	pausedReplicaCount := int32(0)
 	replicaCount := int32(2)
@@ -470,7 +479,7 @@ func TestEventWitTriggerInfo(t *testing.T) {
 	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
 	statusWriter := mock_client.NewMockStatusWriter(ctrl)
 
-	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+	scaleExecutor := NewScaleExecutor(client, client, mockScaleClient, nil, recorder)
 
 	replicaCount := int32(2)
 	idleReplicas := int32(0)
@@ -522,3 +531,193 @@ func TestEventWitTriggerInfo(t *testing.T) {
 	eventstring := <-recorder.Events
 	assert.Equal(t, "Normal KEDAScaleTargetActivated Scaled  namespace/name from 2 to 5, triggered by testTrigger", eventstring)
 }
+
+func TestScaleFollowersWhenScaledFromZero(t *testing.T) {
+	leaderScale, followerScale := testRequestScaleWithFollower(t, 0, 0, true)
+
+	assert.Equal(t, int32(1), leaderScale.Spec.Replicas)
+	assert.Equal(t, int32(1), followerScale.Spec.Replicas)
+}
+
+func TestScaleFollowersWhenScaledToZero(t *testing.T) {
+	leaderScale, followerScale := testRequestScaleWithFollower(t, 1, 1, false)
+
+	assert.Equal(t, int32(0), leaderScale.Spec.Replicas)
+	assert.Equal(t, int32(0), followerScale.Spec.Replicas)
+}
+
+// testRequestScaleWithFollower runs a scale loop of a ScaledObject which has a follower at a quarter of the leader replicas
+// and returns the scale of the leader and of the follower after the loop
+func testRequestScaleWithFollower(t *testing.T, leaderReplicas, followerReplicas int32, isActive bool) (*autoscalingv1.Scale, *autoscalingv1.Scale) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(10)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, client, mockScaleClient, nil, recorder)
+
+	minReplicas := int32(0)
+	deploymentGVKR := v1alpha1.GroupVersionKindResource{
+		Group:    "apps",
+		Version:  "v1",
+		Kind:     "Deployment",
+		Resource: "deployments",
+	}
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "leader",
+			},
+			MinReplicaCount: &minReplicas,
+			Followers: []v1alpha1.Follower{
+				{
+					ScaleTargetRef: v1alpha1.ScaleTarget{Name: "follower"},
+					Ratio:          "0.25",
+				},
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &deploymentGVKR,
+			Followers: []v1alpha1.FollowerStatus{
+				{Name: "follower", ScaleTargetGVKR: deploymentGVKR},
+			},
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &leaderReplicas,
+		},
+	})
+
+	leaderScale := &autoscalingv1.Scale{
+		Spec: autoscalingv1.ScaleSpec{
+			Replicas: leaderReplicas,
+		},
+	}
+	followerScale := &autoscalingv1.Scale{
+		Spec: autoscalingv1.ScaleSpec{
+			Replicas: followerReplicas,
+		},
+	}
+
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).AnyTimes()
+	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Eq("leader"), gomock.Any()).Return(leaderScale, nil).AnyTimes()
+	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Eq("follower"), gomock.Any()).Return(followerScale, nil)
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(leaderScale), gomock.Any())
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(followerScale), gomock.Any())
+
+	client.EXPECT().Status().Return(statusWriter).AnyTimes()
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, isActive, false, &ScaleExecutorOptions{})
+
+	return leaderScale, followerScale
+}
+
+func TestWakeOrderBlocksUntilPreviousStageIsReady(t *testing.T) {
+	scale, scaledObject := testRequestScaleWithWakeOrder(t, ptr.To[int32](0))
+
+	assert.Equal(t, int32(0), scale.Spec.Replicas)
+	condition := scaledObject.Status.Conditions.GetActiveCondition()
+	assert.True(t, condition.IsFalse())
+	assert.Equal(t, "WaitingForWakeOrder", condition.Reason)
+}
+
+func TestWakeOrderWakesOnceThePreviousStageIsReady(t *testing.T) {
+	scale, scaledObject := testRequestScaleWithWakeOrder(t, ptr.To[int32](1))
+
+	assert.Equal(t, int32(1), scale.Spec.Replicas)
+	condition := scaledObject.Status.Conditions.GetActiveCondition()
+	assert.True(t, condition.IsTrue())
+}
+
+func TestWakeOrderIgnoresMissingPreviousStage(t *testing.T) {
+	scale, scaledObject := testRequestScaleWithWakeOrder(t, nil)
+
+	assert.Equal(t, int32(1), scale.Spec.Replicas)
+	condition := scaledObject.Status.Conditions.GetActiveCondition()
+	assert.True(t, condition.IsTrue())
+}
+
+// testRequestScaleWithWakeOrder runs an active scale loop of a ScaledObject scaled to zero which wakes up after the
+// ScaledObject "previous-stage", whose Deployment has the given ready replicas or which doesn't exist if nil
+func testRequestScaleWithWakeOrder(t *testing.T, dependencyReadyReplicas *int32) (*autoscalingv1.Scale, *v1alpha1.ScaledObject) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(10)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, client, mockScaleClient, nil, recorder)
+
+	deploymentGVKR := v1alpha1.GroupVersionKindResource{
+		Group: "apps",
+		Kind:  "Deployment",
+	}
+	scaledObject := &v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "next-stage",
+			Namespace: "namespace",
+			Annotations: map[string]string{
+				v1alpha1.ScaledGroupWakeAfterAnnotation: "previous-stage",
+			},
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "frontend",
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &deploymentGVKR,
+		},
+	}
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, key runtimeclient.ObjectKey, obj runtimeclient.Object, _ ...runtimeclient.GetOption) error {
+			switch o := obj.(type) {
+			case *v1alpha1.ScaledObject:
+				if dependencyReadyReplicas == nil {
+					return errors.NewNotFound(schema.GroupResource{Group: "keda.sh", Resource: "scaledobjects"}, key.Name)
+				}
+				o.Name = key.Name
+				o.Namespace = key.Namespace
+				o.Spec.ScaleTargetRef = &v1alpha1.ScaleTarget{Name: "database"}
+				o.Status.ScaleTargetGVKR = &deploymentGVKR
+			case *appsv1.Deployment:
+				o.Spec.Replicas = ptr.To[int32](0)
+				if key.Name == "database" {
+					o.Status.ReadyReplicas = *dependencyReadyReplicas
+				}
+			}
+			return nil
+		}).AnyTimes()
+
+	scale := &autoscalingv1.Scale{
+		Spec: autoscalingv1.ScaleSpec{
+			Replicas: 0,
+		},
+	}
+
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).AnyTimes()
+	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(scale, nil).AnyTimes()
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any()).AnyTimes()
+
+	client.EXPECT().Status().Return(statusWriter).AnyTimes()
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	scaleExecutor.RequestScale(context.TODO(), scaledObject, true, false, &ScaleExecutorOptions{})
+
+	return scale, scaledObject
+}
@@ -30,6 +30,8 @@ import (
 	"strings"
 
 	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/parser"
 	"github.com/go-logr/logr"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/metrics/pkg/apis/external_metrics"
@@ -147,3 +149,27 @@ func GetPairTriggerAndMetric(so *kedav1alpha1.ScaledObject, metric string, trigg
 	}
 	return map[string]string{}, nil
 }
+
+// GetFormulaTriggerNames returns the names of the triggers referenced by the
+// scalingModifiers formula, a trigger which isn't referenced doesn't need to
+// be a metric source of the composite metric
+func GetFormulaTriggerNames(formula string) (map[string]bool, error) {
+	tree, err := parser.Parse(formula)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing scalingModifiers formula: %w", err)
+	}
+	visitor := &identifierVisitor{names: map[string]bool{}}
+	ast.Walk(&tree.Node, visitor)
+	return visitor.names, nil
+}
+
+// identifierVisitor collects the identifiers of a formula
+type identifierVisitor struct {
+	names map[string]bool
+}
+
+func (v *identifierVisitor) Visit(node *ast.Node) {
+	if identifier, ok := (*node).(*ast.IdentifierNode); ok {
+		v.names[identifier.Value] = true
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modifiers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFormulaTriggerNames(t *testing.T) {
+	names, err := GetFormulaTriggerNames("cpu_trig > 70 ? max(cpu_trig/70, queue_trig) : float(queue_trig)")
+	require.NoError(t, err)
+	assert.True(t, names["cpu_trig"])
+	assert.True(t, names["queue_trig"])
+	assert.False(t, names["memory_trig"])
+
+	_, err = GetFormulaTriggerNames("cpu_trig +")
+	assert.Error(t, err)
+}
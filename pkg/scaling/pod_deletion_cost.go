@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
+)
+
+// buildPodDeletionCostScaler returns the pod-metrics scaler scraping the per-pod signal of the pod deletion cost
+// from its metadata, or nil when the signal is reported by a trigger of the ScaledObject
+func (h *scaleHandler) buildPodDeletionCostScaler(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, podTemplateSpec *corev1.PodTemplateSpec) (scalers.PodMetricsScaler, error) {
+	cost := scaledObject.GetPodDeletionCost()
+	if cost == nil || len(cost.Metadata) == 0 {
+		return nil, nil
+	}
+
+	logger := log.WithValues("scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+	authParams, podIdentity, err := resolver.ResolveAuthRefAndPodIdentity(ctx, h.client, logger, cost.AuthenticationRef, "pod-metrics", podTemplateSpec, scaledObject.Namespace, h.secretsLister)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving the authentication of the pod deletion cost: %w", err)
+	}
+
+	scaler, err := scalers.NewPodMetricsScaler(h.client, h.apiReader, &scalersconfig.ScalerConfig{
+		ScalableObjectName:      scaledObject.Name,
+		ScalableObjectNamespace: scaledObject.Namespace,
+		ScalableObjectType:      "ScaledObject",
+		TriggerName:             "pod-deletion-cost",
+		TriggerMetadata:         cost.Metadata,
+		AuthParams:              authParams,
+		PodIdentity:             podIdentity,
+		GlobalHTTPTimeout:       h.globalHTTPTimeout,
+		AsMetricSource:          true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return scaler.(scalers.PodMetricsScaler), nil
+}
+
+// startPodDeletionCostLoop updates the pod deletion cost of the ScaledObject on every polling interval, apart from
+// the scale loop so scraping and patching the pods doesn't delay the scaling
+func (h *scaleHandler) startPodDeletionCostLoop(ctx context.Context, withTriggers *kedav1alpha1.WithTriggers, scaledObject *kedav1alpha1.ScaledObject) {
+	logger := log.WithValues("scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+	ticker := time.NewTicker(withTriggers.GetPollingInterval())
+	defer ticker.Stop()
+
+	for {
+		h.updatePodDeletionCost(ctx, logger, scaledObject)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			logger.V(1).Info("Context canceled, stopping the pod deletion cost updates")
+			return
+		}
+	}
+}
+
+// updatePodDeletionCost writes the pod deletion cost annotation on the pods of the scale target from their per-pod
+// signal, so the pods doing the least work are removed first on scale in. The pods whose signal can't be read keep their cost.
+func (h *scaleHandler) updatePodDeletionCost(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) {
+	if scaledObject.GetPodDeletionCost() == nil {
+		return
+	}
+
+	cache, err := h.getScalersCacheForScaledObject(ctx, scaledObject.Name, scaledObject.Namespace)
+	if err != nil {
+		logger.Error(err, "error getting scalers cache for the pod deletion cost")
+		return
+	}
+	scaler, err := cache.GetPodDeletionCostScaler()
+	if err != nil {
+		logger.Error(err, "error getting the scaler of the pod deletion cost")
+		return
+	}
+	podValues, err := scaler.GetPodMetrics(ctx)
+	if err != nil {
+		logger.Error(err, "error getting the per-pod values of the pod deletion cost")
+		return
+	}
+	if len(podValues) == 0 {
+		return
+	}
+
+	selector, err := scalers.GetScaleTargetPodSelector(ctx, h.client, "ScaledObject", scaledObject.Name, scaledObject.Namespace)
+	if err != nil {
+		logger.Error(err, "error getting the pod selector of the scale target for the pod deletion cost")
+		return
+	}
+	pods := &corev1.PodList{}
+	if err := h.apiReader.List(ctx, pods, client.InNamespace(scaledObject.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		logger.Error(err, "error listing the pods to update their deletion cost")
+		return
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		value, found := podValues[pod.Name]
+		if !found {
+			continue
+		}
+
+		cost := strconv.Itoa(int(podDeletionCost(value)))
+		if pod.GetAnnotations()[kedav1alpha1.PodDeletionCostAnnotation] == cost {
+			continue
+		}
+		patch := client.MergeFrom(pod.DeepCopy())
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[kedav1alpha1.PodDeletionCostAnnotation] = cost
+		if err := h.client.Patch(ctx, pod, patch); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				logger.Error(err, "error updating the pod deletion cost", "pod", pod.Name)
+			}
+			continue
+		}
+		logger.V(1).Info("Updated the pod deletion cost", "pod", pod.Name, "cost", cost)
+	}
+}
+
+// podDeletionCost returns the value rounded to the int32 range of the pod deletion cost
+func podDeletionCost(value float64) int32 {
+	switch {
+	case math.IsNaN(value):
+		return 0
+	case value >= math.MaxInt32:
+		return math.MaxInt32
+	case value <= math.MinInt32:
+		return math.MinInt32
+	default:
+		return int32(math.Round(value))
+	}
+}
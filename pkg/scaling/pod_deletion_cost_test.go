@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+func newPodDeletionCostTestPod(name string, cost string) *corev1.Pod {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "worker"}}}
+	if cost != "" {
+		pod.Annotations = map[string]string{kedav1alpha1.PodDeletionCostAnnotation: cost}
+	}
+	return pod
+}
+
+func TestUpdatePodDeletionCost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	scaler := mock_scalers.NewMockPodMetricsScaler(ctrl)
+	scaler.EXPECT().GetPodMetrics(gomock.Any()).Return(map[string]float64{"busy": 4.6, "idle": 0, "unchanged": 2, "deleted": 1, "other": 3}, nil)
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "worker"},
+			Advanced:       &kedav1alpha1.AdvancedConfig{PodDeletionCost: &kedav1alpha1.PodDeletionCost{TriggerName: "busy-workers"}},
+			Triggers:       []kedav1alpha1.ScaleTriggers{{Name: "busy-workers", Type: "pod-metrics"}},
+		},
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "worker"}}},
+	}
+	otherPod := newPodDeletionCostTestPod("other", "")
+	otherPod.Labels = map[string]string{"app": "other"}
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		scaledObject,
+		deployment,
+		newPodDeletionCostTestPod("busy", ""),
+		newPodDeletionCostTestPod("idle", "3"),
+		newPodDeletionCostTestPod("unchanged", "2"),
+		newPodDeletionCostTestPod("unscraped", "7"),
+		otherPod,
+	).Build()
+
+	sh := scaleHandler{
+		client:    kubeClient,
+		apiReader: kubeClient,
+		scalerCaches: map[string]*cache.ScalersCache{
+			scaledObject.GenerateIdentifier(): {
+				ScaledObject: scaledObject,
+				Scalers: []cache.ScalerBuilder{{
+					Scaler:       scaler,
+					ScalerConfig: scalersconfig.ScalerConfig{TriggerName: "busy-workers"},
+				}},
+			},
+		},
+		scalerCachesLock: &sync.RWMutex{},
+	}
+	sh.updatePodDeletionCost(context.Background(), logf.Log.WithName("test"), scaledObject)
+
+	expected := map[string]string{"busy": "5", "idle": "0", "unchanged": "2", "unscraped": "7", "other": ""}
+	for name, cost := range expected {
+		pod := &corev1.Pod{}
+		require.NoError(t, kubeClient.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, pod))
+		assert.Equal(t, cost, pod.Annotations[kedav1alpha1.PodDeletionCostAnnotation], name)
+	}
+}
+
+func TestBuildPodDeletionCostScalerAuthentication(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("busy_workers 3\n"))
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	pod := newPodDeletionCostTestPod("worker-1", "")
+	pod.Status = corev1.PodStatus{Phase: corev1.PodRunning, PodIP: serverURL.Hostname()}
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		pod,
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "metrics", Namespace: "default"}, Data: map[string][]byte{"token": []byte("s3cret")}},
+		&kedav1alpha1.TriggerAuthentication{
+			ObjectMeta: metav1.ObjectMeta{Name: "metrics", Namespace: "default"},
+			Spec: kedav1alpha1.TriggerAuthenticationSpec{
+				SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{{Parameter: "bearerToken", Name: "metrics", Key: "token"}},
+			},
+		},
+	).Build()
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "worker"},
+			Advanced: &kedav1alpha1.AdvancedConfig{PodDeletionCost: &kedav1alpha1.PodDeletionCost{
+				Metadata:          map[string]string{"metricName": "busy_workers", "port": serverURL.Port(), "podSelector": "app=worker"},
+				AuthenticationRef: &kedav1alpha1.AuthenticationRef{Name: "metrics"},
+			}},
+		},
+	}
+
+	sh := scaleHandler{client: kubeClient, apiReader: kubeClient, globalHTTPTimeout: time.Second}
+	scaler, err := sh.buildPodDeletionCostScaler(context.Background(), scaledObject, nil)
+	require.NoError(t, err)
+	defer scaler.Close(context.Background())
+
+	podValues, err := scaler.GetPodMetrics(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"worker-1": 3}, podValues)
+}
+
+func TestGetPodDeletionCostScaler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	podMetricsScaler := mock_scalers.NewMockPodMetricsScaler(ctrl)
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	scaledObject := &kedav1alpha1.ScaledObject{
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Advanced: &kedav1alpha1.AdvancedConfig{PodDeletionCost: &kedav1alpha1.PodDeletionCost{TriggerName: "busy-workers"}},
+		},
+	}
+	scalersCache := &cache.ScalersCache{
+		ScaledObject: scaledObject,
+		Scalers: []cache.ScalerBuilder{
+			{Scaler: scaler, ScalerConfig: scalersconfig.ScalerConfig{TriggerName: "queue"}},
+			{Scaler: podMetricsScaler, ScalerConfig: scalersconfig.ScalerConfig{TriggerName: "busy-workers"}},
+		},
+	}
+
+	found, err := scalersCache.GetPodDeletionCostScaler()
+	require.NoError(t, err)
+	assert.Equal(t, podMetricsScaler, found)
+
+	scaledObject.Spec.Advanced.PodDeletionCost.TriggerName = "queue"
+	_, err = scalersCache.GetPodDeletionCostScaler()
+	assert.Error(t, err)
+
+	scalersCache.PodDeletionCostScaler = podMetricsScaler
+	found, err = scalersCache.GetPodDeletionCostScaler()
+	require.NoError(t, err)
+	assert.Equal(t, podMetricsScaler, found)
+}
+
+func TestPodDeletionCostRange(t *testing.T) {
+	assert.Equal(t, int32(0), podDeletionCost(0.4))
+	assert.Equal(t, int32(-3), podDeletionCost(-2.5))
+	assert.Equal(t, int32(math.MaxInt32), podDeletionCost(1e12))
+	assert.Equal(t, int32(math.MinInt32), podDeletionCost(-1e12))
+	assert.Equal(t, int32(0), podDeletionCost(math.NaN()))
+}
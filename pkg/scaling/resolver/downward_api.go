@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// podInstanceFieldPaths are the Downward API fields only known once a pod is created from the template,
+// they can't be resolved for the scale target as a whole
+var podInstanceFieldPaths = map[string]bool{
+	"metadata.name":  true,
+	"metadata.uid":   true,
+	"spec.nodeName":  true,
+	"status.hostIP":  true,
+	"status.hostIPs": true,
+	"status.podIP":   true,
+	"status.podIPs":  true,
+}
+
+// resolveFieldRef resolves a Downward API field against the pod template of the scale target. It returns false
+// for the fields specific to a pod instance, which aren't resolved.
+func resolveFieldRef(podTemplateSpec *corev1.PodTemplateSpec, fieldRef *corev1.ObjectFieldSelector, namespace string) (string, bool, error) {
+	path := fieldRef.FieldPath
+	switch {
+	case path == "metadata.namespace":
+		return namespace, true, nil
+	case path == "spec.serviceAccountName":
+		if podTemplateSpec == nil || podTemplateSpec.Spec.ServiceAccountName == "" {
+			return defaultServiceAccount, true, nil
+		}
+		return podTemplateSpec.Spec.ServiceAccountName, true, nil
+	case podInstanceFieldPaths[path]:
+		return "", false, nil
+	}
+
+	if key, ok := subscriptedFieldPath(path, "metadata.labels"); ok {
+		if podTemplateSpec == nil {
+			return "", true, nil
+		}
+		return podTemplateSpec.Labels[key], true, nil
+	}
+	if key, ok := subscriptedFieldPath(path, "metadata.annotations"); ok {
+		if podTemplateSpec == nil {
+			return "", true, nil
+		}
+		return podTemplateSpec.Annotations[key], true, nil
+	}
+	return "", false, fmt.Errorf("unsupported fieldPath %s", path)
+}
+
+// subscriptedFieldPath returns the key of a field path like metadata.labels['key']
+func subscriptedFieldPath(path, field string) (string, bool) {
+	prefix := field + "['"
+	if !strings.HasPrefix(path, prefix) || !strings.HasSuffix(path, "']") || len(path) <= len(prefix)+2 {
+		return "", false
+	}
+	return path[len(prefix) : len(path)-2], true
+}
+
+// resolveResourceFieldRef resolves a resource request or limit of a container like the kubelet does, rounding
+// the value up to the divisor. It returns false for a limit which isn't set, the kubelet would use the
+// allocatable resources of the node the pod runs on. A request which isn't set defaults to the limit.
+func resolveResourceFieldRef(podTemplateSpec *corev1.PodTemplateSpec, container *corev1.Container, resourceFieldRef *corev1.ResourceFieldSelector) (string, bool, error) {
+	target := container
+	if resourceFieldRef.ContainerName != "" && resourceFieldRef.ContainerName != container.Name {
+		target = nil
+		if podTemplateSpec != nil {
+			for i := range podTemplateSpec.Spec.Containers {
+				if podTemplateSpec.Spec.Containers[i].Name == resourceFieldRef.ContainerName {
+					target = &podTemplateSpec.Spec.Containers[i]
+					break
+				}
+			}
+		}
+		if target == nil {
+			return "", false, fmt.Errorf("couldn't find container with name %s", resourceFieldRef.ContainerName)
+		}
+	}
+
+	kind, name, found := strings.Cut(resourceFieldRef.Resource, ".")
+	if !found || (kind != "limits" && kind != "requests") {
+		return "", false, fmt.Errorf("unsupported resource %s", resourceFieldRef.Resource)
+	}
+	resourceName := corev1.ResourceName(name)
+
+	quantity, ok := target.Resources.Limits[resourceName]
+	if kind == "requests" {
+		if request, requestOk := target.Resources.Requests[resourceName]; requestOk {
+			quantity, ok = request, true
+		}
+		if !ok {
+			quantity, ok = resource.Quantity{}, true
+		}
+	}
+	if !ok {
+		return "", false, nil
+	}
+
+	divisor := resourceFieldRef.Divisor
+	if divisor.IsZero() {
+		divisor = resource.MustParse("1")
+	}
+	var value int64
+	if resourceName == corev1.ResourceCPU {
+		value = int64(math.Ceil(float64(quantity.MilliValue()) / float64(divisor.MilliValue())))
+	} else {
+		value = int64(math.Ceil(float64(quantity.Value()) / float64(divisor.Value())))
+	}
+	return strconv.FormatInt(value, 10), true, nil
+}
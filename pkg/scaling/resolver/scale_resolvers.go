@@ -19,6 +19,7 @@ package resolver
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -149,7 +150,8 @@ func ResolveScaleTargetPodSpec(ctx context.Context, kubeClient client.Client, sc
 
 // ResolveContainerEnv resolves all environment variables in a container.
 // It returns either map of env variable key and value or error if there is any.
-func ResolveContainerEnv(ctx context.Context, client client.Client, logger logr.Logger, podSpec *corev1.PodSpec, containerName, namespace string, secretsLister corev1listers.SecretLister) (map[string]string, error) {
+func ResolveContainerEnv(ctx context.Context, client client.Client, logger logr.Logger, podTemplateSpec *corev1.PodTemplateSpec, containerName, namespace string, secretsLister corev1listers.SecretLister) (map[string]string, error) {
+	podSpec := &podTemplateSpec.Spec
 	if len(podSpec.Containers) < 1 {
 		return nil, fmt.Errorf("target object doesn't have containers")
 	}
@@ -172,15 +174,16 @@ func ResolveContainerEnv(ctx context.Context, client client.Client, logger logr.
 		container = podSpec.Containers[0]
 	}
 
-	return resolveEnv(ctx, client, logger, &container, namespace, secretsLister)
+	return resolveEnv(ctx, client, logger, podTemplateSpec, &container, namespace, secretsLister)
 }
 
 // ResolveAuthRefAndPodIdentity provides authentication parameters and pod identity needed authenticate scaler with the environment.
+// triggerType is checked against the allowed trigger types of a ClusterTriggerAuthentication.
 func ResolveAuthRefAndPodIdentity(ctx context.Context, client client.Client, logger logr.Logger,
-	triggerAuthRef *kedav1alpha1.AuthenticationRef, podTemplateSpec *corev1.PodTemplateSpec,
+	triggerAuthRef *kedav1alpha1.AuthenticationRef, triggerType string, podTemplateSpec *corev1.PodTemplateSpec,
 	namespace string, secretsLister corev1listers.SecretLister) (map[string]string, kedav1alpha1.AuthPodIdentity, error) {
 	if podTemplateSpec != nil {
-		authParams, podIdentity, err := resolveAuthRef(ctx, client, logger, triggerAuthRef, &podTemplateSpec.Spec, namespace, secretsLister)
+		authParams, podIdentity, err := resolveAuthRef(ctx, client, logger, triggerAuthRef, triggerType, podTemplateSpec, namespace, secretsLister)
 
 		if err != nil {
 			return authParams, podIdentity, err
@@ -220,33 +223,41 @@ func ResolveAuthRefAndPodIdentity(ctx context.Context, client client.Client, log
 		return authParams, podIdentity, nil
 	}
 
-	return resolveAuthRef(ctx, client, logger, triggerAuthRef, nil, namespace, secretsLister)
+	return resolveAuthRef(ctx, client, logger, triggerAuthRef, triggerType, nil, namespace, secretsLister)
 }
 
 // resolveAuthRef provides authentication parameters needed authenticate scaler with the environment.
 // based on authentication method defined in TriggerAuthentication, authParams and podIdentity is returned
 func resolveAuthRef(ctx context.Context, client client.Client, logger logr.Logger,
-	triggerAuthRef *kedav1alpha1.AuthenticationRef, podSpec *corev1.PodSpec,
+	triggerAuthRef *kedav1alpha1.AuthenticationRef, triggerType string, podTemplateSpec *corev1.PodTemplateSpec,
 	namespace string, secretsLister corev1listers.SecretLister) (map[string]string, kedav1alpha1.AuthPodIdentity, error) {
 	result := make(map[string]string)
+	var podSpec *corev1.PodSpec
+	if podTemplateSpec != nil {
+		podSpec = &podTemplateSpec.Spec
+	}
 	podIdentity := kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone}
 	var err error
 
 	if namespace != "" && triggerAuthRef != nil && triggerAuthRef.Name != "" {
-		triggerAuthSpec, triggerNamespace, err := getTriggerAuthSpec(ctx, client, triggerAuthRef, namespace)
+		triggerAuthSpec, triggerNamespace, err := getTriggerAuthSpec(ctx, client, triggerAuthRef, triggerType, namespace)
 		if err != nil {
 			logger.Error(err, "error getting triggerAuth", "triggerAuthRef.Name", triggerAuthRef.Name)
+			// a denied access is reported on the scalable object rather than resolved as empty auth params
+			if errors.Is(err, kedav1alpha1.ErrClusterTriggerAuthenticationNotAllowed) || errors.Is(err, kedav1alpha1.ErrTriggerAuthenticationNotGranted) {
+				return result, podIdentity, err
+			}
 		} else {
 			if triggerAuthSpec.PodIdentity != nil {
 				podIdentity = *triggerAuthSpec.PodIdentity
 			}
 			if triggerAuthSpec.Env != nil {
 				for _, e := range triggerAuthSpec.Env {
-					if podSpec == nil {
+					if podTemplateSpec == nil {
 						result[e.Parameter] = ""
 						continue
 					}
-					env, err := ResolveContainerEnv(ctx, client, logger, podSpec, e.ContainerName, namespace, secretsLister)
+					env, err := ResolveContainerEnv(ctx, client, logger, podTemplateSpec, e.ContainerName, namespace, secretsLister)
 					if err != nil {
 						result[e.Parameter] = ""
 					} else {
@@ -343,36 +354,65 @@ func resolveAuthRef(ctx context.Context, client client.Client, logger logr.Logge
 					}
 				}
 			}
+			// templated parameters are rendered last, in order, so they can use every other parameter
+			for i := range triggerAuthSpec.TemplatedParameters {
+				parameter := &triggerAuthSpec.TemplatedParameters[i]
+				value, err := parameter.Render(result)
+				if err != nil {
+					logger.Error(err, "error rendering templated parameter", "triggerAuthRef.Name", triggerAuthRef.Name, "parameter", parameter.Parameter)
+					return result, podIdentity, err
+				}
+				result[parameter.Parameter] = value
+			}
 		}
 	}
 
 	return result, podIdentity, err
 }
 
-func getTriggerAuthSpec(ctx context.Context, client client.Client, triggerAuthRef *kedav1alpha1.AuthenticationRef, namespace string) (*kedav1alpha1.TriggerAuthenticationSpec, string, error) {
+func getTriggerAuthSpec(ctx context.Context, kubeClient client.Client, triggerAuthRef *kedav1alpha1.AuthenticationRef, triggerType string, namespace string) (*kedav1alpha1.TriggerAuthenticationSpec, string, error) {
 	if triggerAuthRef.Kind == "" || triggerAuthRef.Kind == "TriggerAuthentication" {
+		triggerNamespace := triggerAuthRef.GetNamespace(namespace)
+		if triggerNamespace != namespace {
+			grants := &kedav1alpha1.TriggerAuthenticationGrantList{}
+			if err := kubeClient.List(ctx, grants, client.InNamespace(triggerNamespace)); err != nil {
+				return nil, "", err
+			}
+			if !kedav1alpha1.IsTriggerAuthenticationGranted(grants.Items, namespace, triggerAuthRef.Name) {
+				return nil, "", fmt.Errorf("%w: TriggerAuthentication %s/%s from namespace %s", kedav1alpha1.ErrTriggerAuthenticationNotGranted, triggerNamespace, triggerAuthRef.Name, namespace)
+			}
+		}
 		triggerAuth := &kedav1alpha1.TriggerAuthentication{}
-		err := client.Get(ctx, types.NamespacedName{Name: triggerAuthRef.Name, Namespace: namespace}, triggerAuth)
+		err := kubeClient.Get(ctx, types.NamespacedName{Name: triggerAuthRef.Name, Namespace: triggerNamespace}, triggerAuth)
 		if err != nil {
 			return nil, "", err
 		}
-		return &triggerAuth.Spec, namespace, nil
+		return &triggerAuth.Spec, triggerNamespace, nil
 	} else if triggerAuthRef.Kind == "ClusterTriggerAuthentication" {
 		clusterNamespace, err := util.GetClusterObjectNamespace()
 		if err != nil {
 			return nil, "", err
 		}
 		triggerAuth := &kedav1alpha1.ClusterTriggerAuthentication{}
-		err = client.Get(ctx, types.NamespacedName{Name: triggerAuthRef.Name}, triggerAuth)
+		err = kubeClient.Get(ctx, types.NamespacedName{Name: triggerAuthRef.Name}, triggerAuth)
 		if err != nil {
 			return nil, "", err
 		}
+		if triggerAuth.Spec.AllowedNamespaces != nil || len(triggerAuth.Spec.AllowedTriggerTypes) > 0 {
+			ns := &corev1.Namespace{}
+			if err := kubeClient.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+				return nil, "", err
+			}
+			if err := triggerAuth.CheckAccess(ns, triggerType); err != nil {
+				return nil, "", err
+			}
+		}
 		return &triggerAuth.Spec, clusterNamespace, nil
 	}
 	return nil, "", fmt.Errorf("unknown trigger auth kind %s", triggerAuthRef.Kind)
 }
 
-func resolveEnv(ctx context.Context, client client.Client, logger logr.Logger, container *corev1.Container, namespace string, secretsLister corev1listers.SecretLister) (map[string]string, error) {
+func resolveEnv(ctx context.Context, client client.Client, logger logr.Logger, podTemplateSpec *corev1.PodTemplateSpec, container *corev1.Container, namespace string, secretsLister corev1listers.SecretLister) (map[string]string, error) {
 	resolved := make(map[string]string)
 	secretAccessRestricted := isSecretAccessRestricted(logger)
 	accessSecrets := readSecrets(secretAccessRestricted, namespace)
@@ -444,8 +484,33 @@ func resolveEnv(ctx context.Context, client client.Client, logger logr.Logger, c
 							envVar.Name,
 							namespace)
 					}
+				case envVar.ValueFrom.FieldRef != nil:
+					// env is a Downward API field of the pod
+					var ok bool
+					value, ok, err = resolveFieldRef(podTemplateSpec, envVar.ValueFrom.FieldRef, namespace)
+					if err != nil {
+						// a trigger referencing the env through *FromEnv fails on the missing value instead
+						logger.Info("cannot resolve env to a value. fieldRef env is skipped", "env-var-name", envVar.Name, "fieldPath", envVar.ValueFrom.FieldRef.FieldPath, "error", err.Error())
+						continue
+					}
+					if !ok {
+						logger.V(1).Info("cannot resolve env to a value. fieldRef env of a pod instance field is skipped", "env-var-name", envVar.Name, "fieldPath", envVar.ValueFrom.FieldRef.FieldPath)
+						continue
+					}
+				case envVar.ValueFrom.ResourceFieldRef != nil:
+					// env is a resource request or limit of a container
+					var ok bool
+					value, ok, err = resolveResourceFieldRef(podTemplateSpec, container, envVar.ValueFrom.ResourceFieldRef)
+					if err != nil {
+						logger.Info("cannot resolve env to a value. resourceFieldRef env is skipped", "env-var-name", envVar.Name, "resource", envVar.ValueFrom.ResourceFieldRef.Resource, "error", err.Error())
+						continue
+					}
+					if !ok {
+						logger.V(1).Info("cannot resolve env to a value. resourceFieldRef env of a resource without request or limit is skipped", "env-var-name", envVar.Name, "resource", envVar.ValueFrom.ResourceFieldRef.Resource)
+						continue
+					}
 				default:
-					logger.V(1).Info("cannot resolve env to a value. fieldRef and resourceFieldRef env are skipped", "env-var-name", envVar.Name)
+					logger.V(1).Info("cannot resolve env to a value", "env-var-name", envVar.Name)
 					continue
 				}
 			}
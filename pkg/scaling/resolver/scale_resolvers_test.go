@@ -24,6 +24,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"go.uber.org/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -38,6 +39,7 @@ import (
 var (
 	namespace                 = "test-namespace"
 	clusterNamespace          = "keda"
+	sharedNamespace           = "shared-services"
 	triggerAuthenticationName = "triggerauth"
 	secretName                = "supersecret"
 	secretKey                 = "mysecretkey"
@@ -228,7 +230,7 @@ func TestResolveNonExistingConfigMapsOrSecretsEnv(t *testing.T) {
 	var secretsLister corev1listers.SecretLister
 	for _, testData := range testMetadatas {
 		ctx := context.Background()
-		_, err := resolveEnv(ctx, fake.NewClientBuilder().Build(), logf.Log.WithName("test"), testData.container, namespace, secretsLister)
+		_, err := resolveEnv(ctx, fake.NewClientBuilder().Build(), logf.Log.WithName("test"), nil, testData.container, namespace, secretsLister)
 
 		if err != nil && !testData.isError {
 			t.Errorf("Expected success because %s got error, %s", testData.comment, err)
@@ -251,7 +253,8 @@ func TestResolveAuthRef(t *testing.T) {
 		name                string
 		existing            []runtime.Object
 		soar                *kedav1alpha1.AuthenticationRef
-		podSpec             *corev1.PodSpec
+		triggerType         string
+		podTemplateSpec     *corev1.PodTemplateSpec
 		expected            map[string]string
 		expectedPodIdentity kedav1alpha1.AuthPodIdentity
 		isError             bool
@@ -604,10 +607,201 @@ func TestResolveAuthRef(t *testing.T) {
 				},
 			},
 			soar:                &kedav1alpha1.AuthenticationRef{Name: triggerAuthenticationName, Kind: "ClusterTriggerAuthentication"},
-			podSpec:             &corev1.PodSpec{},
+			podTemplateSpec:     &corev1.PodTemplateSpec{},
 			expected:            map[string]string{},
 			expectedPodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderGCP},
 		},
+		{
+			name: "clustertriggerauth allows the namespace by label and the trigger type",
+			existing: []runtime.Object{
+				&corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: namespace, Labels: map[string]string{"tenant": "platform"}},
+				},
+				&kedav1alpha1.ClusterTriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: triggerAuthenticationName,
+					},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{
+							{Parameter: secretKey, Name: secretName, Key: secretKey},
+						},
+						AllowedNamespaces: &kedav1alpha1.AllowedNamespaces{
+							Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "platform"}},
+						},
+						AllowedTriggerTypes: []string{"kafka"},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: clusterNamespace,
+						Name:      secretName,
+					},
+					Data: map[string][]byte{secretKey: []byte(secretData)}},
+			},
+			soar:                &kedav1alpha1.AuthenticationRef{Name: triggerAuthenticationName, Kind: "ClusterTriggerAuthentication"},
+			triggerType:         "kafka",
+			expected:            map[string]string{secretKey: secretData},
+			expectedPodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone},
+		},
+		{
+			name: "clustertriggerauth doesn't allow the namespace",
+			existing: []runtime.Object{
+				&corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: namespace},
+				},
+				&kedav1alpha1.ClusterTriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: triggerAuthenticationName,
+					},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{
+							{Parameter: secretKey, Name: secretName, Key: secretKey},
+						},
+						AllowedNamespaces: &kedav1alpha1.AllowedNamespaces{Names: []string{"platform"}},
+					},
+				},
+			},
+			soar:                &kedav1alpha1.AuthenticationRef{Name: triggerAuthenticationName, Kind: "ClusterTriggerAuthentication"},
+			triggerType:         "kafka",
+			expected:            map[string]string{},
+			expectedPodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone},
+			isError:             true,
+			comment:             "the namespace isn't in allowedNamespaces",
+		},
+		{
+			name: "clustertriggerauth doesn't allow the trigger type",
+			existing: []runtime.Object{
+				&corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: namespace},
+				},
+				&kedav1alpha1.ClusterTriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: triggerAuthenticationName,
+					},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{
+							{Parameter: secretKey, Name: secretName, Key: secretKey},
+						},
+						AllowedTriggerTypes: []string{"kafka"},
+					},
+				},
+			},
+			soar:                &kedav1alpha1.AuthenticationRef{Name: triggerAuthenticationName, Kind: "ClusterTriggerAuthentication"},
+			triggerType:         "rabbitmq",
+			expected:            map[string]string{},
+			expectedPodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone},
+			isError:             true,
+			comment:             "the trigger type isn't in allowedTriggerTypes",
+		},
+		{
+			name: "triggerauth with templated parameters",
+			existing: []runtime.Object{
+				&kedav1alpha1.TriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      triggerAuthenticationName,
+					},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{
+							{Parameter: "password", Name: secretName, Key: secretKey},
+						},
+						ConfigMapTargetRef: []kedav1alpha1.AuthConfigMapTargetRef{
+							{Parameter: "host", Name: cmName, Key: cmKey},
+						},
+						TemplatedParameters: []kedav1alpha1.AuthTemplatedParameter{
+							{Parameter: "user", Template: "keda@example"},
+							{Parameter: "connection", Template: "amqp://{{ urlUserInfo .user .password }}@{{ .host }}/"},
+						},
+					},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: cmName},
+					Data:       map[string]string{cmKey: cmData},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: secretName},
+					Data:       map[string][]byte{secretKey: []byte("p@ss:word")}},
+			},
+			soar: &kedav1alpha1.AuthenticationRef{Name: triggerAuthenticationName},
+			expected: map[string]string{
+				"password":   "p@ss:word",
+				"host":       cmData,
+				"user":       "keda@example",
+				"connection": "amqp://keda%40example:p%40ss%3Aword@" + cmData + "/",
+			},
+			expectedPodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone},
+		},
+		{
+			name: "triggerauth with templated parameter using a missing parameter",
+			existing: []runtime.Object{
+				&kedav1alpha1.TriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      triggerAuthenticationName,
+					},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						TemplatedParameters: []kedav1alpha1.AuthTemplatedParameter{
+							{Parameter: "connection", Template: "amqp://{{ .host }}/"},
+						},
+					},
+				},
+			},
+			soar:                &kedav1alpha1.AuthenticationRef{Name: triggerAuthenticationName},
+			expected:            map[string]string{},
+			expectedPodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone},
+			isError:             true,
+			comment:             "the template uses a parameter which isn't resolved",
+		},
+		{
+			name: "triggerauth in another namespace granted by a TriggerAuthenticationGrant",
+			existing: []runtime.Object{
+				&kedav1alpha1.TriggerAuthenticationGrant{
+					ObjectMeta: metav1.ObjectMeta{Namespace: sharedNamespace, Name: "grant"},
+					Spec: kedav1alpha1.TriggerAuthenticationGrantSpec{
+						From: []kedav1alpha1.TriggerAuthenticationGrantFrom{{Namespace: namespace}},
+						To:   []kedav1alpha1.TriggerAuthenticationGrantTo{{Name: triggerAuthenticationName}},
+					},
+				},
+				&kedav1alpha1.TriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{Namespace: sharedNamespace, Name: triggerAuthenticationName},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{
+							{Parameter: secretKey, Name: secretName, Key: secretKey},
+						},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: sharedNamespace, Name: secretName},
+					Data:       map[string][]byte{secretKey: []byte(secretData)}},
+			},
+			soar:                &kedav1alpha1.AuthenticationRef{Name: triggerAuthenticationName, Namespace: sharedNamespace},
+			expected:            map[string]string{secretKey: secretData},
+			expectedPodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone},
+		},
+		{
+			name: "triggerauth in another namespace without a TriggerAuthenticationGrant",
+			existing: []runtime.Object{
+				&kedav1alpha1.TriggerAuthenticationGrant{
+					ObjectMeta: metav1.ObjectMeta{Namespace: sharedNamespace, Name: "grant"},
+					Spec: kedav1alpha1.TriggerAuthenticationGrantSpec{
+						From: []kedav1alpha1.TriggerAuthenticationGrantFrom{{Namespace: "other-namespace"}},
+					},
+				},
+				&kedav1alpha1.TriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{Namespace: sharedNamespace, Name: triggerAuthenticationName},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{
+							{Parameter: secretKey, Name: secretName, Key: secretKey},
+						},
+					},
+				},
+			},
+			soar:                &kedav1alpha1.AuthenticationRef{Name: triggerAuthenticationName, Namespace: sharedNamespace},
+			expected:            map[string]string{},
+			expectedPodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone},
+			isError:             true,
+			comment:             "no TriggerAuthenticationGrant allows the namespace",
+		},
 	}
 	var secretsLister corev1listers.SecretLister
 	for _, test := range tests {
@@ -620,7 +814,8 @@ func TestResolveAuthRef(t *testing.T) {
 				fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(test.existing...).Build(),
 				logf.Log.WithName("test"),
 				test.soar,
-				test.podSpec,
+				test.triggerType,
+				test.podTemplateSpec,
 				namespace,
 				secretsLister)
 
@@ -750,7 +945,7 @@ func TestResolveDependentEnv(t *testing.T) {
 		test := test
 		t.Run(test.name, func(t *testing.T) {
 			ctx := context.Background()
-			envMap, _ := resolveEnv(ctx, fake.NewClientBuilder().Build(), logf.Log.WithName("test"), test.container, namespace, secretsLister)
+			envMap, _ := resolveEnv(ctx, fake.NewClientBuilder().Build(), logf.Log.WithName("test"), nil, test.container, namespace, secretsLister)
 			if diff := cmp.Diff(envMap, test.expected); diff != "" {
 				t.Errorf("Returned authParams are different: %s", diff)
 			}
@@ -758,6 +953,84 @@ func TestResolveDependentEnv(t *testing.T) {
 	}
 }
 
+func TestResolveDownwardAPIEnv(t *testing.T) {
+	podTemplateSpec := &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{"app": "orders"},
+			Annotations: map[string]string{"queue": "orders-queue"},
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "orders-consumer",
+			Containers: []corev1.Container{
+				{
+					Name: "consumer",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+						Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1500m"), corev1.ResourceMemory: resource.MustParse("512Mi")},
+					},
+					Env: []corev1.EnvVar{
+						{Name: "NAMESPACE", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}}},
+						{Name: "APP", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels['app']"}}},
+						{Name: "QUEUE", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['queue']"}}},
+						{Name: "MISSING_LABEL", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels['tier']"}}},
+						{Name: "SERVICE_ACCOUNT", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.serviceAccountName"}}},
+						{Name: "POD_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+						{Name: "QUEUE_NAME", Value: "$(NAMESPACE)-$(QUEUE)"},
+						{Name: "CPU_REQUEST", ValueFrom: &corev1.EnvVarSource{ResourceFieldRef: &corev1.ResourceFieldSelector{Resource: "requests.cpu", Divisor: resource.MustParse("1m")}}},
+						{Name: "CPU_LIMIT", ValueFrom: &corev1.EnvVarSource{ResourceFieldRef: &corev1.ResourceFieldSelector{Resource: "limits.cpu"}}},
+						{Name: "MEMORY_LIMIT", ValueFrom: &corev1.EnvVarSource{ResourceFieldRef: &corev1.ResourceFieldSelector{Resource: "limits.memory", Divisor: resource.MustParse("1Mi")}}},
+						{Name: "MEMORY_REQUEST", ValueFrom: &corev1.EnvVarSource{ResourceFieldRef: &corev1.ResourceFieldSelector{Resource: "requests.memory"}}},
+						{Name: "STORAGE_LIMIT", ValueFrom: &corev1.EnvVarSource{ResourceFieldRef: &corev1.ResourceFieldSelector{Resource: "limits.ephemeral-storage"}}},
+						{Name: "SIDECAR_CPU_LIMIT", ValueFrom: &corev1.EnvVarSource{ResourceFieldRef: &corev1.ResourceFieldSelector{ContainerName: "sidecar", Resource: "limits.cpu", Divisor: resource.MustParse("1m")}}},
+					},
+				},
+				{
+					Name: "sidecar",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+					},
+				},
+			},
+		},
+	}
+
+	var secretsLister corev1listers.SecretLister
+	envMap, err := ResolveContainerEnv(context.Background(), fake.NewClientBuilder().Build(), logf.Log.WithName("test"), podTemplateSpec, "consumer", namespace, secretsLister)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"NAMESPACE":         namespace,
+		"APP":               "orders",
+		"QUEUE":             "orders-queue",
+		"MISSING_LABEL":     "",
+		"SERVICE_ACCOUNT":   "orders-consumer",
+		"QUEUE_NAME":        namespace + "-orders-queue",
+		"CPU_REQUEST":       "250",
+		"CPU_LIMIT":         "2",
+		"MEMORY_LIMIT":      "512",
+		"MEMORY_REQUEST":    "536870912",
+		"SIDECAR_CPU_LIMIT": "100",
+	}
+	if diff := cmp.Diff(envMap, expected); diff != "" {
+		t.Errorf("Returned env are different: %s", diff)
+	}
+
+	podTemplateSpec.Spec.Containers[0].Env = []corev1.EnvVar{
+		{Name: "UNSUPPORTED_FIELD", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.generation"}}},
+		{Name: "MISSING_CONTAINER", ValueFrom: &corev1.EnvVarSource{ResourceFieldRef: &corev1.ResourceFieldSelector{ContainerName: "missing", Resource: "limits.cpu"}}},
+		{Name: "UNSUPPORTED_RESOURCE", ValueFrom: &corev1.EnvVarSource{ResourceFieldRef: &corev1.ResourceFieldSelector{Resource: "limits.gpu"}}},
+		{Name: "APP", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels['app']"}}},
+	}
+	envMap, err = ResolveContainerEnv(context.Background(), fake.NewClientBuilder().Build(), logf.Log.WithName("test"), podTemplateSpec, "consumer", namespace, secretsLister)
+	if err != nil {
+		t.Fatalf("Expected unresolvable Downward API env to be skipped but got error: %s", err)
+	}
+	if diff := cmp.Diff(envMap, map[string]string{"APP": "orders"}); diff != "" {
+		t.Errorf("Returned env are different: %s", diff)
+	}
+}
+
 func TestEnvWithRestrictSecretAccess(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -801,7 +1074,7 @@ func TestEnvWithRestrictSecretAccess(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			restrictSecretAccess = "true"
 			ctx := context.Background()
-			envMap, _ := resolveEnv(ctx, fake.NewClientBuilder().Build(), logf.Log.WithName("test"), test.container, namespace, secretsLister)
+			envMap, _ := resolveEnv(ctx, fake.NewClientBuilder().Build(), logf.Log.WithName("test"), nil, test.container, namespace, secretsLister)
 			if diff := cmp.Diff(envMap, test.expected); diff != "" {
 				t.Errorf("Returned env map is different: %s", diff)
 			}
@@ -864,7 +1137,7 @@ func TestEnvWithRestrictedNamespace(t *testing.T) {
 			restrictSecretAccess = "true"
 			kedaNamespace = "keda"
 			ctx := context.Background()
-			envMap, _ := resolveEnv(ctx, fake.NewClientBuilder().Build(), logf.Log.WithName("test"), test.container, clusterNamespace, mockSecretLister)
+			envMap, _ := resolveEnv(ctx, fake.NewClientBuilder().Build(), logf.Log.WithName("test"), nil, test.container, clusterNamespace, mockSecretLister)
 			if diff := cmp.Diff(envMap, test.expected); diff != "" {
 				t.Errorf("Returned env map is different: %s", diff)
 			}
@@ -27,6 +27,7 @@ import (
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	corev1listers "k8s.io/client-go/listers/core/v1"
@@ -66,6 +67,7 @@ type ScaleHandler interface {
 
 type scaleHandler struct {
 	client                   client.Client
+	apiReader                client.Reader
 	scaleLoopContexts        *sync.Map
 	scaleExecutor            executor.ScaleExecutor
 	globalHTTPTimeout        time.Duration
@@ -74,14 +76,16 @@ type scaleHandler struct {
 	scalerCachesLock         *sync.RWMutex
 	scaledObjectsMetricCache metricscache.MetricsCache
 	secretsLister            corev1listers.SecretLister
+	triggersStatusUpdates    sync.Map
 }
 
-// NewScaleHandler creates a ScaleHandler object
-func NewScaleHandler(client client.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, globalHTTPTimeout time.Duration, recorder record.EventRecorder, secretsLister corev1listers.SecretLister) ScaleHandler {
+// NewScaleHandler creates a ScaleHandler object, the apiReader reads from the API server directly
+func NewScaleHandler(client client.Client, apiReader client.Reader, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, globalHTTPTimeout time.Duration, recorder record.EventRecorder, secretsLister corev1listers.SecretLister) ScaleHandler {
 	return &scaleHandler{
 		client:                   client,
+		apiReader:                apiReader,
 		scaleLoopContexts:        &sync.Map{},
-		scaleExecutor:            executor.NewScaleExecutor(client, scaleClient, reconcilerScheme, recorder),
+		scaleExecutor:            executor.NewScaleExecutor(client, apiReader, scaleClient, reconcilerScheme, recorder),
 		globalHTTPTimeout:        globalHTTPTimeout,
 		recorder:                 recorder,
 		scalerCaches:             map[string]*cache.ScalersCache{},
@@ -126,6 +130,9 @@ func (h *scaleHandler) HandleScalableObject(ctx context.Context, scalableObject
 	case *kedav1alpha1.ScaledObject:
 		go h.startPushScalers(ctx, withTriggers, obj.DeepCopy(), scalingMutex)
 		go h.startScaleLoop(ctx, withTriggers, obj.DeepCopy(), scalingMutex, true)
+		if obj.GetPodDeletionCost() != nil {
+			go h.startPodDeletionCostLoop(ctx, withTriggers, obj.DeepCopy())
+		}
 	case *kedav1alpha1.ScaledJob:
 		go h.startPushScalers(ctx, withTriggers, obj.DeepCopy(), scalingMutex)
 		go h.startScaleLoop(ctx, withTriggers, obj.DeepCopy(), scalingMutex, false)
@@ -149,6 +156,7 @@ func (h *scaleHandler) DeleteScalableObject(ctx context.Context, scalableObject
 			cancel()
 		}
 		h.scaleLoopContexts.Delete(key)
+		h.triggersStatusUpdates.Delete(key)
 		err := h.ClearScalersCache(ctx, scalableObject)
 		if err != nil {
 			log.Error(err, "error clearing scalers cache", "scalableObject", scalableObject, "key", key)
@@ -218,6 +226,7 @@ func (h *scaleHandler) startPushScalers(ctx context.Context, withTriggers *kedav
 					switch obj := scalableObject.(type) {
 					case *kedav1alpha1.ScaledObject:
 						h.scaleExecutor.RequestScale(ctx, obj, active, false, &executor.ScaleExecutorOptions{})
+						// the last scale is written with the triggers status on the next poll of the ScaledObject
 					case *kedav1alpha1.ScaledJob:
 						logger.Info("Warning: External Push Scaler does not support ScaledJob", "object", scalableObject)
 					}
@@ -240,13 +249,14 @@ func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interfac
 			log.Error(err, "error getting scaledObject", "object", scalableObject)
 			return
 		}
-		isActive, isError, metricsRecords, activeTriggers, err := h.getScaledObjectState(ctx, obj)
+		isActive, isError, metricsRecords, activeTriggers, triggersStatus, err := h.getScaledObjectState(ctx, obj)
 		if err != nil {
 			log.Error(err, "error getting state of scaledObject", "scaledObject.Namespace", obj.Namespace, "scaledObject.Name", obj.Name)
 			return
 		}
 
 		h.scaleExecutor.RequestScale(ctx, obj, isActive, isError, &executor.ScaleExecutorOptions{ActiveTriggers: activeTriggers})
+		h.updateTriggersStatus(ctx, log.WithValues("scaledObject.Namespace", obj.Namespace, "scaledObject.Name", obj.Name), obj, triggersStatus)
 
 		if len(metricsRecords) > 0 {
 			log.V(1).Info("Storing metrics to cache", "scaledObject.Namespace", obj.Namespace, "scaledObject.Name", obj.Name, "metricsRecords", metricsRecords)
@@ -345,13 +355,20 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 	}
 
 	asMetricSource := false
+	var formulaTriggers map[string]bool
 	switch obj := scalableObject.(type) {
 	case *kedav1alpha1.ScaledObject:
 		asMetricSource = obj.IsUsingModifiers()
+		if asMetricSource {
+			formulaTriggers, err = modifiers.GetFormulaTriggerNames(obj.Spec.Advanced.ScalingModifiers.Formula)
+			if err != nil {
+				return nil, err
+			}
+		}
 	default:
 	}
 
-	scalers, err := h.buildScalers(ctx, withTriggers, podTemplateSpec, containerName, asMetricSource)
+	scalers, err := h.buildScalers(ctx, withTriggers, podTemplateSpec, containerName, asMetricSource, formulaTriggers)
 	if err != nil {
 		return nil, err
 	}
@@ -372,6 +389,13 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 			}
 			newCache.CompiledFormula = program
 		}
+		podDeletionCostScaler, err := h.buildPodDeletionCostScaler(ctx, obj, podTemplateSpec)
+		if err != nil {
+			log.Error(err, "error building the pod deletion cost scaler")
+			newCache.Close(ctx)
+			return nil, err
+		}
+		newCache.PodDeletionCostScaler = podDeletionCostScaler
 		newCache.ScaledObject = obj
 	default:
 	}
@@ -594,8 +618,10 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 // is active as the first return value,
 // the second return value indicates whether there was any error during querying scalers,
 // the third return value is a map of metrics record - a metric value for each scaler and its metric
-// the fourth return value contains error if is not able to access scalers cache
-func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (bool, bool, map[string]metricscache.MetricsRecord, []string, error) {
+// the fourth return value contains the names of the active triggers
+// the fifth return value contains the status of each trigger, in the order of the triggers
+// the sixth return value contains error if is not able to access scalers cache
+func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (bool, bool, map[string]metricscache.MetricsRecord, []string, []kedav1alpha1.TriggerStatus, error) {
 	logger := log.WithValues("scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
 
 	isScaledObjectActive := false
@@ -608,7 +634,7 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 	cache, err := h.GetScalersCache(ctx, scaledObject)
 	metricscollector.RecordScaledObjectError(scaledObject.Namespace, scaledObject.Name, err)
 	if err != nil {
-		return false, true, map[string]metricscache.MetricsRecord{}, []string{}, fmt.Errorf("error getting scalers cache %w", err)
+		return false, true, map[string]metricscache.MetricsRecord{}, []string{}, nil, fmt.Errorf("error getting scalers cache %w", err)
 	}
 
 	// count the number of non-external triggers (cpu/mem) in order to check for
@@ -636,7 +662,9 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 	}
 	wg.Wait()
 	close(results)
+	triggersStatus := make([]kedav1alpha1.TriggerStatus, len(allScalers))
 	for result := range results {
+		triggersStatus[result.TriggerIndex] = result.Status
 		if result.IsActive {
 			isScaledObjectActive = true
 			activeTriggers = append(activeTriggers, result.TriggerName)
@@ -678,7 +706,7 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 			if scaledObject.Spec.Advanced.ScalingModifiers.ActivationTarget != "" {
 				targetValue, err := strconv.ParseFloat(scaledObject.Spec.Advanced.ScalingModifiers.ActivationTarget, 64)
 				if err != nil {
-					return false, true, metricsRecord, []string{}, fmt.Errorf("scalingModifiers.ActivationTarget parsing error %w", err)
+					return false, true, metricsRecord, []string{}, triggersStatus, fmt.Errorf("scalingModifiers.ActivationTarget parsing error %w", err)
 				}
 				activationValue = targetValue
 			}
@@ -703,7 +731,7 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 	if len(scaledObject.Spec.Triggers) <= cpuMemCount && !isScaledObjectError {
 		isScaledObjectActive = true
 	}
-	return isScaledObjectActive, isScaledObjectError, metricsRecord, activeTriggers, err
+	return isScaledObjectActive, isScaledObjectError, metricsRecord, activeTriggers, triggersStatus, err
 }
 
 // scalerState is used as return
@@ -712,12 +740,15 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 // info for calculating the ScaledObjectState
 type scalerState struct {
 	// IsActive will be overrided by formula calculation
-	IsActive    bool
-	TriggerName string
-	Metrics     []external_metrics.ExternalMetricValue
-	Pairs       map[string]string
-	Records     map[string]metricscache.MetricsRecord
-	Err         error
+	IsActive     bool
+	TriggerName  string
+	TriggerIndex int
+	Metrics      []external_metrics.ExternalMetricValue
+	Pairs        map[string]string
+	Records      map[string]metricscache.MetricsRecord
+	Err          error
+	// Status is the status of the trigger reported in the ScaledObject
+	Status kedav1alpha1.TriggerStatus
 }
 
 // getScalerState returns getStateScalerResult with the state
@@ -727,32 +758,50 @@ type scalerState struct {
 func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler, triggerIndex int, scalerConfig scalersconfig.ScalerConfig,
 	cache *cache.ScalersCache, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) scalerState {
 	result := scalerState{
-		IsActive:    false,
-		Err:         nil,
-		TriggerName: "",
-		Metrics:     []external_metrics.ExternalMetricValue{},
-		Pairs:       map[string]string{},
-		Records:     map[string]metricscache.MetricsRecord{},
+		IsActive:     false,
+		Err:          nil,
+		TriggerName:  "",
+		TriggerIndex: triggerIndex,
+		Metrics:      []external_metrics.ExternalMetricValue{},
+		Pairs:        map[string]string{},
+		Records:      map[string]metricscache.MetricsRecord{},
+		Status:       kedav1alpha1.TriggerStatus{Name: scalerConfig.TriggerName},
 	}
 
 	result.TriggerName = strings.Replace(fmt.Sprintf("%T", scaler), "*scalers.", "", 1)
 	if scalerConfig.TriggerName != "" {
 		result.TriggerName = scalerConfig.TriggerName
 	}
+	if triggerIndex < len(scaledObject.Spec.Triggers) {
+		result.Status.Type = scaledObject.Spec.Triggers[triggerIndex].Type
+	}
 
 	metricSpecs, err := cache.GetMetricSpecForScalingForScaler(ctx, triggerIndex)
 	if err != nil {
 		result.Err = err
+		result.Status.LastError = err.Error()
 		logger.Error(err, "error getting metric spec for the scaler", "scaler", result.TriggerName)
 		cache.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
 	}
 
+	// cpu and memory triggers are evaluated by the HPA, only their target is reported
+	if len(metricSpecs) > 0 && metricSpecs[0].Resource != nil {
+		result.Status.MetricName = metricSpecs[0].Resource.Name.String()
+		result.Status.Target = metricTarget(metricSpecs[0])
+	}
+
 	for _, spec := range metricSpecs {
 		if spec.External == nil {
 			continue
 		}
 
 		metricName := spec.External.Metric.Name
+		isFirstMetric := result.Status.MetricName == ""
+		if isFirstMetric {
+			result.Status.MetricName = metricName
+			result.Status.Target = metricTarget(spec)
+			result.Status.Fallback = fallback.IsFallbackActive(scaledObject, spec, metricName)
+		}
 
 		var latency time.Duration
 		metrics, isMetricActive, latency, err := cache.GetMetricsAndActivityForScaler(ctx, triggerIndex, metricName)
@@ -773,6 +822,7 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 
 		if err != nil {
 			result.Err = err
+			result.Status.LastError = err.Error()
 			if scaledObject.IsUsingModifiers() {
 				logger.Error(err, "error getting metric source", "source", result.TriggerName)
 				cache.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAMetricSourceFailed, err.Error())
@@ -782,6 +832,15 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 			}
 		} else {
 			result.IsActive = isMetricActive
+			result.Status.Active = result.Status.Active || isMetricActive
+			if isFirstMetric {
+				now := metav1.Now()
+				result.Status.LastSuccessfulPollTime = &now
+				if len(metrics) > 0 {
+					value := metrics[0].Value.DeepCopy()
+					result.Status.Value = &value
+				}
+			}
 			for _, metric := range metrics {
 				metricValue := metric.Value.AsApproximateFloat64()
 				metricscollector.RecordScalerMetric(scaledObject.Namespace, scaledObject.Name, result.TriggerName, triggerIndex, metric.MetricName, true, metricValue)
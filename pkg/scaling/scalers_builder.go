@@ -18,6 +18,7 @@ package scaling
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
@@ -36,8 +37,9 @@ import (
 /// ----------            Scaler-Building related methods             --------- ///
 /// --------------------------------------------------------------------------- ///
 
-// buildScalers returns list of Scalers for the specified triggers
-func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alpha1.WithTriggers, podTemplateSpec *corev1.PodTemplateSpec, containerName string, asMetricSource bool) ([]cache.ScalerBuilder, error) {
+// buildScalers returns list of Scalers for the specified triggers, the cpu/memory triggers are
+// metric sources only when the scalingModifiers formula references them and keep their resource metric otherwise
+func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alpha1.WithTriggers, podTemplateSpec *corev1.PodTemplateSpec, containerName string, asMetricSource bool, formulaTriggers map[string]bool) ([]cache.ScalerBuilder, error) {
 	logger := log.WithValues("type", withTriggers.Kind, "namespace", withTriggers.Namespace, "name", withTriggers.Name)
 	var err error
 	resolvedEnv := make(map[string]string)
@@ -45,10 +47,14 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 
 	for i, t := range withTriggers.Spec.Triggers {
 		triggerIndex, trigger := i, t
+		triggerAsMetricSource := asMetricSource
+		if trigger.Type == "cpu" || trigger.Type == "memory" {
+			triggerAsMetricSource = asMetricSource && formulaTriggers[trigger.Name]
+		}
 
 		factory := func() (scalers.Scaler, *scalersconfig.ScalerConfig, error) {
 			if podTemplateSpec != nil {
-				resolvedEnv, err = resolver.ResolveContainerEnv(ctx, h.client, logger, &podTemplateSpec.Spec, containerName, withTriggers.Namespace, h.secretsLister)
+				resolvedEnv, err = resolver.ResolveContainerEnv(ctx, h.client, logger, podTemplateSpec, containerName, withTriggers.Namespace, h.secretsLister)
 				if err != nil {
 					return nil, nil, fmt.Errorf("error resolving secrets for ScaleTarget: %w", err)
 				}
@@ -65,11 +71,11 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 				GlobalHTTPTimeout:       h.globalHTTPTimeout,
 				TriggerIndex:            triggerIndex,
 				MetricType:              trigger.MetricType,
-				AsMetricSource:          asMetricSource,
+				AsMetricSource:          triggerAsMetricSource,
 				TriggerUniqueKey:        fmt.Sprintf("%s-%s-%s-%d", withTriggers.Kind, withTriggers.Namespace, withTriggers.Name, triggerIndex),
 			}
 
-			authParams, podIdentity, err := resolver.ResolveAuthRefAndPodIdentity(ctx, h.client, logger, trigger.AuthenticationRef, podTemplateSpec, withTriggers.Namespace, h.secretsLister)
+			authParams, podIdentity, err := resolver.ResolveAuthRefAndPodIdentity(ctx, h.client, logger, trigger.AuthenticationRef, trigger.Type, podTemplateSpec, withTriggers.Namespace, h.secretsLister)
 			switch podIdentity.Provider {
 			case kedav1alpha1.PodIdentityProviderAwsEKS:
 				// FIXME: Delete this for v3
@@ -82,14 +88,21 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 			}
 			config.AuthParams = authParams
 			config.PodIdentity = podIdentity
-			scaler, err := buildScaler(ctx, h.client, trigger.Type, config)
+			scaler, err := buildScaler(ctx, h.client, h.apiReader, trigger.Type, config)
 			return scaler, config, err
 		}
 
 		// nosemgrep: invalid-usage-of-modified-variable
 		scaler, config, err := factory()
 		if err != nil {
-			h.recorder.Event(withTriggers, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
+			reason := eventreason.KEDAScalerFailed
+			switch {
+			case errors.Is(err, kedav1alpha1.ErrClusterTriggerAuthenticationNotAllowed):
+				reason = eventreason.ClusterTriggerAuthenticationNotAllowed
+			case errors.Is(err, kedav1alpha1.ErrTriggerAuthenticationNotGranted):
+				reason = eventreason.TriggerAuthenticationNotGranted
+			}
+			h.recorder.Event(withTriggers, corev1.EventTypeWarning, reason, err.Error())
 			logger.Error(err, "error resolving auth params", "triggerIndex", triggerIndex)
 			if scaler != nil {
 				scaler.Close(ctx)
@@ -113,7 +126,7 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 }
 
 // buildScaler builds a scaler form input config and trigger type
-func buildScaler(ctx context.Context, client client.Client, triggerType string, config *scalersconfig.ScalerConfig) (scalers.Scaler, error) {
+func buildScaler(ctx context.Context, client client.Client, apiReader client.Reader, triggerType string, config *scalersconfig.ScalerConfig) (scalers.Scaler, error) {
 	// TRIGGERS-START
 	switch triggerType {
 	case "activemq":
@@ -157,7 +170,7 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 	case "couchdb":
 		return scalers.NewCouchDBScaler(ctx, config)
 	case "cpu":
-		return scalers.NewCPUMemoryScaler(corev1.ResourceCPU, config)
+		return scalers.NewCPUMemoryScaler(corev1.ResourceCPU, client, config)
 	case "cron":
 		return scalers.NewCronScaler(config)
 	case "datadog":
@@ -173,6 +186,8 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewExternalMockScaler(config)
 	case "external-push":
 		return scalers.NewExternalPushScaler(config)
+	case "forecast":
+		return scalers.NewForecastScaler(config)
 	case "gcp-cloudtasks":
 		return scalers.NewGcpCloudTasksScaler(config)
 	case "gcp-pubsub":
@@ -200,7 +215,7 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 	case "loki":
 		return scalers.NewLokiScaler(config)
 	case "memory":
-		return scalers.NewCPUMemoryScaler(corev1.ResourceMemory, config)
+		return scalers.NewCPUMemoryScaler(corev1.ResourceMemory, client, config)
 	case "metrics-api":
 		return scalers.NewMetricsAPIScaler(config)
 	case "mongodb":
@@ -217,6 +232,10 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewOpenstackMetricScaler(ctx, config)
 	case "openstack-swift":
 		return scalers.NewOpenstackSwiftScaler(config)
+	case "otel":
+		return scalers.NewOtelScaler(config)
+	case "pod-metrics":
+		return scalers.NewPodMetricsScaler(client, apiReader, config)
 	case "postgresql":
 		return scalers.NewPostgreSQLScaler(config)
 	case "predictkube":
@@ -247,6 +266,8 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewSolrScaler(config)
 	case "stan":
 		return scalers.NewStanScaler(config)
+	case "webhook":
+		return scalers.NewWebhookScaler(config)
 	default:
 		return nil, fmt.Errorf("no scaler found for type: %s", triggerType)
 	}
@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedastatus "github.com/kedacore/keda/v2/pkg/status"
+)
+
+// triggersStatusUpdateInterval is the minimum interval between two updates of the triggers status of a ScaledObject
+// which only change the metric values and the poll times, the other changes are written right away
+const triggersStatusUpdateInterval = 30 * time.Second
+
+// metricTarget returns the target of the metric spec, the target utilization for cpu and memory metrics
+func metricTarget(spec v2.MetricSpec) *resource.Quantity {
+	var target v2.MetricTarget
+	switch {
+	case spec.External != nil:
+		target = spec.External.Target
+	case spec.Resource != nil:
+		target = spec.Resource.Target
+	default:
+		return nil
+	}
+	switch {
+	case target.AverageUtilization != nil:
+		return resource.NewQuantity(int64(*target.AverageUtilization), resource.DecimalSI)
+	case target.AverageValue != nil:
+		return target.AverageValue
+	default:
+		return target.Value
+	}
+}
+
+// mergeTriggersStatus keeps the value and the last successful poll time of the previous status
+// for the triggers whose last poll failed
+func mergeTriggersStatus(previous, current []kedav1alpha1.TriggerStatus) []kedav1alpha1.TriggerStatus {
+	for i := range current {
+		if i >= len(previous) || previous[i].Type != current[i].Type || previous[i].Name != current[i].Name {
+			continue
+		}
+		if current[i].LastSuccessfulPollTime == nil {
+			current[i].LastSuccessfulPollTime = previous[i].LastSuccessfulPollTime
+			if current[i].Value == nil {
+				current[i].Value = previous[i].Value
+			}
+		}
+	}
+	return current
+}
+
+// isTriggersStatusChanged checks if the triggers status changed beyond the metric values and the poll times
+func isTriggersStatusChanged(previous, current []kedav1alpha1.TriggerStatus) bool {
+	if len(previous) != len(current) {
+		return true
+	}
+	for i := range current {
+		p, c := previous[i], current[i]
+		if p.Name != c.Name || p.Type != c.Type || p.MetricName != c.MetricName || p.Active != c.Active ||
+			p.LastError != c.LastError || p.Fallback != c.Fallback {
+			return true
+		}
+		if (p.Target == nil) != (c.Target == nil) || (p.Target != nil && !p.Target.Equal(*c.Target)) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateTriggersStatus writes the triggers status of the ScaledObject along with the last scale done by KEDA or by the HPA,
+// the updates which only change the metric values and the poll times are throttled
+func (h *scaleHandler) updateTriggersStatus(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, triggers []kedav1alpha1.TriggerStatus) {
+	status := scaledObject.Status.DeepCopy()
+	status.Triggers = mergeTriggersStatus(scaledObject.Status.Triggers, triggers)
+	changed := isTriggersStatusChanged(scaledObject.Status.Triggers, status.Triggers)
+
+	if scaleTime, desiredReplicas, ok := h.scaleExecutor.PopLastScale(scaledObject); ok {
+		status.LastScaleTime = &scaleTime
+		status.DesiredReplicas = &desiredReplicas
+		changed = true
+	}
+
+	if status.HpaName != "" {
+		hpa := &v2.HorizontalPodAutoscaler{}
+		err := h.client.Get(ctx, types.NamespacedName{Name: status.HpaName, Namespace: scaledObject.Namespace}, hpa)
+		if err != nil {
+			logger.V(1).Info("Unable to get the HPA to read its last scale", "hpa", status.HpaName, "error", err.Error())
+		} else if hpa.Status.LastScaleTime != nil && (status.LastScaleTime == nil || status.LastScaleTime.Before(hpa.Status.LastScaleTime)) {
+			desiredReplicas := hpa.Status.DesiredReplicas
+			status.LastScaleTime = hpa.Status.LastScaleTime.DeepCopy()
+			status.DesiredReplicas = &desiredReplicas
+			changed = true
+		}
+	}
+
+	key := scaledObject.GenerateIdentifier()
+	if !changed {
+		if lastUpdate, ok := h.triggersStatusUpdates.Load(key); ok && time.Since(lastUpdate.(time.Time)) < triggersStatusUpdateInterval {
+			return
+		}
+	}
+
+	if err := kedastatus.UpdateScaledObjectStatus(ctx, h.client, logger, scaledObject, status); err != nil {
+		logger.Error(err, "error updating the triggers status")
+		return
+	}
+	h.triggersStatusUpdates.Store(key, time.Now())
+}
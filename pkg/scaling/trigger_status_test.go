@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_scaling/mock_executor"
+)
+
+func TestMetricTarget(t *testing.T) {
+	averageValue := resource.MustParse("10")
+	utilization := int32(50)
+
+	external := v2.MetricSpec{External: &v2.ExternalMetricSource{Target: v2.MetricTarget{Type: v2.AverageValueMetricType, AverageValue: &averageValue}}}
+	assert.Equal(t, "10", metricTarget(external).String())
+
+	cpu := v2.MetricSpec{Resource: &v2.ResourceMetricSource{Target: v2.MetricTarget{Type: v2.UtilizationMetricType, AverageUtilization: &utilization}}}
+	assert.Equal(t, "50", metricTarget(cpu).String())
+
+	assert.Nil(t, metricTarget(v2.MetricSpec{}))
+}
+
+func TestMergeTriggersStatus(t *testing.T) {
+	pollTime := metav1.NewTime(time.Now().Add(-time.Minute))
+	value := resource.MustParse("5")
+	previous := []kedav1alpha1.TriggerStatus{
+		{Name: "queue", Type: "rabbitmq", Value: &value, LastSuccessfulPollTime: &pollTime},
+		{Type: "cron", Value: &value, LastSuccessfulPollTime: &pollTime},
+	}
+	current := []kedav1alpha1.TriggerStatus{
+		{Name: "queue", Type: "rabbitmq", LastError: "connection refused"},
+		{Type: "kafka", LastError: "connection refused"},
+	}
+
+	merged := mergeTriggersStatus(previous, current)
+	assert.Equal(t, &pollTime, merged[0].LastSuccessfulPollTime)
+	assert.Equal(t, &value, merged[0].Value)
+	assert.Nil(t, merged[1].LastSuccessfulPollTime, "the status of a different trigger must not be kept")
+	assert.Nil(t, merged[1].Value)
+}
+
+func TestIsTriggersStatusChanged(t *testing.T) {
+	target := resource.MustParse("10")
+	otherTarget := resource.MustParse("20")
+	value := resource.MustParse("5")
+	otherValue := resource.MustParse("6")
+	pollTime := metav1.Now()
+	previous := []kedav1alpha1.TriggerStatus{{Type: "rabbitmq", MetricName: "s0-rabbitmq", Target: &target, Value: &value}}
+
+	assert.False(t, isTriggersStatusChanged(previous, []kedav1alpha1.TriggerStatus{{Type: "rabbitmq", MetricName: "s0-rabbitmq", Target: &target, Value: &otherValue, LastSuccessfulPollTime: &pollTime}}))
+	assert.True(t, isTriggersStatusChanged(previous, []kedav1alpha1.TriggerStatus{{Type: "rabbitmq", MetricName: "s0-rabbitmq", Target: &otherTarget, Value: &value}}))
+	assert.True(t, isTriggersStatusChanged(previous, []kedav1alpha1.TriggerStatus{{Type: "rabbitmq", MetricName: "s0-rabbitmq", Target: &target, Active: true}}))
+	assert.True(t, isTriggersStatusChanged(previous, []kedav1alpha1.TriggerStatus{{Type: "rabbitmq", MetricName: "s0-rabbitmq", Target: &target, LastError: "error"}}))
+	assert.True(t, isTriggersStatusChanged(previous, nil))
+}
+
+func TestUpdateTriggersStatusIsThrottled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_client.NewMockClient(ctrl)
+	mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
+	mockExecutor := mock_executor.NewMockScaleExecutor(ctrl)
+	mockExecutor.EXPECT().PopLastScale(gomock.Any()).AnyTimes()
+	sh := scaleHandler{client: mockClient, scaleExecutor: mockExecutor}
+
+	target := resource.MustParse("10")
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: testNameGlobal, Namespace: testNamespaceGlobal},
+	}
+	triggers := func(value string) []kedav1alpha1.TriggerStatus {
+		v := resource.MustParse(value)
+		now := metav1.Now()
+		return []kedav1alpha1.TriggerStatus{{Type: "rabbitmq", MetricName: "s0-rabbitmq", Target: &target, Value: &v, LastSuccessfulPollTime: &now}}
+	}
+
+	// the first status is written right away
+	mockClient.EXPECT().Status().Return(mockStatusWriter)
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	sh.updateTriggersStatus(context.TODO(), logr.Discard(), scaledObject, triggers("1"))
+	assert.Len(t, scaledObject.Status.Triggers, 1)
+
+	// a new metric value is throttled
+	sh.updateTriggersStatus(context.TODO(), logr.Discard(), scaledObject, triggers("2"))
+	assert.Equal(t, "1", scaledObject.Status.Triggers[0].Value.String())
+
+	// a trigger becoming active is written right away
+	active := triggers("3")
+	active[0].Active = true
+	mockClient.EXPECT().Status().Return(mockStatusWriter)
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	sh.updateTriggersStatus(context.TODO(), logr.Discard(), scaledObject, active)
+	assert.True(t, scaledObject.Status.Triggers[0].Active)
+
+	// once the interval elapsed the metric value is written again
+	sh.triggersStatusUpdates.Store(scaledObject.GenerateIdentifier(), time.Now().Add(-triggersStatusUpdateInterval))
+	active = triggers("4")
+	active[0].Active = true
+	mockClient.EXPECT().Status().Return(mockStatusWriter)
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	sh.updateTriggersStatus(context.TODO(), logr.Discard(), scaledObject, active)
+	assert.Equal(t, "4", scaledObject.Status.Triggers[0].Value.String())
+}
+
+func TestUpdateTriggersStatusWritesLastScale(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_client.NewMockClient(ctrl)
+	mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
+	mockExecutor := mock_executor.NewMockScaleExecutor(ctrl)
+	sh := scaleHandler{client: mockClient, scaleExecutor: mockExecutor}
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: testNameGlobal, Namespace: testNamespaceGlobal},
+	}
+	triggers := []kedav1alpha1.TriggerStatus{{Type: "cron", MetricName: "s0-cron"}}
+
+	mockExecutor.EXPECT().PopLastScale(gomock.Any())
+	mockClient.EXPECT().Status().Return(mockStatusWriter)
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	sh.updateTriggersStatus(context.TODO(), logr.Discard(), scaledObject, triggers)
+
+	// a scale done by KEDA is written right away along with the throttled triggers status
+	scaleTime := metav1.Now()
+	mockExecutor.EXPECT().PopLastScale(gomock.Any()).Return(scaleTime, int32(3), true)
+	mockClient.EXPECT().Status().Return(mockStatusWriter)
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	sh.updateTriggersStatus(context.TODO(), logr.Discard(), scaledObject, triggers)
+	assert.Equal(t, &scaleTime, scaledObject.Status.LastScaleTime)
+	assert.Equal(t, int32(3), *scaledObject.Status.DesiredReplicas)
+}
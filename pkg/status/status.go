@@ -42,6 +42,10 @@ func SetStatusConditions(ctx context.Context, client runtimeclient.StatusClient,
 			obj.Status.Conditions = *conditions
 		case *kedav1alpha1.ScaledJob:
 			obj.Status.Conditions = *conditions
+		case *kedav1alpha1.ScaledGroup:
+			obj.Status.Conditions = *conditions
+		case *kedav1alpha1.ScaledObjectSet:
+			obj.Status.Conditions = *conditions
 		case *eventingv1alpha1.CloudEventSource:
 			obj.Status.Conditions = *conditions
 		default:
@@ -68,6 +72,57 @@ func UpdateScaledObjectStatus(ctx context.Context, client runtimeclient.StatusCl
 	return TransformObject(ctx, client, logger, scaledObject, status, transform)
 }
 
+// UpdateScaledJobRolloutStatus patches the given ScaledJob with the updated rollout status passed to it or returns an error.
+func UpdateScaledJobRolloutStatus(ctx context.Context, client runtimeclient.StatusClient, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, rollout *kedav1alpha1.ScaledJobRolloutStatus) error {
+	transform := func(runtimeObj runtimeclient.Object, target interface{}) error {
+		rollout, ok := target.(*kedav1alpha1.ScaledJobRolloutStatus)
+		if !ok {
+			return fmt.Errorf("transform target is not kedav1alpha1.ScaledJobRolloutStatus type %v", target)
+		}
+		switch obj := runtimeObj.(type) {
+		case *kedav1alpha1.ScaledJob:
+			obj.Status.Rollout = rollout
+		default:
+		}
+		return nil
+	}
+	return TransformObject(ctx, client, logger, scaledJob, rollout, transform)
+}
+
+// UpdateScaledGroupStatus patches the given ScaledGroup with the updated status passed to it or returns an error.
+func UpdateScaledGroupStatus(ctx context.Context, client runtimeclient.StatusClient, logger logr.Logger, scaledGroup *kedav1alpha1.ScaledGroup, status *kedav1alpha1.ScaledGroupStatus) error {
+	transform := func(runtimeObj runtimeclient.Object, target interface{}) error {
+		status, ok := target.(*kedav1alpha1.ScaledGroupStatus)
+		if !ok {
+			return fmt.Errorf("transform target is not kedav1alpha1.ScaledGroupStatus type %v", target)
+		}
+		switch obj := runtimeObj.(type) {
+		case *kedav1alpha1.ScaledGroup:
+			obj.Status = *status
+		default:
+		}
+		return nil
+	}
+	return TransformObject(ctx, client, logger, scaledGroup, status, transform)
+}
+
+// UpdateScaledObjectSetStatus patches the given ScaledObjectSet with the updated status passed to it or returns an error.
+func UpdateScaledObjectSetStatus(ctx context.Context, client runtimeclient.StatusClient, logger logr.Logger, scaledObjectSet *kedav1alpha1.ScaledObjectSet, status *kedav1alpha1.ScaledObjectSetStatus) error {
+	transform := func(runtimeObj runtimeclient.Object, target interface{}) error {
+		status, ok := target.(*kedav1alpha1.ScaledObjectSetStatus)
+		if !ok {
+			return fmt.Errorf("transform target is not kedav1alpha1.ScaledObjectSetStatus type %v", target)
+		}
+		switch obj := runtimeObj.(type) {
+		case *kedav1alpha1.ScaledObjectSet:
+			obj.Status = *status
+		default:
+		}
+		return nil
+	}
+	return TransformObject(ctx, client, logger, scaledObjectSet, status, transform)
+}
+
 // getTriggerAuth returns TriggerAuthentication/ClusterTriggerAuthentication object and its status from AuthenticationRef or returns an error.
 func getTriggerAuth(ctx context.Context, client runtimeclient.Client, triggerAuthRef *kedav1alpha1.AuthenticationRef, namespace string) (runtimeclient.Object, *kedav1alpha1.TriggerAuthenticationStatus, error) {
 	if triggerAuthRef == nil {
@@ -76,7 +131,7 @@ func getTriggerAuth(ctx context.Context, client runtimeclient.Client, triggerAut
 
 	if triggerAuthRef.Kind == "" || triggerAuthRef.Kind == "TriggerAuthentication" {
 		triggerAuth := &kedav1alpha1.TriggerAuthentication{}
-		err := client.Get(ctx, types.NamespacedName{Name: triggerAuthRef.Name, Namespace: namespace}, triggerAuth)
+		err := client.Get(ctx, types.NamespacedName{Name: triggerAuthRef.Name, Namespace: triggerAuthRef.GetNamespace(namespace)}, triggerAuth)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -166,6 +221,18 @@ func TransformObject(ctx context.Context, client runtimeclient.StatusClient, log
 			logger.Error(err, "failed to patch ScaledJob")
 			return err
 		}
+	case *kedav1alpha1.ScaledGroup:
+		patch = runtimeclient.MergeFrom(obj.DeepCopy())
+		if err := transform(obj, target); err != nil {
+			logger.Error(err, "failed to patch ScaledGroup")
+			return err
+		}
+	case *kedav1alpha1.ScaledObjectSet:
+		patch = runtimeclient.MergeFrom(obj.DeepCopy())
+		if err := transform(obj, target); err != nil {
+			logger.Error(err, "failed to patch ScaledObjectSet")
+			return err
+		}
 	case *kedav1alpha1.TriggerAuthentication:
 		patch = runtimeclient.MergeFrom(obj.DeepCopy())
 		if err := transform(obj, target); err != nil {
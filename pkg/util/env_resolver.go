@@ -86,6 +86,11 @@ func GetPodNamespace() string {
 	return ns
 }
 
+// GetPodName returns the name of the pod or an empty string when it isn't known
+func GetPodName() string {
+	return os.Getenv("POD_NAME")
+}
+
 // GetRestrictSecretAccess retrieves the value of the environment variable of KEDA_RESTRICT_SECRET_ACCESS
 func GetRestrictSecretAccess() string {
 	return os.Getenv(RestrictSecretAccessEnvVar)
@@ -1,18 +1,27 @@
 package util
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
+const (
+	WatchNamespaceEnvVar         = "WATCH_NAMESPACE"
+	WatchNamespaceSelectorEnvVar = "WATCH_NAMESPACE_SELECTOR"
+)
+
 // GetWatchNamespaces returns the namespaces the operator should be watching for changes
 func GetWatchNamespaces() (map[string]cache.Config, error) {
-	const WatchNamespaceEnvVar = "WATCH_NAMESPACE"
 	ns, found := os.LookupEnv(WatchNamespaceEnvVar)
 	if !found {
 		return map[string]cache.Config{}, fmt.Errorf("%s must be set", WatchNamespaceEnvVar)
@@ -30,6 +39,20 @@ func GetWatchNamespaces() (map[string]cache.Config, error) {
 	return nssMap, nil
 }
 
+// GetWatchNamespaceSelector returns the label selector of the namespaces the operator should be watching for changes,
+// nil when the namespaces aren't selected by their labels
+func GetWatchNamespaceSelector() (labels.Selector, error) {
+	selector := os.Getenv(WatchNamespaceSelectorEnvVar)
+	if selector == "" || selector == "\"\"" {
+		return nil, nil
+	}
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", WatchNamespaceSelectorEnvVar, err)
+	}
+	return parsed, nil
+}
+
 // IgnoreOtherNamespaces returns the predicate for watched events that will filter out those that are not coming
 // from a watched namespace (empty namespace or unset env var denotes all)
 func IgnoreOtherNamespaces() predicate.Predicate {
@@ -44,3 +67,63 @@ func IgnoreOtherNamespaces() predicate.Predicate {
 		},
 	}
 }
+
+// WatchScope tells whether a namespace is watched, from the WATCH_NAMESPACE list and the labels of the namespace
+// matching the WATCH_NAMESPACE_SELECTOR, read from the cache so labelling a namespace changes the scope right away.
+// A nil WatchScope watches all the namespaces.
+type WatchScope struct {
+	namespaces map[string]cache.Config
+	selector   labels.Selector
+	reader     client.Reader
+}
+
+// NewWatchScope returns the WatchScope of the namespaces in the list, all when it is empty, whose labels match the selector, if any
+func NewWatchScope(reader client.Reader, namespaces map[string]cache.Config, selector labels.Selector) *WatchScope {
+	return &WatchScope{
+		namespaces: namespaces,
+		selector:   selector,
+		reader:     reader,
+	}
+}
+
+// IsDynamic returns whether the scope changes with the labels of the namespaces
+func (s *WatchScope) IsDynamic() bool {
+	return s != nil && s.selector != nil
+}
+
+// Contains returns whether the namespace is watched
+func (s *WatchScope) Contains(ctx context.Context, namespace string) (bool, error) {
+	if s == nil {
+		return true, nil
+	}
+	if len(s.namespaces) > 0 {
+		if _, found := s.namespaces[namespace]; !found {
+			return false, nil
+		}
+	}
+	if s.selector == nil {
+		return true, nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := s.reader.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return s.selector.Matches(labels.Set(ns.GetLabels())), nil
+}
+
+// ScopeChangedPredicate returns the predicate for the events of the namespaces that only passes the updates
+// of the labels moving a namespace in or out of the scope
+func (s *WatchScope) ScopeChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if !s.IsDynamic() || e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			return s.selector.Matches(labels.Set(e.ObjectOld.GetLabels())) != s.selector.Matches(labels.Set(e.ObjectNew.GetLabels()))
+		},
+	}
+}
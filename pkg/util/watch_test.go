@@ -0,0 +1,77 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestGetWatchNamespaceSelector(t *testing.T) {
+	selector, err := GetWatchNamespaceSelector()
+	assert.NoError(t, err)
+	assert.Nil(t, selector)
+
+	t.Setenv(WatchNamespaceSelectorEnvVar, "keda.sh/managed=true")
+	selector, err = GetWatchNamespaceSelector()
+	assert.NoError(t, err)
+	assert.Equal(t, "keda.sh/managed=true", selector.String())
+
+	t.Setenv(WatchNamespaceSelectorEnvVar, "keda.sh/managed in")
+	_, err = GetWatchNamespaceSelector()
+	assert.Error(t, err)
+}
+
+func TestWatchScopeContains(t *testing.T) {
+	reader := fake.NewClientBuilder().WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "managed", Labels: map[string]string{"keda.sh/managed": "true"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "unmanaged"}},
+	).Build()
+	selector := labels.SelectorFromSet(labels.Set{"keda.sh/managed": "true"})
+
+	tests := []struct {
+		name      string
+		scope     *WatchScope
+		namespace string
+		expected  bool
+	}{
+		{"nil scope", nil, "unmanaged", true},
+		{"all namespaces", NewWatchScope(reader, map[string]cache.Config{}, nil), "unmanaged", true},
+		{"listed namespace", NewWatchScope(reader, map[string]cache.Config{"unmanaged": {}}, nil), "unmanaged", true},
+		{"unlisted namespace", NewWatchScope(reader, map[string]cache.Config{"managed": {}}, nil), "unmanaged", false},
+		{"selected namespace", NewWatchScope(reader, map[string]cache.Config{}, selector), "managed", true},
+		{"unselected namespace", NewWatchScope(reader, map[string]cache.Config{}, selector), "unmanaged", false},
+		{"selected but unlisted namespace", NewWatchScope(reader, map[string]cache.Config{"unmanaged": {}}, selector), "managed", false},
+		{"missing namespace", NewWatchScope(reader, map[string]cache.Config{}, selector), "missing", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			inScope, err := test.scope.Contains(context.Background(), test.namespace)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, inScope)
+		})
+	}
+}
+
+func TestWatchScopeChangedPredicate(t *testing.T) {
+	selector := labels.SelectorFromSet(labels.Set{"keda.sh/managed": "true"})
+	managed := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test", Labels: map[string]string{"keda.sh/managed": "true"}}}
+	unmanaged := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test", Labels: map[string]string{"team": "a"}}}
+	relabelled := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test", Labels: map[string]string{"keda.sh/managed": "true", "team": "a"}}}
+
+	pred := NewWatchScope(nil, map[string]cache.Config{}, selector).ScopeChangedPredicate()
+	assert.True(t, pred.Update(event.UpdateEvent{ObjectOld: unmanaged, ObjectNew: managed}))
+	assert.True(t, pred.Update(event.UpdateEvent{ObjectOld: managed, ObjectNew: unmanaged}))
+	assert.False(t, pred.Update(event.UpdateEvent{ObjectOld: managed, ObjectNew: relabelled}))
+	assert.False(t, pred.Create(event.CreateEvent{Object: managed}))
+
+	static := NewWatchScope(nil, map[string]cache.Config{}, nil).ScopeChangedPredicate()
+	assert.False(t, static.Update(event.UpdateEvent{ObjectOld: unmanaged, ObjectNew: managed}))
+}